@@ -0,0 +1,274 @@
+// Package cache provides a disk-backed cache of Veracode API responses.
+//
+// Client wraps the API client behind the services' HTTPClient interface. GET
+// responses are stored on disk keyed by method, path and query parameters:
+//
+//   - A fresh response (younger than its endpoint's TTL) is returned without a request.
+//   - A stale response is returned immediately and refreshed in the background;
+//     listeners are told when the refresh completes so the view can reload.
+//   - If the refresh fails (for example without network), the stale response is kept.
+//   - Without stale serving (for commands that exit when done), a stale response is
+//     refreshed before returning instead.
+//   - In offline mode only cached responses are returned and writes are refused.
+//
+// Successful writes invalidate the cached responses of the written resource and of the
+// collection it belongs to, and for a resource of an application, every cached response of
+// that application in the Applications and Findings APIs.
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrOffline is returned for requests that cannot be served in offline mode
+var ErrOffline = errors.New("not available offline")
+
+// Upstream is the API client whose responses are cached
+type Upstream interface {
+	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error)
+}
+
+// EventKind describes what happened to a cached response
+type EventKind int
+
+const (
+	// EventStale means a stale response was served and a background refresh started
+	EventStale EventKind = iota
+	// EventRefreshed means a background refresh completed
+	EventRefreshed
+	// EventRefreshFailed means a background refresh failed; the stale response remains
+	EventRefreshFailed
+	// EventCached means a cached response was served in offline mode
+	EventCached
+)
+
+// Event is sent to the listener when cached data is served or refreshed
+type Event struct {
+	Kind      EventKind
+	Path      string
+	FetchedAt time.Time // When the served or refreshed response was fetched from the API
+	Changed   bool      // For EventRefreshed, whether the response differs from the cached one
+	Err       error     // For EventRefreshFailed, the error from the API
+}
+
+// Client caches the responses of an Upstream client
+type Client struct {
+	upstream Upstream
+	store    *Store
+	rules    []TTLRule
+	offline  bool
+	noStale  bool
+	now      func() time.Time
+
+	mu       sync.Mutex
+	listener func(Event)
+	inflight map[string]bool
+	wg       sync.WaitGroup
+}
+
+// NewClient returns a caching client in front of upstream using the default TTL rules
+func NewClient(upstream Upstream, store *Store) *Client {
+	return &Client{
+		upstream: upstream,
+		store:    store,
+		rules:    DefaultTTLRules,
+		now:      time.Now,
+		inflight: make(map[string]bool),
+	}
+}
+
+// SetOffline switches offline mode, in which only cached responses are served
+func (c *Client) SetOffline(offline bool) {
+	c.offline = offline
+}
+
+// Offline reports whether the client is in offline mode
+func (c *Client) Offline() bool {
+	return c.offline
+}
+
+// SetServeStale chooses whether stale responses are returned while they refresh in the
+// background (the default) or are refreshed before returning
+func (c *Client) SetServeStale(serveStale bool) {
+	c.noStale = !serveStale
+}
+
+// SetTTLRules replaces the TTL rules
+func (c *Client) SetTTLRules(rules []TTLRule) {
+	c.rules = rules
+}
+
+// Invalidate removes the cached responses whose request path starts with prefix, so that the
+// next request for them is fetched from the API
+func (c *Client) Invalidate(prefix string) error {
+	return c.store.InvalidatePrefix(prefix)
+}
+
+// SetListener registers a function called (on its own goroutine) for each cache event
+func (c *Client) SetListener(listener func(Event)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listener = listener
+}
+
+func (c *Client) notify(event Event) {
+	c.mu.Lock()
+	listener := c.listener
+	c.mu.Unlock()
+	if listener != nil {
+		go listener(event)
+	}
+}
+
+// requestKey identifies a request by method, path and (sorted) query parameters
+func requestKey(method, urlPath string, params url.Values) string {
+	key := strings.ToUpper(method) + " " + urlPath
+	if len(params) > 0 {
+		key += "?" + params.Encode()
+	}
+	return key
+}
+
+// DoRequestWithQueryParams serves GET requests from the cache where possible
+func (c *Client) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if !strings.EqualFold(method, "GET") {
+		if c.offline {
+			return nil, fmt.Errorf("%s %s: %w", method, urlPath, ErrOffline)
+		}
+		return c.upstream.DoRequestWithQueryParams(method, urlPath, params)
+	}
+
+	key := requestKey(method, urlPath, params)
+	entry, err := c.store.Get(key)
+	if err != nil {
+		// A corrupt entry is treated as a miss
+		entry = nil
+	}
+
+	if c.offline {
+		if entry == nil {
+			return nil, fmt.Errorf("no cached response for %s: %w", urlPath, ErrOffline)
+		}
+		c.notify(Event{Kind: EventCached, Path: urlPath, FetchedAt: entry.FetchedAt})
+		return entry.Body, nil
+	}
+
+	if entry != nil {
+		if c.now().Sub(entry.FetchedAt) < ttlFor(c.rules, urlPath) {
+			return entry.Body, nil
+		}
+		if c.noStale {
+			return c.fetch(key, method, urlPath, params)
+		}
+		if c.startRefresh(key, method, urlPath, params, entry) {
+			c.notify(Event{Kind: EventStale, Path: urlPath, FetchedAt: entry.FetchedAt})
+		}
+		return entry.Body, nil
+	}
+
+	return c.fetch(key, method, urlPath, params)
+}
+
+// fetch requests a response from upstream and caches it
+func (c *Client) fetch(key, method, urlPath string, params url.Values) ([]byte, error) {
+	body, err := c.upstream.DoRequestWithQueryParams(method, urlPath, params)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, urlPath, body)
+	return body, nil
+}
+
+// DoRequestWithBody passes writes through and invalidates the cached responses they affect
+func (c *Client) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	if c.offline {
+		return nil, fmt.Errorf("%s %s: %w", method, urlPath, ErrOffline)
+	}
+
+	respBody, err := c.upstream.DoRequestWithBody(method, urlPath, body, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Best effort: a stale entry is refreshed once its TTL expires anyway
+	_ = c.store.invalidate(func(entryPath string) bool {
+		return writeInvalidates(urlPath, entryPath)
+	})
+	return respBody, nil
+}
+
+// applicationResource matches the paths of an application and its resources in the Applications
+// (v1) and Findings (v2) APIs, capturing the application GUID
+var applicationResource = regexp.MustCompile(`^/appsec/v[12]/applications/([^/]+)`)
+
+// writeInvalidates reports whether a write to urlPath can change the cached response for
+// entryPath: the written resource itself, the collection listing it, and anything of the same
+// application, whose profile, compliance, sandboxes, findings and summary report follow from
+// its scans and mitigations. Other collections, such as policies, are left in place.
+func writeInvalidates(urlPath, entryPath string) bool {
+	if entryPath == urlPath || entryPath == path.Dir(urlPath) {
+		return true
+	}
+	m := applicationResource.FindStringSubmatch(urlPath)
+	if m == nil {
+		return false
+	}
+	for _, root := range []string{"/appsec/v1/applications/" + m[1], "/appsec/v2/applications/" + m[1]} {
+		if entryPath == root || strings.HasPrefix(entryPath, root+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// startRefresh fetches a stale response in the background, unless a refresh of the same key is
+// already running. It reports whether a refresh was started.
+func (c *Client) startRefresh(key, method, urlPath string, params url.Values, stale *Entry) bool {
+	c.mu.Lock()
+	if c.inflight[key] {
+		c.mu.Unlock()
+		return false
+	}
+	c.inflight[key] = true
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.inflight, key)
+			c.mu.Unlock()
+		}()
+
+		body, err := c.upstream.DoRequestWithQueryParams(method, urlPath, params)
+		if err != nil {
+			c.notify(Event{Kind: EventRefreshFailed, Path: urlPath, FetchedAt: stale.FetchedAt, Err: err})
+			return
+		}
+		entry := c.put(key, urlPath, body)
+		c.notify(Event{Kind: EventRefreshed, Path: urlPath, FetchedAt: entry.FetchedAt, Changed: !bytes.Equal(body, stale.Body)})
+	}()
+	return true
+}
+
+// put stores a fresh response; failures to write the cache do not fail the request
+func (c *Client) put(key, urlPath string, body []byte) *Entry {
+	entry := &Entry{Key: key, Path: urlPath, FetchedAt: c.now(), Body: body}
+	_ = c.store.Put(entry)
+	return entry
+}
+
+// wait blocks until background refreshes have finished
+func (c *Client) wait() {
+	c.wg.Wait()
+}
@@ -0,0 +1,297 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeUpstream returns a numbered response for each request and records the requests
+type fakeUpstream struct {
+	mu       sync.Mutex
+	requests []string
+	fail     error
+}
+
+func (f *fakeUpstream) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail != nil {
+		return nil, f.fail
+	}
+	f.requests = append(f.requests, urlPath)
+	return []byte(fmt.Sprintf(`{"response": %d}`, len(f.requests))), nil
+}
+
+func (f *fakeUpstream) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, method+" "+urlPath)
+	return []byte(`{}`), nil
+}
+
+func (f *fakeUpstream) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeUpstream, *time.Time) {
+	t.Helper()
+	upstream := &fakeUpstream{}
+	client := NewClient(upstream, NewStore(t.TempDir()))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	return client, upstream, &now
+}
+
+func TestFreshResponseIsServedFromCache(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+	params := url.Values{"size": {"100"}, "page": {"0"}}
+
+	first, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", params)
+	if err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	// Same parameters in a different order share the cache entry
+	second, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", url.Values{"page": {"0"}, "size": {"100"}})
+	if err != nil {
+		t.Fatalf("Second request failed: %v", err)
+	}
+
+	if string(first) != string(second) || upstream.count() != 1 {
+		t.Errorf("Expected one upstream request, got %d", upstream.count())
+	}
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", url.Values{"page": {"1"}}); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if upstream.count() != 2 {
+		t.Errorf("Expected different parameters to miss the cache, got %d requests", upstream.count())
+	}
+}
+
+func TestStaleResponseIsServedAndRefreshed(t *testing.T) {
+	client, upstream, now := newTestClient(t)
+	events := make(chan Event, 4)
+	client.SetListener(func(e Event) { events <- e })
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	*now = now.Add(5*time.Minute + time.Second)
+	body, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	if err != nil {
+		t.Fatalf("Stale request failed: %v", err)
+	}
+	if string(body) != `{"response": 1}` {
+		t.Errorf("Expected the stale response, got %s", body)
+	}
+
+	client.wait()
+	if upstream.count() != 2 {
+		t.Errorf("Expected a background refresh, got %d requests", upstream.count())
+	}
+
+	kinds := map[EventKind]Event{}
+	for i := 0; i < 2; i++ {
+		select {
+		case e := <-events:
+			kinds[e.Kind] = e
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for cache events")
+		}
+	}
+	if _, ok := kinds[EventStale]; !ok {
+		t.Error("Expected a stale event")
+	}
+	if refreshed, ok := kinds[EventRefreshed]; !ok || !refreshed.Changed {
+		t.Errorf("Expected a changed refresh event, got %+v", kinds)
+	}
+
+	body, _ = client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	if string(body) != `{"response": 2}` {
+		t.Errorf("Expected the refreshed response, got %s", body)
+	}
+}
+
+func TestFailedRefreshKeepsStaleResponse(t *testing.T) {
+	client, upstream, now := newTestClient(t)
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	upstream.fail = errors.New("no network")
+	*now = now.Add(time.Hour)
+	body, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	client.wait()
+	if err != nil || string(body) != `{"response": 1}` {
+		t.Errorf("Expected the stale response, got %s, %v", body, err)
+	}
+}
+
+func TestStaleResponseIsRefreshedWithoutStaleServing(t *testing.T) {
+	client, upstream, now := newTestClient(t)
+	client.SetServeStale(false)
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	body, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	if err != nil || string(body) != `{"response": 1}` || upstream.count() != 1 {
+		t.Errorf("Expected the fresh response from the cache, got %s, %v", body, err)
+	}
+
+	*now = now.Add(time.Hour)
+	body, err = client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	if err != nil || string(body) != `{"response": 2}` {
+		t.Errorf("Expected the stale response to be refreshed before returning, got %s, %v", body, err)
+	}
+}
+
+func TestOfflineMode(t *testing.T) {
+	client, upstream, now := newTestClient(t)
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	client.SetOffline(true)
+	*now = now.Add(30 * 24 * time.Hour)
+
+	body, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+	if err != nil || string(body) != `{"response": 1}` {
+		t.Errorf("Expected the cached response offline, got %s, %v", body, err)
+	}
+
+	if _, err := client.DoRequestWithQueryParams("GET", "/appsec/v1/applications/other", nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected ErrOffline for an uncached request, got %v", err)
+	}
+	if _, err := client.DoRequestWithBody("POST", "/appsec/v2/applications/a/annotations", nil, nil); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected ErrOffline for a write, got %v", err)
+	}
+	if upstream.count() != 1 {
+		t.Errorf("Expected no upstream requests offline, got %d", upstream.count())
+	}
+}
+
+func TestWriteInvalidatesRelatedResponses(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v2/applications/app-1/findings", nil)
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+
+	if _, err := client.DoRequestWithBody("POST", "/appsec/v2/applications/app-1/annotations", []byte(`{}`), nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v2/applications/app-1/findings", nil)
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v1/applications", nil)
+
+	// findings refetched, application list still cached
+	if upstream.count() != 4 {
+		t.Errorf("Expected 4 upstream requests, got %d: %v", upstream.count(), upstream.requests)
+	}
+}
+
+func TestPromoteInvalidatesApplication(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+	reads := []string{
+		"/appsec/v1/applications/app-1",
+		"/appsec/v1/applications/app-1/sandboxes",
+		"/appsec/v2/applications/app-1/findings",
+		"/appsec/v2/applications/app-1/summary_report",
+		"/appsec/v1/applications/app-10",
+	}
+	readAll := func() {
+		for _, p := range reads {
+			_, _ = client.DoRequestWithQueryParams("GET", p, nil)
+		}
+	}
+
+	readAll()
+	if _, err := client.DoRequestWithBody("POST", "/appsec/v1/applications/app-1/sandboxes/sb-1/promote", nil, nil); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+
+	// app-1 responses refetched, app-10 still cached
+	readAll()
+	if upstream.count() != 10 {
+		t.Errorf("Expected 10 upstream requests, got %d: %v", upstream.count(), upstream.requests)
+	}
+}
+
+func TestDeleteApplicationInvalidatesFindings(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v2/applications/app-1/findings", nil)
+	if _, err := client.DoRequestWithBody("DELETE", "/appsec/v1/applications/app-1", nil, nil); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	_, _ = client.DoRequestWithQueryParams("GET", "/appsec/v2/applications/app-1/findings", nil)
+
+	if upstream.count() != 3 || upstream.requests[2] != "/appsec/v2/applications/app-1/findings" {
+		t.Errorf("Expected the findings to be refetched, got %v", upstream.requests)
+	}
+}
+
+func TestCreateApplicationKeepsPolicies(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+	reads := []string{"/appsec/v1/applications", "/appsec/v1/policies", "/appsec/v1/applications/app-1"}
+	for _, p := range reads {
+		_, _ = client.DoRequestWithQueryParams("GET", p, nil)
+	}
+
+	if _, err := client.DoRequestWithBody("POST", "/appsec/v1/applications", []byte(`{}`), nil); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	for _, p := range reads {
+		_, _ = client.DoRequestWithQueryParams("GET", p, nil)
+	}
+
+	// Only the application list is refetched
+	if upstream.count() != 5 || upstream.requests[4] != "/appsec/v1/applications" {
+		t.Errorf("Expected only the application list to be refetched, got %v", upstream.requests)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	client, upstream, _ := newTestClient(t)
+
+	_, _ = client.DoRequestWithQueryParams("GET", "/api/authn/v2/principal", nil)
+	_, _ = client.DoRequestWithQueryParams("GET", "/api/authn/v2/api_credentials", nil)
+
+	if err := client.Invalidate("/api/authn/v2/principal"); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+
+	_, _ = client.DoRequestWithQueryParams("GET", "/api/authn/v2/principal", nil)
+	_, _ = client.DoRequestWithQueryParams("GET", "/api/authn/v2/api_credentials", nil)
+
+	// principal refetched, credentials still cached
+	if upstream.count() != 3 {
+		t.Errorf("Expected 3 upstream requests, got %d: %v", upstream.count(), upstream.requests)
+	}
+}
+
+func TestTTLFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/appsec/v1/applications", 5 * time.Minute},
+		{"/appsec/v1/applications/abc/sandboxes", 10 * time.Minute},
+		{"/appsec/v2/applications/abc/findings/12/static_flaw_info", 24 * time.Hour},
+		{"/somewhere/else", DefaultTTL},
+	}
+	for _, tt := range tests {
+		if got := ttlFor(DefaultTTLRules, tt.path); got != tt.want {
+			t.Errorf("ttlFor(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a cached API response
+type Entry struct {
+	Key       string    `json:"key"`
+	Path      string    `json:"path"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Body      []byte    `json:"body"`
+}
+
+// Store keeps cached responses on disk, one file per request key
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store that keeps its files in dir. The directory is created on first write.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Namespace returns a directory name for the cache of an API key, so that
+// responses fetched with one set of credentials are never shown for another
+func Namespace(apiKeyID string) string {
+	sum := sha256.Sum256([]byte(apiKeyID))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *Store) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the entry for a key, or nil if it is not cached
+func (s *Store) Get(key string) (*Entry, error) {
+	data, err := os.ReadFile(s.file(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cache entry: %w", err)
+	}
+	// Guard against hash collisions and hand-edited files
+	if entry.Key != key {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// Put stores an entry, replacing any previous entry for the same key
+func (s *Store) Put(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file and rename so that readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.file(entry.Key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+// InvalidatePrefix removes all entries whose request path starts with prefix
+func (s *Store) InvalidatePrefix(prefix string) error {
+	return s.invalidate(func(entryPath string) bool {
+		return strings.HasPrefix(entryPath, prefix)
+	})
+}
+
+// invalidate removes all entries whose request path matches
+func (s *Store) invalidate(match func(entryPath string) bool) error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil || match(entry.Path) {
+			// Unreadable entries are removed too
+			_ = os.Remove(file)
+		}
+	}
+	return nil
+}
+
+// Clear removes all cached entries
+func (s *Store) Clear() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
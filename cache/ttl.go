@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"path"
+	"time"
+)
+
+// TTLRule sets how long responses for matching paths are considered fresh.
+// Pattern uses path.Match syntax, so "*" matches a single path segment.
+type TTLRule struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// DefaultTTL applies to paths that match no rule
+const DefaultTTL = 2 * time.Minute
+
+// DefaultTTLRules lists the freshness of each endpoint used by the services, most specific first
+var DefaultTTLRules = []TTLRule{
+	// Static flaw data paths only change with a new scan
+	{Pattern: "/appsec/v2/applications/*/findings/*/static_flaw_info", TTL: 24 * time.Hour},
+	{Pattern: "/appsec/v2/applications/*/findings", TTL: 5 * time.Minute},
+	{Pattern: "/appsec/v2/applications/*/summary_report", TTL: 10 * time.Minute},
+	{Pattern: "/appsec/v1/applications/*/sandboxes/*", TTL: 10 * time.Minute},
+	{Pattern: "/appsec/v1/applications/*/sandboxes", TTL: 10 * time.Minute},
+	{Pattern: "/appsec/v1/applications/*", TTL: 5 * time.Minute},
+	{Pattern: "/appsec/v1/applications", TTL: 5 * time.Minute},
+	{Pattern: "/api/authn/v2/*", TTL: time.Hour},
+}
+
+// ttlFor returns the TTL of the first rule matching urlPath
+func ttlFor(rules []TTLRule, urlPath string) time.Duration {
+	for _, rule := range rules {
+		if matched, err := path.Match(rule.Pattern, urlPath); err == nil && matched {
+			return rule.TTL
+		}
+	}
+	return DefaultTTL
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/analytics"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/findings/query"
+)
+
+// parseDate parses a --since or --until value: a date such as 2025-06-01, or a number of days
+// before now such as 7d. An empty value is the zero time.
+func parseDate(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	date, err := time.ParseInLocation(analytics.DateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD or a number of days such as 7d", value)
+	}
+	return date, nil
+}
+
+// findingsUpdated runs a Reporting API job for the policy scan findings updated between from and
+// to, of one application when appID is set or of all applications otherwise. Rows of other scan
+// types, or that do not match the filter, are left out. Progress is written to stderr.
+func findingsUpdated(env *Env, from, to time.Time, appID int, scanTypes []string, filter *query.Query) ([]analytics.FindingRow, error) {
+	if env.Analytics == nil {
+		return nil, fmt.Errorf("the Reporting API is not available")
+	}
+
+	request := analytics.NewReportRequest(analytics.ReportFindings, from, to)
+	request.PolicySandbox = analytics.ContextPolicy
+	request.ApplicationID = appID
+	job := env.Analytics.NewJob(request)
+	job.OnProgress = func(p analytics.Progress) {
+		fmt.Fprintln(env.Stderr, p)
+	}
+	content, err := job.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []analytics.FindingRow
+	for _, row := range content.Findings {
+		f := row.Finding()
+		if !slices.Contains(scanTypes, string(f.ScanType)) || (filter != nil && !filter.Match(&f)) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// rowFindings converts report rows to findings
+func rowFindings(rows []analytics.FindingRow) []findings.Finding {
+	list := make([]findings.Finding, 0, len(rows))
+	for i := range rows {
+		list = append(list, rows[i].Finding())
+	}
+	return list
+}
@@ -0,0 +1,111 @@
+// Package cli implements the non-interactive subcommands of veracode-tui,
+// such as exporting findings for use in scripts and pipelines.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/analytics"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/dipsylala/veracode-tui/snapshot"
+)
+
+// Env holds the services, local stores and output streams available to commands
+type Env struct {
+	Applications *applications.Service
+	Findings     *findings.Service
+	Policies     *policies.Service
+	Analytics    *analytics.Service // Reporting API jobs, for findings updated in a date range
+	Identity     *identity.Service
+	// CachedApplications and CachedFindings reuse API responses younger than their TTL from the
+	// local cache, for commands that query every application. They are the same as Applications
+	// and Findings when caching is disabled.
+	CachedApplications *applications.Service
+	CachedFindings     *findings.Service
+	Bookmarks          *config.Bookmarks
+	Snapshots          *snapshot.Store
+	Stdout             io.Writer
+	Stderr             io.Writer
+	Version            string // veracode-tui version, recorded in generated documents
+	// ConfigPath is the veracode.yml file holding the API credentials. WriteCredentials replaces
+	// them (config.UpdateAPICredentials) and CheckCredentials runs a healthcheck with new ones.
+	ConfigPath       string
+	WriteCredentials func(configPath, keyID, keySecret string, now time.Time) (string, error)
+	CheckCredentials func(keyID, keySecret string) error
+}
+
+// Command is a non-interactive subcommand
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(env *Env, args []string) error
+}
+
+// commands lists the available subcommands in help order
+var commands = []*Command{
+	exportCommand,
+	snapshotCommand,
+	diffCommand,
+	gateCommand,
+	licensesCommand,
+	whereUsedCommand,
+	sbomCommand,
+	reportCommand,
+	rotateCredentialsCommand,
+}
+
+// Lookup returns the command with the given name, or nil if there is none
+func Lookup(name string) *Command {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// PrintUsage writes the list of commands to w
+func PrintUsage(w io.Writer) {
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  veracode-tui %-22s %s\n", cmd.Name+" [options]", cmd.Summary)
+	}
+}
+
+// Run executes the command named by args[0] and returns the process exit code
+func Run(env *Env, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(env.Stderr, "No command given")
+		return 2
+	}
+
+	cmd := Lookup(args[0])
+	if cmd == nil {
+		fmt.Fprintf(env.Stderr, "Unknown command %q. Available commands:\n", args[0])
+		PrintUsage(env.Stderr)
+		return 2
+	}
+
+	if err := cmd.Run(env, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(env.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newFlagSet creates a flag set for a command that reports errors to the command's stderr
+func newFlagSet(env *Env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	return fs
+}
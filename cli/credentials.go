@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var rotateCredentialsCommand = &Command{
+	Name:    "rotate-credentials",
+	Summary: "Generate new API credentials and save them to veracode.yml",
+	Run:     runRotateCredentials,
+}
+
+func runRotateCredentials(env *Env, args []string) error {
+	fs := newFlagSet(env, "rotate-credentials")
+	yes := fs.Bool("yes", false, "Rotate without asking; the current credentials stop working")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui rotate-credentials --yes")
+		fmt.Fprintln(env.Stderr)
+		fmt.Fprintln(env.Stderr, "Generates new API credentials for the current user, replaces the key-id and key-secret in")
+		fmt.Fprintln(env.Stderr, "veracode.yml (keeping a backup of the file) and checks that the new credentials work.")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if env.Identity == nil || env.ConfigPath == "" {
+		return fmt.Errorf("credential rotation is not available")
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	if !*yes {
+		current, err := env.Identity.GetAPICredentials(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the current API credentials: %w", err)
+		}
+		fmt.Fprintf(env.Stdout, "The current API credentials (%s) %s.\n", current.APIID, current.DescribeExpiry(now))
+		fmt.Fprintln(env.Stdout, "Rotating them revokes them immediately, including for any other tools that use them.")
+		return fmt.Errorf("run again with --yes to rotate the credentials")
+	}
+
+	creds, err := env.Identity.GenerateAPICredentials(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate API credentials: %w", err)
+	}
+
+	backup, err := env.WriteCredentials(env.ConfigPath, creds.APIID, creds.APISecret, now)
+	if err != nil {
+		// The secret cannot be retrieved again, so it must not be lost
+		fmt.Fprintf(env.Stderr, "New credentials, which could not be saved; add them to %s yourself:\n", env.ConfigPath)
+		fmt.Fprintf(env.Stderr, "  key-id: %s\n  key-secret: %s\n", creds.APIID, creds.APISecret)
+		return err
+	}
+	fmt.Fprintf(env.Stdout, "Saved new API credentials %s to %s (backup: %s)\n", creds.APIID, env.ConfigPath, backup)
+
+	if err := env.CheckCredentials(creds.APIID, creds.APISecret); err != nil {
+		return fmt.Errorf("the new credentials failed the healthcheck: %w", err)
+	}
+	fmt.Fprintf(env.Stdout, "Healthcheck passed. The new credentials %s.\n", creds.DescribeExpiry(now))
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/identity"
+)
+
+// identityClient serves the current and newly generated credentials
+type identityClient struct {
+	generated int
+}
+
+func (c *identityClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	return []byte(`{"api_id": "old-id", "expiration_ts": "2099-01-01T00:00:00.000+0000"}`), nil
+}
+
+func (c *identityClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	c.generated++
+	return []byte(`{"api_id": "new-id", "api_secret": "new-secret", "expiration_ts": "2099-01-01T00:00:00.000+0000"}`), nil
+}
+
+func TestRotateCredentials(t *testing.T) {
+	client := &identityClient{}
+	var written, checked string
+	var stdout, stderr bytes.Buffer
+	env := &Env{
+		Identity:   identity.NewService(client),
+		ConfigPath: "veracode.yml",
+		WriteCredentials: func(configPath, keyID, keySecret string, now time.Time) (string, error) {
+			written = keyID + ":" + keySecret
+			return configPath + ".bak", nil
+		},
+		CheckCredentials: func(keyID, keySecret string) error {
+			checked = keyID + ":" + keySecret
+			return nil
+		},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+
+	if err := runRotateCredentials(env, nil); err == nil || client.generated != 0 {
+		t.Fatalf("Expected rotation without --yes to stop before generating, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "(old-id) expire on 2099-01-01") {
+		t.Errorf("Expected the current expiry, got %q", stdout.String())
+	}
+
+	if err := runRotateCredentials(env, []string{"--yes"}); err != nil {
+		t.Fatalf("Rotation failed: %v", err)
+	}
+	if written != "new-id:new-secret" || checked != "new-id:new-secret" {
+		t.Errorf("Expected the new credentials to be written and checked, got %q and %q", written, checked)
+	}
+
+	// A failed write shows the new credentials so that they are not lost
+	env.WriteCredentials = func(configPath, keyID, keySecret string, now time.Time) (string, error) {
+		return "", errors.New("disk full")
+	}
+	if err := runRotateCredentials(env, []string{"--yes"}); err == nil {
+		t.Fatal("Expected the write error")
+	}
+	if !strings.Contains(stderr.String(), "key-secret: new-secret") {
+		t.Errorf("Expected the new secret on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,457 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/analytics"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/findings/query"
+)
+
+var exportCommand = &Command{
+	Name:    "export",
+	Summary: "Export findings to CSV or JSON",
+	Run:     runExport,
+}
+
+// exportOptions holds the parsed options of the export command
+type exportOptions struct {
+	app       string
+	sandbox   string
+	scanTypes string
+	filter    string
+	view      string
+	format    string
+	output    string
+	since     string
+	until     string
+}
+
+func runExport(env *Env, args []string) error {
+	var opts exportOptions
+	fs := newFlagSet(env, "export")
+	fs.StringVar(&opts.app, "app", "", "Application name or GUID (required)")
+	fs.StringVar(&opts.sandbox, "sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	fs.StringVar(&opts.scanTypes, "scan-type", "STATIC,DYNAMIC,SCA", "Comma-separated scan types to export")
+	fs.StringVar(&opts.filter, "filter", "", "Filter expression, e.g. 'severity>=4 and not mitigated'")
+	fs.StringVar(&opts.view, "view", "", "Saved view (bookmark) name supplying the app, sandbox, scan type and filters")
+	fs.StringVar(&opts.format, "format", "csv", "Output format: csv or json")
+	fs.StringVar(&opts.output, "output", "", "Output file (default: stdout)")
+	fs.StringVar(&opts.since, "since", "", "Export the policy scan findings updated since a date (YYYY-MM-DD or e.g. 7d) using the Reporting API; --app is optional")
+	fs.StringVar(&opts.until, "until", "", "End of the --since date range (default: now)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui export --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr, "       veracode-tui export --view <saved view> [options]")
+		fmt.Fprintln(env.Stderr, "       veracode-tui export --since <date> [--app <name|guid>] [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+		fmt.Fprintln(env.Stderr)
+		printQueryFields(env.Stderr)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.view != "" {
+		if err := applyView(env, fs, &opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.app == "" && opts.since == "" {
+		fs.Usage()
+		return fmt.Errorf("--app, --view or --since is required")
+	}
+	if opts.format != "csv" && opts.format != "json" {
+		return fmt.Errorf("unsupported format %q, use csv or json", opts.format)
+	}
+	if opts.until != "" && opts.since == "" {
+		return fmt.Errorf("--until requires --since")
+	}
+
+	var filter *query.Query
+	if opts.filter != "" {
+		q, err := parseFilter(opts.filter)
+		if err != nil {
+			return err
+		}
+		filter = q
+	}
+
+	if opts.since != "" {
+		return exportUpdated(env, &opts, filter)
+	}
+
+	t, err := resolveTarget(env, opts.app, opts.sandbox)
+	if err != nil {
+		return err
+	}
+	list, err := fetchFindings(env, t, parseScanTypes(opts.scanTypes))
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		list = filter.Filter(list)
+	}
+
+	return writeOutput(env, opts.output, func(w io.Writer) error {
+		if opts.format == "json" {
+			return writeFindingsJSON(w, list)
+		}
+		return writeFindingsCSV(w, list)
+	}, fmt.Sprintf("%d findings", len(list)))
+}
+
+// exportUpdated exports the policy scan findings updated in the --since date range, of one
+// application or of all of them, from a Reporting API job
+func exportUpdated(env *Env, opts *exportOptions, filter *query.Query) error {
+	if opts.sandbox != "" {
+		return fmt.Errorf("--since exports policy scan findings and cannot be combined with --sandbox")
+	}
+	now := time.Now()
+	from, err := parseDate(opts.since, now)
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(opts.until, now)
+	if err != nil {
+		return err
+	}
+
+	appID := 0
+	if opts.app != "" {
+		app, err := resolveApplication(env, opts.app)
+		if err != nil {
+			return err
+		}
+		appID = app.ID
+	}
+
+	rows, err := findingsUpdated(env, from, to, appID, parseScanTypes(opts.scanTypes), filter)
+	if err != nil {
+		return err
+	}
+	return writeOutput(env, opts.output, func(w io.Writer) error {
+		if opts.format == "json" {
+			return writeReportJSON(w, rows)
+		}
+		return writeReportCSV(w, rows)
+	}, fmt.Sprintf("%d findings", len(rows)))
+}
+
+// applyView fills in the options not given on the command line from a saved view.
+// The view's severity, policy and expression filters are combined with any --filter.
+func applyView(env *Env, fs *flag.FlagSet, opts *exportOptions) error {
+	if env.Bookmarks == nil {
+		return fmt.Errorf("saved views are not available")
+	}
+	view := env.Bookmarks.Find(opts.view)
+	if view == nil {
+		var names []string
+		for _, bookmark := range env.Bookmarks.Items {
+			names = append(names, bookmark.Name)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no saved view named %q; save one with b on a findings table", opts.view)
+		}
+		return fmt.Errorf("no saved view named %q; available: %s", opts.view, strings.Join(names, ", "))
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if !set["app"] {
+		opts.app = view.AppGUID
+		if !set["sandbox"] {
+			opts.sandbox = view.SandboxGUID
+		}
+	}
+	if !set["scan-type"] && view.ScanType != "" {
+		opts.scanTypes = view.ScanType
+	}
+
+	var conditions []string
+	if view.MinSeverity > 0 {
+		conditions = append(conditions, fmt.Sprintf("severity>=%d", view.MinSeverity))
+	}
+	switch findings.PolicyFilterType(view.Policy) {
+	case findings.PolicyFilterViolations:
+		conditions = append(conditions, "violates_policy")
+	case findings.PolicyFilterNonViolations:
+		conditions = append(conditions, "not violates_policy")
+	}
+	if view.Query != "" {
+		if _, err := query.Parse(view.Query); err == nil {
+			conditions = append(conditions, "("+view.Query+")")
+		} else {
+			fmt.Fprintf(env.Stderr, "Note: ignoring the quick filter %q of view %q as it is not a filter expression\n", view.Query, view.Name)
+		}
+	}
+	if opts.filter != "" {
+		conditions = append(conditions, "("+opts.filter+")")
+	}
+	opts.filter = strings.Join(conditions, " and ")
+
+	return nil
+}
+
+// parseFilter parses a filter expression, showing where any syntax error is
+func parseFilter(expr string) (*query.Query, error) {
+	q, err := query.Parse(expr)
+	if err != nil {
+		var qerr *query.Error
+		if errors.As(err, &qerr) {
+			return nil, fmt.Errorf("invalid --filter: %w\n\n  %s", err, strings.ReplaceAll(qerr.Caret(), "\n", "\n  "))
+		}
+		return nil, fmt.Errorf("invalid --filter: %w", err)
+	}
+	return q, nil
+}
+
+// target is a resolved application and optional sandbox
+type target struct {
+	app     *applications.Application
+	sandbox *applications.Sandbox // nil for the policy scan
+}
+
+// appName returns the application's name, or its GUID when the profile is missing
+func (t *target) appName() string {
+	if t.app.Profile != nil {
+		return t.app.Profile.Name
+	}
+	return t.app.GUID
+}
+
+// sandboxGUID returns the sandbox GUID, or "" for the policy scan
+func (t *target) sandboxGUID() string {
+	if t.sandbox == nil {
+		return ""
+	}
+	return t.sandbox.GUID
+}
+
+// sandboxName returns the sandbox name, or "" for the policy scan
+func (t *target) sandboxName() string {
+	if t.sandbox == nil {
+		return ""
+	}
+	return t.sandbox.Name
+}
+
+// resolveTarget resolves an application and optional sandbox by name or GUID
+func resolveTarget(env *Env, appName, sandboxName string) (*target, error) {
+	app, err := resolveApplication(env, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &target{app: app}
+	if sandboxName != "" {
+		sandbox, err := resolveSandbox(env, app.GUID, sandboxName)
+		if err != nil {
+			return nil, err
+		}
+		t.sandbox = sandbox
+	}
+	return t, nil
+}
+
+// parseScanTypes splits a comma-separated list of scan types into upper-case names
+func parseScanTypes(scanTypes string) []string {
+	var types []string
+	for _, scanType := range strings.Split(scanTypes, ",") {
+		scanType = strings.ToUpper(strings.TrimSpace(scanType))
+		if scanType != "" {
+			types = append(types, scanType)
+		}
+	}
+	return types
+}
+
+// fetchFindings retrieves all findings of the given scan types for a target
+func fetchFindings(env *Env, t *target, scanTypes []string) ([]findings.Finding, error) {
+	fetch := portfolio.ByScanType(env.Findings, findings.GetFindingsOptions{
+		Context:            t.sandboxGUID(),
+		IncludeAnnotations: true,
+	}, scanTypes)
+	return fetch(t.app)
+}
+
+// writeOutput writes to the output file, or to stdout when no file is given.
+// what describes the content in the confirmation, e.g. "12 findings".
+func writeOutput(env *Env, path string, write func(w io.Writer) error, what string) error {
+	if path == "" {
+		return write(env.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Fprintf(env.Stderr, "Exported %s to %s\n", what, path)
+	return nil
+}
+
+// csvHeader lists the columns written by writeFindingsCSV
+var csvHeader = []string{
+	"scan_type", "issue_id", "severity", "cwe", "cwe_name", "status", "resolution_status", "new",
+	"mitigated", "violates_policy", "file", "line", "module", "procedure", "url", "parameter",
+	"component", "version", "cve", "cvss3_score", "cvss3_vector", "cvss2_score", "cvss2_vector",
+	"first_found", "description",
+}
+
+// writeFindingsCSV writes findings as CSV with one row per finding
+func writeFindingsCSV(w io.Writer, list []findings.Finding) error {
+	records := make([][]string, 0, len(list)+1)
+	records = append(records, csvHeader)
+	for i := range list {
+		records = append(records, findingRecord(&list[i]))
+	}
+	return writeCSV(w, records)
+}
+
+// writeReportCSV writes Reporting API findings as CSV, with the columns of writeFindingsCSV
+// preceded by the application name
+func writeReportCSV(w io.Writer, rows []analytics.FindingRow) error {
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, append([]string{"application"}, csvHeader...))
+	for i := range rows {
+		f := rows[i].Finding()
+		records = append(records, append([]string{rows[i].AppName}, findingRecord(&f)...))
+	}
+	return writeCSV(w, records)
+}
+
+// findingRecord returns the CSV columns of a finding, as listed in csvHeader
+func findingRecord(f *findings.Finding) []string {
+	resolution := ""
+	firstFound := ""
+	if f.FindingStatus != nil {
+		resolution = string(f.FindingStatus.ResolutionStatus)
+		if f.FindingStatus.FirstFoundDate != nil {
+			firstFound = f.FindingStatus.FirstFoundDate.Format("2006-01-02")
+		}
+	}
+
+	record := []string{
+		string(f.ScanType),
+		strconv.FormatInt(f.IssueID, 10),
+		strconv.Itoa(f.Severity()),
+		optionalInt(f.CWEID()),
+		f.CWEName(),
+		string(f.Status()),
+		resolution,
+		strconv.FormatBool(f.IsNew()),
+		strconv.FormatBool(f.IsMitigated()),
+		strconv.FormatBool(f.ViolatesPolicy),
+		f.FilePath(),
+		optionalInt(f.FileLine()),
+		f.Module(),
+		f.Procedure(),
+		f.URL(),
+		f.VulnerableParameter(),
+		f.Component(),
+		f.ComponentVersion(),
+		f.CVE(),
+	}
+	record = append(record, cvssColumns(f)...)
+	return append(record, firstFound, f.Description)
+}
+
+// writeCSV writes the records as CSV, with fields that a spreadsheet would take for a formula
+// quoted as text
+func writeCSV(w io.Writer, records [][]string) error {
+	for _, record := range records {
+		portfolio.SafeCSVRecord(record)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// cvssColumns returns the v3 score and vector, then the v2 score and vector, of a finding,
+// leaving empty the columns of versions it has no score for
+func cvssColumns(f *findings.Finding) []string {
+	columns := make([]string, 4)
+	for _, score := range f.CVSSScores() {
+		offset := 2
+		if score.Version.IsV3() {
+			offset = 0
+		}
+		columns[offset] = strconv.FormatFloat(score.Base, 'f', 1, 64)
+		columns[offset+1] = score.Vector
+	}
+	return columns
+}
+
+// writeFindingsJSON writes findings as an indented JSON array, as returned by the API
+func writeFindingsJSON(w io.Writer, list []findings.Finding) error {
+	if list == nil {
+		list = []findings.Finding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(list); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// writeReportJSON writes Reporting API findings as an indented JSON array, as returned by the API
+func writeReportJSON(w io.Writer, rows []analytics.FindingRow) error {
+	if rows == nil {
+		rows = []analytics.FindingRow{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(rows); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// optionalInt formats a number, leaving zero (unknown) values empty
+func optionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// printQueryFields lists the fields available in filter expressions
+func printQueryFields(w io.Writer) {
+	fmt.Fprintln(w, "Filter fields:")
+	for _, field := range query.Fields() {
+		name := field.Name
+		if len(field.Aliases) > 0 {
+			name += " (" + strings.Join(field.Aliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "  %-26s %-8s %s\n", name, field.Kind, field.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Operators: = != > >= < <= ~ (contains) !~ (does not contain) in (a, b)")
+	fmt.Fprintln(w, "Combine with and, or, not and parentheses; quote values containing spaces.")
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/analytics"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+func sampleFindings(t *testing.T) []findings.Finding {
+	t.Helper()
+	data := `[
+		{
+			"issue_id": 7,
+			"scan_type": "STATIC",
+			"description": "SQL injection, \"quoted\"",
+			"finding_status": {"status": "OPEN", "new": true, "first_found_date": "2024-03-10T12:00:00Z"},
+			"finding_details": {"severity": 4, "cwe": {"id": 89, "name": "SQL Injection"}, "file_path": "src/a.java", "file_line_number": 3}
+		},
+		{
+			"issue_id": 8,
+			"scan_type": "SCA",
+			"finding_details": {"severity": 5, "component_filename": "log4j-core.jar", "version": "2.14.1", "cve": {"name": "CVE-2021-44228",
+				"cvss": 9.3, "vector": "AV:N/AC:M/Au:N/C:C/I:C/A:C", "cvss3": {"score": 10.0, "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}}}
+		}
+	]`
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	return list
+}
+
+func TestWriteFindingsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeFindingsCSV(&buf, sampleFindings(t)); err != nil {
+		t.Fatalf("writeFindingsCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["issue_id"] != "7" || row["cwe"] != "89" || row["line"] != "3" || row["new"] != "true" {
+		t.Errorf("Unexpected static row: %v", row)
+	}
+	if row["description"] != `SQL injection, "quoted"` {
+		t.Errorf("Description not round-tripped: %q", row["description"])
+	}
+
+	for i, column := range records[0] {
+		row[column] = records[2][i]
+	}
+	if row["cve"] != "CVE-2021-44228" || row["cwe"] != "" || row["first_found"] != "" {
+		t.Errorf("Unexpected SCA row: %v", row)
+	}
+	if row["cvss3_score"] != "10.0" || !strings.HasPrefix(row["cvss3_vector"], "CVSS:3.1/") ||
+		row["cvss2_score"] != "9.3" || row["cvss2_vector"] != "AV:N/AC:M/Au:N/C:C/I:C/A:C" {
+		t.Errorf("Unexpected CVSS columns: %v", row)
+	}
+}
+
+func TestWriteFindingsCSVQuotesFormulas(t *testing.T) {
+	list := []findings.Finding{{IssueID: 9, ScanType: findings.ScanTypeStatic, Description: "=HYPERLINK(\"http://example.com\")"}}
+
+	var buf bytes.Buffer
+	if err := writeFindingsCSV(&buf, list); err != nil {
+		t.Fatalf("writeFindingsCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected a header and 1 row of valid CSV, got %d, %v", len(records), err)
+	}
+	if description := records[1][len(records[1])-1]; description != `'=HYPERLINK("http://example.com")` {
+		t.Errorf("Expected the description to be quoted, got %q", description)
+	}
+}
+
+func TestWriteFindingsJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeFindingsJSON(&buf, nil); err != nil {
+		t.Fatalf("writeFindingsJSON failed: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected empty JSON array, got %q", buf.String())
+	}
+}
+
+func TestWriteReportCSV(t *testing.T) {
+	rows := []analytics.FindingRow{
+		{AppName: "Verademo", IssueID: 7, ScanType: "Static Analysis", Severity: 4, CWEID: 89, Status: "Open", FilePath: "src/a.java"},
+	}
+	var buf bytes.Buffer
+	if err := writeReportCSV(&buf, rows); err != nil {
+		t.Fatalf("writeReportCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected header and 1 row, got %v, %v", records, err)
+	}
+	if records[0][0] != "application" || len(records[0]) != len(csvHeader)+1 {
+		t.Errorf("Unexpected header %v", records[0])
+	}
+	if strings.Join(records[1][:4], ",") != "Verademo,STATIC,7,4" {
+		t.Errorf("Unexpected row %v", records[1])
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.Local)
+	if date, err := parseDate("7d", now); err != nil || !date.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("parseDate(7d) = %v, %v", date, err)
+	}
+	if date, err := parseDate("2025-06-01", now); err != nil || date.Day() != 1 || date.Month() != time.June {
+		t.Errorf("parseDate(2025-06-01) = %v, %v", date, err)
+	}
+	if date, err := parseDate("", now); err != nil || !date.IsZero() {
+		t.Errorf("parseDate() = %v, %v", date, err)
+	}
+	if _, err := parseDate("last week", now); err == nil {
+		t.Error("Expected an error for an invalid date")
+	}
+}
+
+func TestParseFilterShowsCaret(t *testing.T) {
+	_, err := parseFilter("severity>>4")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "  severity>>4\n           ^") {
+		t.Errorf("Expected caret under the problem, got:\n%s", err.Error())
+	}
+}
+
+func TestApplyView(t *testing.T) {
+	env := &Env{
+		Bookmarks: &config.Bookmarks{Items: []config.Bookmark{{
+			Name:        "API criticals",
+			AppGUID:     "app-guid",
+			SandboxGUID: "sandbox-guid",
+			ScanType:    "DYNAMIC",
+			MinSeverity: 4,
+			Policy:      "Violations",
+			Query:       "cwe in (89, 79)",
+		}}},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+	}
+
+	fs := newFlagSet(env, "export")
+	opts := exportOptions{view: "api criticals", scanTypes: "STATIC,DYNAMIC,SCA", filter: "not mitigated"}
+	if err := applyView(env, fs, &opts); err != nil {
+		t.Fatalf("applyView failed: %v", err)
+	}
+
+	if opts.app != "app-guid" || opts.sandbox != "sandbox-guid" || opts.scanTypes != "DYNAMIC" {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+	want := "severity>=4 and violates_policy and (cwe in (89, 79)) and (not mitigated)"
+	if opts.filter != want {
+		t.Errorf("filter = %q, want %q", opts.filter, want)
+	}
+	if _, err := parseFilter(opts.filter); err != nil {
+		t.Errorf("Combined filter does not parse: %v", err)
+	}
+
+	opts = exportOptions{view: "missing"}
+	if err := applyView(env, fs, &opts); err == nil || !strings.Contains(err.Error(), "API criticals") {
+		t.Errorf("Expected error listing available views, got %v", err)
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var stderr bytes.Buffer
+	env := &Env{Stdout: &bytes.Buffer{}, Stderr: &stderr}
+
+	if code := Run(env, []string{"nope"}); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "export") {
+		t.Errorf("Expected usage listing export, got %q", stderr.String())
+	}
+}
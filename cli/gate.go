@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/compliance"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+var gateCommand = &Command{
+	Name:    "gate",
+	Summary: "Check policy compliance and the remediation SLA; fails when findings are past their grace period",
+	Run:     runGate,
+}
+
+func runGate(env *Env, args []string) error {
+	fs := newFlagSet(env, "gate")
+	app := fs.String("app", "", "Application name or GUID (required)")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	scanTypes := fs.String("scan-type", "STATIC,DYNAMIC,SCA", "Comma-separated scan types to check")
+	failOnViolations := fs.Bool("fail-on-violations", false, "Also fail when there are open policy violations still within their grace period")
+	explain := fs.Bool("explain", false, "Evaluate each rule of the application's policy locally and list the findings breaking it; fails when the policy does not pass")
+	whatIf := fs.String("what-if", "", "Comma-separated issue IDs to treat as mitigated, to check whether the gate would then pass (implies --explain)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui gate --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *app == "" {
+		fs.Usage()
+		return fmt.Errorf("--app is required")
+	}
+	mitigated, err := parseIssueIDs(*whatIf)
+	if err != nil {
+		return err
+	}
+
+	t, err := resolveTarget(env, *app, *sandbox)
+	if err != nil {
+		return err
+	}
+	list, err := fetchFindings(env, t, parseScanTypes(*scanTypes))
+	if err != nil {
+		return err
+	}
+	if len(mitigated) > 0 {
+		list = compliance.AssumeMitigated(list, mitigated)
+	}
+
+	now := time.Now()
+	fmt.Fprintf(env.Stdout, "Application: %s (%s)\n", t.appName(), contextLabel(t))
+	if t.sandbox == nil && t.app.Profile != nil {
+		for _, policy := range t.app.Profile.Policies {
+			fmt.Fprintf(env.Stdout, "Policy: %s - %s\n", policy.Name, policy.PolicyComplianceStatus)
+		}
+	}
+
+	summary := findings.SummarizeSLA(list, now)
+	printSLASummary(env.Stdout, summary)
+	printSLAFindings(env.Stdout, findings.SLAFindings(list), now)
+
+	var evaluation *compliance.Result
+	if *explain || len(mitigated) > 0 {
+		evaluation, err = explainPolicy(env, t, list, mitigated, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case evaluation != nil && evaluation.Status == compliance.DidNotPass:
+		return fmt.Errorf("gate failed: the findings do not pass policy %s", evaluation.Policy.Name)
+	case summary.Overdue > 0:
+		return fmt.Errorf("gate failed: %d policy-violating findings are past their grace period", summary.Overdue)
+	case *failOnViolations && summary.Tracked > 0:
+		return fmt.Errorf("gate failed: %d open policy-violating findings", summary.Tracked)
+	}
+	fmt.Fprintln(env.Stdout, "Gate passed")
+	return nil
+}
+
+// explainPolicy evaluates the findings against the application's policy, prints the outcome of
+// each rule with the findings breaking it and returns the evaluation
+func explainPolicy(env *Env, t *target, list []findings.Finding, mitigated []int64, now time.Time) (*compliance.Result, error) {
+	if t.app.Profile == nil || len(t.app.Profile.Policies) == 0 {
+		return nil, fmt.Errorf("application %s has no policy to evaluate", t.appName())
+	}
+	policy, err := env.Policies.GetPolicy(t.app.Profile.Policies[0].GUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	in := compliance.Input{Policy: policy, Findings: list, Now: now}
+	if t.sandbox == nil {
+		// Scan frequency only applies to the policy scan
+		in.Scans = t.app.Scans
+	}
+	result := compliance.Evaluate(in)
+
+	fmt.Fprintln(env.Stdout)
+	if len(mitigated) > 0 {
+		ids := make([]string, len(mitigated))
+		for i, id := range mitigated {
+			ids[i] = "#" + strconv.FormatInt(id, 10)
+		}
+		fmt.Fprintf(env.Stdout, "What if %s were mitigated:\n", strings.Join(ids, ", "))
+	}
+	printEvaluation(env.Stdout, result)
+	return result, nil
+}
+
+// printEvaluation prints a policy evaluation rule by rule, then the findings to fix to pass
+func printEvaluation(w io.Writer, result *compliance.Result) {
+	fmt.Fprintf(w, "Policy evaluation (%s): %s\n", result.Policy.Name, result.Status)
+	for _, rule := range result.Rules {
+		fmt.Fprintf(w, "  [%-4s] %s: %s - %s\n", ruleLabel(rule.Status), rule.Kind, rule.Rule, rule.Explanation)
+		for _, f := range rule.Findings {
+			fmt.Fprintf(w, "         #%-8d %-7s Sev %d  %s\n", f.IssueID, f.ScanType, f.Severity(), describeFinding(f))
+		}
+	}
+
+	toFix := result.ToFix()
+	if len(toFix) == 0 {
+		return
+	}
+	ids := make([]string, len(toFix))
+	for i, f := range toFix {
+		ids[i] = strconv.FormatInt(f.IssueID, 10)
+	}
+	fmt.Fprintf(w, "To pass the finding rules, fix or mitigate: %s\n", strings.Join(ids, ","))
+}
+
+// ruleLabel returns the short label of a rule outcome
+func ruleLabel(status compliance.Status) string {
+	switch status {
+	case compliance.Passed:
+		return "PASS"
+	case compliance.ConditionalPass:
+		return "COND"
+	case compliance.DidNotPass:
+		return "FAIL"
+	default:
+		return "N/A"
+	}
+}
+
+// parseIssueIDs parses a comma-separated list of issue IDs
+func parseIssueIDs(s string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "#")
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue ID %q", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// contextLabel returns "Policy Scan" or "Sandbox: <name>"
+func contextLabel(t *target) string {
+	if t.sandbox == nil {
+		return "Policy Scan"
+	}
+	return "Sandbox: " + t.sandbox.Name
+}
+
+// printSLASummary prints the SLA counts on one line, with the next grace period expiry
+func printSLASummary(w io.Writer, summary findings.SLASummary) {
+	fmt.Fprintf(w, "SLA: %d open policy violations, %d overdue, %d due within %d days",
+		summary.Tracked, summary.Overdue, summary.DueSoon, findings.DueSoonDays)
+	if summary.NextExpiry != nil {
+		fmt.Fprintf(w, ", next expiry %s", summary.NextExpiry.Format("2006-01-02"))
+	}
+	fmt.Fprintln(w)
+}
+
+// printSLAFindings lists the overdue and due-soon findings, most overdue first
+func printSLAFindings(w io.Writer, tracked []findings.Finding, now time.Time) {
+	for i := range tracked {
+		f := &tracked[i]
+		days, _ := f.GraceDaysRemaining(now)
+		if days > findings.DueSoonDays {
+			// Sorted by expiry, so the rest are not due soon either
+			break
+		}
+		state := fmt.Sprintf("due in %d days", days)
+		if days < 0 {
+			state = fmt.Sprintf("%d days overdue", -days)
+		}
+		fmt.Fprintf(w, "  #%-8d %-7s Sev %d  %-16s %s  %s\n", f.IssueID, f.ScanType, f.Severity(), state,
+			f.GracePeriodExpiresDate.Format("2006-01-02"), describeFinding(f))
+	}
+}
+
+// describeFinding returns the CWE or CVE of a finding and where it is
+func describeFinding(f *findings.Finding) string {
+	var parts []string
+	switch f.ScanType {
+	case findings.ScanTypeSCA:
+		parts = append(parts, f.CVE(), strings.TrimSpace(f.Component()+" "+f.ComponentVersion()))
+	case findings.ScanTypeDynamic:
+		parts = append(parts, f.CWELabel(), f.URL())
+	default:
+		location := f.FilePath()
+		if line := f.FileLine(); line > 0 {
+			location = fmt.Sprintf("%s:%d", location, line)
+		}
+		parts = append(parts, f.CWELabel(), location)
+	}
+
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "  ")
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/compliance"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/policies"
+)
+
+func TestPrintSLAFindingsListsOverdueAndDueSoon(t *testing.T) {
+	var list []findings.Finding
+	data := `[
+		{"issue_id": 11, "scan_type": "STATIC", "violates_policy": true, "grace_period_expires_date": "2025-06-05T00:00:00Z",
+		 "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 4, "cwe": {"id": 89, "name": "SQL Injection"}, "file_path": "a.java", "file_line_number": 9}},
+		{"issue_id": 12, "scan_type": "SCA", "violates_policy": true, "grace_period_expires_date": "2025-06-12T00:00:00Z",
+		 "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5, "component_filename": "log4j-core.jar", "version": "2.14.1", "cve": {"name": "CVE-2021-44228"}}},
+		{"issue_id": 13, "scan_type": "STATIC", "violates_policy": true, "grace_period_expires_date": "2025-09-01T00:00:00Z",
+		 "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 3}}
+	]`
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+
+	var buf bytes.Buffer
+	printSLAFindings(&buf, findings.SLAFindings(list), time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	if len(lines) != 2 {
+		t.Fatalf("Expected the overdue and due-soon findings only, got:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[0], "#11") || !strings.Contains(lines[0], "5 days overdue") || !strings.Contains(lines[0], "CWE-89 SQL Injection  a.java:9") {
+		t.Errorf("Unexpected overdue line %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "due in 2 days") || !strings.Contains(lines[1], "CVE-2021-44228  log4j-core.jar 2.14.1") {
+		t.Errorf("Unexpected due-soon line %q", lines[1])
+	}
+}
+
+func TestPrintEvaluationWhatIf(t *testing.T) {
+	var list []findings.Finding
+	data := `[
+		{"issue_id": 21, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "grace_period_expires_date": "2025-06-01T00:00:00Z",
+		 "finding_details": {"severity": 4, "cwe": {"id": 89, "name": "SQL Injection"}, "file_path": "a.java", "file_line_number": 9}},
+		{"issue_id": 22, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "grace_period_expires_date": "2025-07-01T00:00:00Z",
+		 "finding_details": {"severity": 5, "cwe": {"id": 78}}}
+	]`
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	policy := &policies.Policy{
+		Name:         "High",
+		FindingRules: []policies.FindingRule{{Type: policies.RuleMaxSeverity, ScanType: []string{"STATIC"}, Value: "4"}},
+	}
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	printEvaluation(&buf, compliance.Evaluate(compliance.Input{Policy: policy, Findings: list, Now: now}))
+	out := buf.String()
+	if !strings.Contains(out, "Policy evaluation (High): DID_NOT_PASS") || !strings.Contains(out, "[FAIL] Finding rule") {
+		t.Errorf("Expected the failing rule, got:\n%s", out)
+	}
+	if !strings.Contains(out, "#21") || !strings.Contains(out, "fix or mitigate: 22,21") {
+		t.Errorf("Expected the findings to fix, got:\n%s", out)
+	}
+
+	ids, err := parseIssueIDs("#21, 22")
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("parseIssueIDs = %v, %v", ids, err)
+	}
+	buf.Reset()
+	printEvaluation(&buf, compliance.Evaluate(compliance.Input{Policy: policy, Findings: compliance.AssumeMitigated(list, ids), Now: now}))
+	if !strings.Contains(buf.String(), "Policy evaluation (High): PASSED") {
+		t.Errorf("Expected the policy to pass once mitigated, got:\n%s", buf.String())
+	}
+	if _, err := parseIssueIDs("21,abc"); err == nil {
+		t.Error("Expected an error for an invalid issue ID")
+	}
+}
+
+const gateAppGUID = "2a0f4e6c-1b2d-4c3e-8f5a-6b7c8d9e0f1a"
+
+// gateClient serves an application whose only finding breaks its policy past the grace period,
+// while the API does not flag it as violating, so only the local evaluation fails
+type gateClient struct{}
+
+func (gateClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	switch {
+	case urlPath == "/appsec/v1/applications/"+gateAppGUID:
+		return []byte(`{"guid": "` + gateAppGUID + `", "profile": {"name": "Payments", "policies": [{"guid": "policy-1", "name": "High"}]}}`), nil
+	case strings.HasSuffix(urlPath, "/findings"):
+		if params.Get("scan_type") != "STATIC" {
+			return []byte(`{}`), nil
+		}
+		return []byte(`{"_embedded": {"findings": [{"issue_id": 31, "scan_type": "STATIC", "grace_period_expires_date": "2020-01-01T00:00:00Z",
+			"finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5, "cwe": {"id": 78}}}]}}`), nil
+	case strings.HasSuffix(urlPath, "/policy-1"):
+		return []byte(`{"guid": "policy-1", "name": "High", "finding_rules": [{"type": "MAX_SEVERITY", "scan_type": ["STATIC"], "value": "4"}]}`), nil
+	}
+	return nil, fmt.Errorf("unexpected request %s %s", method, urlPath)
+}
+
+func (gateClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	return nil, fmt.Errorf("unexpected request %s %s", method, urlPath)
+}
+
+func TestRunGateFailsOnPolicyEvaluation(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	env := &Env{
+		Applications: applications.NewService(gateClient{}),
+		Findings:     findings.NewService(gateClient{}),
+		Policies:     policies.NewService(gateClient{}),
+		Stdout:       &stdout,
+		Stderr:       &stderr,
+	}
+
+	if err := runGate(env, []string{"--app", gateAppGUID}); err != nil {
+		t.Fatalf("Expected the gate to pass on the SLA alone, got %v", err)
+	}
+
+	stdout.Reset()
+	err := runGate(env, []string{"--app", gateAppGUID, "--explain"})
+	if err == nil || !strings.Contains(err.Error(), "do not pass policy High") {
+		t.Fatalf("Expected the gate to fail on the policy evaluation, got %v\n%s", err, stdout.String())
+	}
+
+	stdout.Reset()
+	if err := runGate(env, []string{"--app", gateAppGUID, "--what-if", "31"}); err != nil {
+		t.Fatalf("Expected the gate to pass with #31 mitigated, got %v\n%s", err, stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "Gate passed") {
+		t.Errorf("Expected the gate to pass, got:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+var licensesCommand = &Command{
+	Name:    "licenses",
+	Summary: "Export the licenses of SCA components to CSV, per application or across all applications",
+	Run:     runLicenses,
+}
+
+func runLicenses(env *Env, args []string) error {
+	fs := newFlagSet(env, "licenses")
+	app := fs.String("app", "", "Application name or GUID")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan; only with --app)")
+	all := fs.Bool("all", false, "Report on all applications instead of one")
+	highRisk := fs.Bool("high-risk", false, "Only include high risk licenses")
+	copyleft := fs.Bool("copyleft", false, "Only include copyleft licenses (with --high-risk: either)")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui licenses --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr, "       veracode-tui licenses --all [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if (*app == "") == !*all {
+		fs.Usage()
+		return fmt.Errorf("use either --app or --all")
+	}
+	if *all && *sandbox != "" {
+		return fmt.Errorf("--sandbox can only be used with --app")
+	}
+
+	var results []portfolio.AppFindings
+	if *all {
+		apps, err := env.Applications.GetAllApplications(nil)
+		if err != nil {
+			return fmt.Errorf("failed to list applications: %w", err)
+		}
+		fmt.Fprintf(env.Stderr, "Collecting SCA findings of %d applications...\n", len(apps))
+		fetch := portfolio.ByScanType(env.Findings, findings.GetFindingsOptions{}, []string{string(findings.ScanTypeSCA)})
+		results = portfolio.Collect(apps, fetch, portfolio.DefaultWorkers, nil)
+		for i := range results {
+			if results[i].Err != nil {
+				fmt.Fprintf(env.Stderr, "Skipping %s: %v\n", results[i].AppName(), results[i].Err)
+			}
+		}
+	} else {
+		t, err := resolveTarget(env, *app, *sandbox)
+		if err != nil {
+			return err
+		}
+		list, err := fetchFindings(env, t, []string{string(findings.ScanTypeSCA)})
+		if err != nil {
+			return err
+		}
+		results = []portfolio.AppFindings{{App: t.app, Findings: list}}
+	}
+
+	rows := portfolio.Licenses(results, portfolio.LicenseFilter{HighRisk: *highRisk, Copyleft: *copyleft})
+	return writeOutput(env, *output, func(w io.Writer) error {
+		return portfolio.WriteLicensesCSV(w, rows)
+	}, fmt.Sprintf("%d component licenses", len(rows)))
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/report"
+	"github.com/dipsylala/veracode-tui/snapshot"
+)
+
+var reportCommand = &Command{
+	Name:    "report",
+	Summary: "Generate a self-contained HTML security report of an application",
+	Run:     runReport,
+}
+
+func runReport(env *Env, args []string) error {
+	fs := newFlagSet(env, "report")
+	app := fs.String("app", "", "Application name or GUID (required)")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	scanTypes := fs.String("scan-type", "STATIC,DYNAMIC,SCA", "Comma-separated scan types to report on")
+	templatePath := fs.String("template", "", "HTML template (default: "+report.TemplateFileName+" in the state directory, or the built-in template)")
+	printTemplate := fs.Bool("print-template", false, "Write the built-in template, as a starting point for a custom one, and exit")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui report --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *printTemplate {
+		return writeOutput(env, *output, func(w io.Writer) error {
+			_, err := io.WriteString(w, report.DefaultTemplate)
+			return err
+		}, "the built-in report template")
+	}
+	if *app == "" {
+		fs.Usage()
+		return fmt.Errorf("--app is required")
+	}
+
+	// Resolve the template before fetching, so that template errors are reported straight away
+	stateDir, _ := config.StateDir()
+	tmpl, err := report.LoadTemplate(*templatePath, stateDir)
+	if err != nil {
+		return err
+	}
+
+	t, err := resolveTarget(env, *app, *sandbox)
+	if err != nil {
+		return err
+	}
+	list, err := fetchFindings(env, t, parseScanTypes(*scanTypes))
+	if err != nil {
+		return err
+	}
+
+	var history []*snapshot.Snapshot
+	if env.Snapshots != nil {
+		history, err = env.Snapshots.List(t.app.GUID, t.sandboxGUID())
+		if err != nil {
+			return fmt.Errorf("failed to read snapshots: %w", err)
+		}
+	}
+
+	r := report.Build(report.Input{
+		App:         t.app,
+		SandboxName: t.sandboxName(),
+		Findings:    list,
+		Snapshots:   history,
+		Now:         time.Now(),
+		ToolVersion: env.Version,
+	})
+	return writeOutput(env, *output, func(w io.Writer) error {
+		return report.Write(w, tmpl, r)
+	}, fmt.Sprintf("a report of %d findings", len(list)))
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+)
+
+// guidPattern matches Veracode GUIDs such as 2a0f4e6c-1b2d-4c3e-8f5a-6b7c8d9e0f1a
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// resolveApplication finds an application by GUID or by name.
+// Names are matched exactly (case-insensitive); a partial match is accepted if it is unique.
+func resolveApplication(env *Env, nameOrGUID string) (*applications.Application, error) {
+	if guidPattern.MatchString(nameOrGUID) {
+		app, err := env.Applications.GetApplication(nameOrGUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application %s: %w", nameOrGUID, err)
+		}
+		return app, nil
+	}
+
+	result, err := env.Applications.GetApplications(&applications.GetApplicationsOptions{Name: nameOrGUID, Size: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search applications: %w", err)
+	}
+	if result.Embedded == nil || len(result.Embedded.Applications) == 0 {
+		return nil, fmt.Errorf("no application found matching %q", nameOrGUID)
+	}
+
+	apps := result.Embedded.Applications
+	for i := range apps {
+		if apps[i].Profile != nil && strings.EqualFold(apps[i].Profile.Name, nameOrGUID) {
+			return &apps[i], nil
+		}
+	}
+	if len(apps) == 1 {
+		return &apps[0], nil
+	}
+
+	var names []string
+	for _, app := range apps {
+		if app.Profile != nil {
+			names = append(names, app.Profile.Name)
+		}
+	}
+	return nil, fmt.Errorf("%q matches %d applications, be more specific: %s", nameOrGUID, len(apps), strings.Join(names, ", "))
+}
+
+// resolveSandbox finds a sandbox of an application by GUID or by name (case-insensitive)
+func resolveSandbox(env *Env, appGUID, nameOrGUID string) (*applications.Sandbox, error) {
+	if guidPattern.MatchString(nameOrGUID) {
+		sandbox, err := env.Applications.GetSandbox(appGUID, nameOrGUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sandbox %s: %w", nameOrGUID, err)
+		}
+		return sandbox, nil
+	}
+
+	result, err := env.Applications.GetSandboxes(appGUID, &applications.GetSandboxesOptions{Size: 500})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sandboxes: %w", err)
+	}
+	if result.Embedded != nil {
+		for i := range result.Embedded.Sandboxes {
+			if strings.EqualFold(result.Embedded.Sandboxes[i].Name, nameOrGUID) {
+				return &result.Embedded.Sandboxes[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no sandbox named %q", nameOrGUID)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dipsylala/veracode-tui/sbom"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+var sbomCommand = &Command{
+	Name:    "sbom",
+	Summary: "Generate a CycloneDX or SPDX JSON SBOM from an application's SCA findings",
+	Run:     runSBOM,
+}
+
+func runSBOM(env *Env, args []string) error {
+	fs := newFlagSet(env, "sbom")
+	app := fs.String("app", "", "Application name or GUID (required)")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	format := fs.String("format", string(sbom.FormatCycloneDX), "Output format: cyclonedx or spdx")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui sbom --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *app == "" {
+		fs.Usage()
+		return fmt.Errorf("--app is required")
+	}
+	sbomFormat, err := sbom.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	t, err := resolveTarget(env, *app, *sandbox)
+	if err != nil {
+		return err
+	}
+	list, err := fetchFindings(env, t, []string{string(findings.ScanTypeSCA)})
+	if err != nil {
+		return err
+	}
+
+	bom := sbom.Build(t.appName(), list, sbom.Options{ToolVersion: env.Version})
+	return writeOutput(env, *output, func(w io.Writer) error {
+		return sbom.Write(w, bom, sbomFormat)
+	}, fmt.Sprintf("an SBOM of %d components", len(bom.Components)))
+}
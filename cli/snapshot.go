@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/analytics"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/snapshot"
+)
+
+var snapshotCommand = &Command{
+	Name:    "snapshot",
+	Summary: "Record the current findings of an application for later diffs",
+	Run:     runSnapshot,
+}
+
+var diffCommand = &Command{
+	Name:    "diff",
+	Summary: "Show new, fixed, reopened, mitigated and re-rated findings between snapshots",
+	Run:     runDiff,
+}
+
+func runSnapshot(env *Env, args []string) error {
+	fs := newFlagSet(env, "snapshot")
+	app := fs.String("app", "", "Application name or GUID (required)")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	scanTypes := fs.String("scan-type", "STATIC,DYNAMIC,SCA", "Comma-separated scan types to record")
+	listOnly := fs.Bool("list", false, "List the recorded snapshots instead of taking one")
+	since := fs.String("since", "", "Update the latest policy scan snapshots with the findings updated since a date (YYYY-MM-DD or e.g. 7d) using the Reporting API; --app is optional")
+	until := fs.String("until", "", "End of the --since date range (default: now)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui snapshot --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr, "       veracode-tui snapshot --since <date> [--app <name|guid>] [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *since != "" && !*listOnly {
+		if *sandbox != "" {
+			return fmt.Errorf("--since updates policy scan snapshots and cannot be combined with --sandbox")
+		}
+		return updateSnapshots(env, *app, *since, *until, parseScanTypes(*scanTypes))
+	}
+	if *app == "" {
+		fs.Usage()
+		return fmt.Errorf("--app is required")
+	}
+
+	t, err := resolveTarget(env, *app, *sandbox)
+	if err != nil {
+		return err
+	}
+
+	if *listOnly {
+		snapshots, err := env.Snapshots.List(t.app.GUID, t.sandboxGUID())
+		if err != nil {
+			return err
+		}
+		printSnapshotList(env.Stdout, snapshots)
+		return nil
+	}
+
+	types := parseScanTypes(*scanTypes)
+	current, err := fetchFindings(env, t, types)
+	if err != nil {
+		return err
+	}
+
+	snap := snapshot.New(t.app.GUID, t.appName(), t.sandboxGUID(), t.sandboxName(), types, current, time.Now())
+	saved, err := env.Snapshots.Save(snap)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		fmt.Fprintf(env.Stdout, "No changes since the last snapshot (%d findings, %d open)\n", len(snap.Findings), snap.OpenCount())
+		return nil
+	}
+	fmt.Fprintf(env.Stdout, "Saved snapshot %s (%d findings, %d open)\n", snap.ID, len(snap.Findings), snap.OpenCount())
+	return nil
+}
+
+// updateSnapshots records new policy scan snapshots of the applications with findings updated in
+// a date range, by applying a Reporting API job's findings to their latest snapshots. An
+// application needs a full snapshot of the same scan types to update.
+func updateSnapshots(env *Env, appName, since, until string, scanTypes []string) error {
+	now := time.Now()
+	from, err := parseDate(since, now)
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(until, now)
+	if err != nil {
+		return err
+	}
+
+	// The report identifies applications by ID, snapshots by GUID
+	var apps []applications.Application
+	appID := 0
+	if appName != "" {
+		app, err := resolveApplication(env, appName)
+		if err != nil {
+			return err
+		}
+		apps = []applications.Application{*app}
+		appID = app.ID
+	} else if apps, err = env.Applications.GetAllApplications(nil); err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	rows, err := findingsUpdated(env, from, to, appID, scanTypes, nil)
+	if err != nil {
+		return err
+	}
+	byApp := make(map[int64][]analytics.FindingRow)
+	for _, row := range rows {
+		byApp[row.AppID] = append(byApp[row.AppID], row)
+	}
+
+	types := slices.Clone(scanTypes)
+	slices.Sort(types)
+	updated := 0
+	for i := range apps {
+		app := &apps[i]
+		appRows, ok := byApp[int64(app.ID)]
+		if !ok {
+			continue
+		}
+		name := appRows[0].AppName
+		base, err := env.Snapshots.Latest(app.GUID, "", types)
+		if err != nil {
+			return err
+		}
+		if base == nil {
+			fmt.Fprintf(env.Stderr, "Skipped %s: no snapshot of %v to update; take one with 'snapshot --app'\n", name, types)
+			continue
+		}
+
+		snap := snapshot.Update(base, rowFindings(appRows), now)
+		saved, err := env.Snapshots.Save(snap)
+		if err != nil {
+			return err
+		}
+		if saved {
+			updated++
+			fmt.Fprintf(env.Stdout, "Saved snapshot %s of %s (%d findings updated, %d open)\n", snap.ID, name, len(appRows), snap.OpenCount())
+		}
+	}
+	fmt.Fprintf(env.Stdout, "Updated %d snapshots from %d findings\n", updated, len(rows))
+	return nil
+}
+
+func runDiff(env *Env, args []string) error {
+	fs := newFlagSet(env, "diff")
+	app := fs.String("app", "", "Application name or GUID (required)")
+	sandbox := fs.String("sandbox", "", "Sandbox name or GUID (default: policy scan)")
+	from := fs.String("from", "", "Snapshot ID to compare from (default: the one before --to)")
+	to := fs.String("to", "", "Snapshot ID to compare to (default: the latest)")
+	format := fs.String("format", "text", "Output format: text or json")
+	failOnNew := fs.Bool("fail-on-new", false, "Exit with status 1 when there are new or reopened findings")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui diff --app <name|guid> [options]")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *app == "" {
+		fs.Usage()
+		return fmt.Errorf("--app is required")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unsupported format %q, use text or json", *format)
+	}
+
+	t, err := resolveTarget(env, *app, *sandbox)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := env.Snapshots.List(t.app.GUID, t.sandboxGUID())
+	if err != nil {
+		return err
+	}
+	fromSnap, toSnap, err := pickSnapshots(snapshots, *from, *to)
+	if err != nil {
+		return err
+	}
+
+	diff := snapshot.Compare(fromSnap, toSnap)
+	if *format == "json" {
+		encoder := json.NewEncoder(env.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+	} else {
+		printDiff(env.Stdout, diff)
+	}
+
+	if *failOnNew && len(diff.New)+len(diff.Reopened) > 0 {
+		return fmt.Errorf("%d new and %d reopened findings", len(diff.New), len(diff.Reopened))
+	}
+	return nil
+}
+
+// pickSnapshots selects the snapshots to compare. By default the latest snapshot is compared
+// with the one before it that covers the same scan types.
+func pickSnapshots(snapshots []*snapshot.Snapshot, fromID, toID string) (*snapshot.Snapshot, *snapshot.Snapshot, error) {
+	find := func(id string) (int, error) {
+		for i, snap := range snapshots {
+			if snap.ID == id {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("snapshot %s not found; use 'snapshot --list' to see the recorded snapshots", id)
+	}
+
+	if len(snapshots) == 0 {
+		return nil, nil, fmt.Errorf("no snapshots recorded; take one with the snapshot command or by opening the findings in the TUI")
+	}
+
+	toIndex := len(snapshots) - 1
+	if toID != "" {
+		var err error
+		if toIndex, err = find(toID); err != nil {
+			return nil, nil, err
+		}
+	}
+	to := snapshots[toIndex]
+
+	if fromID != "" {
+		fromIndex, err := find(fromID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return snapshots[fromIndex], to, nil
+	}
+
+	for i := toIndex - 1; i >= 0; i-- {
+		if slices.Equal(snapshots[i].ScanTypes, to.ScanTypes) {
+			return snapshots[i], to, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no earlier snapshot of %v to compare %s with", to.ScanTypes, to.ID)
+}
+
+func printSnapshotList(w io.Writer, snapshots []*snapshot.Snapshot) {
+	if len(snapshots) == 0 {
+		fmt.Fprintln(w, "No snapshots recorded")
+		return
+	}
+	fmt.Fprintf(w, "%-20s  %-17s  %-20s  %8s  %6s\n", "ID", "Taken", "Scan Types", "Findings", "Open")
+	for _, snap := range snapshots {
+		fmt.Fprintf(w, "%-20s  %-17s  %-20v  %8d  %6d\n", snap.ID, snap.TakenAt.Local().Format("2006-01-02 15:04"), snap.ScanTypes, len(snap.Findings), snap.OpenCount())
+	}
+}
+
+func printDiff(w io.Writer, diff *snapshot.Diff) {
+	fmt.Fprintf(w, "Changes in %s - %s, scan types %v\n", diff.To.AppName, diff.To.ContextName(), diff.ScanTypes)
+	fmt.Fprintf(w, "From %s (%s) to %s (%s)\n", diff.From.TakenAt.Local().Format("2006-01-02 15:04"), diff.From.ID,
+		diff.To.TakenAt.Local().Format("2006-01-02 15:04"), diff.To.ID)
+
+	if diff.Empty() {
+		fmt.Fprintln(w, "\nNo changes")
+		return
+	}
+
+	for _, category := range diff.Categories() {
+		if len(category.Changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n", category.Name, len(category.Changes))
+		for i := range category.Changes {
+			change := &category.Changes[i]
+			record := change.Record()
+			severity := fmt.Sprintf("%d", record.Severity)
+			if change.Before != nil && change.After != nil && change.Before.Severity != change.After.Severity {
+				severity = fmt.Sprintf("%d->%d", change.Before.Severity, change.After.Severity)
+			}
+			fmt.Fprintf(w, "  #%-8d %-7s Sev %-4s  %s\n", change.IssueID, record.ScanType, severity, record.Describe())
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+var whereUsedCommand = &Command{
+	Name:    "where-used",
+	Summary: "Find the applications and sandboxes using a component or affected by a CVE",
+	Run:     runWhereUsed,
+}
+
+func runWhereUsed(env *Env, args []string) error {
+	fs := newFlagSet(env, "where-used")
+	policyOnly := fs.Bool("policy-only", false, "Only search policy scans, not sandboxes")
+	fresh := fs.Bool("fresh", false, "Ignore cached API responses")
+	format := fs.String("format", "text", "Output format: text or csv")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(env.Stderr, "Usage: veracode-tui where-used [options] <CVE|component[@version]>")
+		fmt.Fprintln(env.Stderr)
+		fmt.Fprintln(env.Stderr, "Examples: where-used CVE-2021-44228, where-used log4j-core, where-used log4j-core@2.14.1")
+		fmt.Fprintln(env.Stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one CVE or component to search for")
+	}
+	search, err := portfolio.ParseSearch(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *format != "text" && *format != "csv" {
+		return fmt.Errorf("unsupported format %q, use text or csv", *format)
+	}
+
+	appService, findingsService := env.CachedApplications, env.CachedFindings
+	if *fresh || appService == nil || findingsService == nil {
+		appService, findingsService = env.Applications, env.Findings
+	}
+
+	apps, err := appService.GetAllApplications(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+	fmt.Fprintf(env.Stderr, "Searching the SCA findings of %d applications for %s...\n", len(apps), search)
+
+	scanTypes := []string{string(findings.ScanTypeSCA)}
+	var names portfolio.SandboxNames
+	fetch := portfolio.ByScanType(findingsService, findings.GetFindingsOptions{}, scanTypes)
+	if !*policyOnly {
+		fetch = portfolio.WithSandboxes(findingsService, appService, findings.GetFindingsOptions{}, scanTypes, &names)
+	}
+	results := portfolio.Collect(apps, fetch, portfolio.DefaultWorkers, nil)
+	for i := range results {
+		if results[i].Err != nil {
+			fmt.Fprintf(env.Stderr, "Skipping %s: %v\n", results[i].AppName(), results[i].Err)
+		}
+	}
+
+	usages := portfolio.FindUsages(results, search, &names)
+	return writeOutput(env, *output, func(w io.Writer) error {
+		if *format == "csv" {
+			return portfolio.WriteUsagesCSV(w, usages)
+		}
+		printUsages(w, search, usages)
+		return nil
+	}, fmt.Sprintf("%d usages", len(usages)))
+}
+
+// printUsages lists the usages grouped by application, with the context, component version,
+// CVEs and component paths of each
+func printUsages(w io.Writer, search portfolio.Search, usages []portfolio.Usage) {
+	if len(usages) == 0 {
+		fmt.Fprintf(w, "%s was not found in any application\n", search)
+		return
+	}
+	fmt.Fprintf(w, "%s found in %d applications (%d component versions)\n", search, portfolio.UsageApplications(usages), len(usages))
+
+	application := ""
+	for i := range usages {
+		usage := &usages[i]
+		if usage.AppGUID != application {
+			application = usage.AppGUID
+			fmt.Fprintf(w, "\n%s\n", usage.Application)
+		}
+		fmt.Fprintf(w, "  %-20s %s %s  Sev %d  %s\n", usage.Context(), usage.Component, usage.Version,
+			usage.MaxSeverity, strings.Join(usage.CVEs, ", "))
+		for _, path := range usage.Paths {
+			fmt.Fprintf(w, "  %-20s   %s\n", "", path)
+		}
+	}
+}
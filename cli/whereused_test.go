@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+)
+
+func TestPrintUsagesGroupsByApplication(t *testing.T) {
+	search, _ := portfolio.ParseSearch("log4j-core")
+	usages := []portfolio.Usage{
+		{Application: "Shop", AppGUID: "1", Component: "log4j-core.jar", Version: "2.14.1", CVEs: []string{"CVE-2021-44228"}, MaxSeverity: 5,
+			Paths: []string{"shop.war > log4j-core.jar"}},
+		{Application: "Shop", AppGUID: "1", SandboxGUID: "s", Sandbox: "Release", Component: "log4j-core.jar", Version: "2.17.1"},
+		{Application: "Billing", AppGUID: "2", Component: "log4j-core.jar", Version: "2.14.1"},
+	}
+
+	var buf bytes.Buffer
+	printUsages(&buf, search, usages)
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "log4j-core found in 2 applications (3 component versions)") {
+		t.Errorf("Unexpected summary:\n%s", out)
+	}
+	if strings.Count(out, "\nShop\n") != 1 || !strings.Contains(out, "\nBilling\n") {
+		t.Errorf("Expected one heading per application:\n%s", out)
+	}
+	if !strings.Contains(out, "Release") || !strings.Contains(out, "shop.war > log4j-core.jar") {
+		t.Errorf("Expected the sandbox and component path:\n%s", out)
+	}
+
+	buf.Reset()
+	printUsages(&buf, search, nil)
+	if !strings.Contains(buf.String(), "was not found") {
+		t.Errorf("Unexpected output without usages: %q", buf.String())
+	}
+}
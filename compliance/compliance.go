@@ -0,0 +1,278 @@
+// Package compliance evaluates an application's findings against its policy locally, rule by
+// rule, to explain why the application does not pass and what needs fixing for it to pass.
+package compliance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/policies"
+)
+
+// Status is the outcome of a rule or of a whole evaluation. The compliance statuses match those
+// reported by the platform.
+type Status string
+
+// Evaluation outcomes
+const (
+	Passed          Status = "PASSED"
+	ConditionalPass Status = "CONDITIONAL_PASS"
+	DidNotPass      Status = "DID_NOT_PASS"
+	NotEvaluated    Status = "NOT_EVALUATED"
+)
+
+// Kind is the kind of requirement a rule result is for
+type Kind string
+
+// Requirement kinds
+const (
+	KindFindingRule   Kind = "Finding rule"
+	KindGracePeriod   Kind = "Grace period"
+	KindScanFrequency Kind = "Scan frequency"
+)
+
+// RuleResult is the evaluation of one requirement of a policy
+type RuleResult struct {
+	Kind        Kind
+	Rule        string              // What the policy requires
+	Status      Status              // Passed, ConditionalPass (broken within grace), DidNotPass or NotEvaluated
+	Explanation string              // Why the rule passes or fails
+	Findings    []*findings.Finding // The findings breaking the rule, most severe first
+}
+
+// Result is the evaluation of a policy
+type Result struct {
+	Policy *policies.Policy
+	Status Status
+	Rules  []RuleResult
+}
+
+// Input is what a policy is evaluated against
+type Input struct {
+	Policy   *policies.Policy
+	Findings []findings.Finding
+	Scans    []applications.ApplicationScan // For the scan frequency rules; nil skips them
+	Now      time.Time
+}
+
+// Evaluate checks each finding rule, the grace periods and the scan frequency rules of a policy.
+// Findings breaking a finding rule make the application conditionally pass while they are within
+// their grace period; once a grace period has expired, or a required scan is overdue, the
+// application does not pass. Closed and mitigated findings are ignored.
+func Evaluate(in Input) *Result {
+	result := &Result{Policy: in.Policy, Status: Passed}
+
+	breaking := make(map[*findings.Finding]bool)
+	for _, rule := range in.Policy.FindingRules {
+		r := evaluateFindingRule(in, rule)
+		for _, f := range r.Findings {
+			breaking[f] = true
+		}
+		result.add(r)
+	}
+
+	result.add(evaluateGracePeriods(in, breaking))
+	if in.Scans != nil {
+		for _, rule := range in.Policy.ScanFrequencyRules {
+			if rule.Frequency != policies.FrequencyNotRequired {
+				result.add(evaluateScanFrequency(in, rule))
+			}
+		}
+	}
+	return result
+}
+
+// add appends a rule result and lowers the overall status to match it
+func (r *Result) add(rule RuleResult) {
+	r.Rules = append(r.Rules, rule)
+	switch {
+	case rule.Status == DidNotPass:
+		r.Status = DidNotPass
+	case rule.Status == ConditionalPass && r.Status == Passed:
+		r.Status = ConditionalPass
+	}
+}
+
+// Passed reports whether the policy passes without conditions
+func (r *Result) Passed() bool {
+	return r.Status == Passed
+}
+
+// ToFix returns the findings that need fixing or mitigating for every finding rule to pass,
+// most severe first
+func (r *Result) ToFix() []*findings.Finding {
+	var list []*findings.Finding
+	for _, rule := range r.Rules {
+		if rule.Kind != KindFindingRule {
+			continue
+		}
+		for _, f := range rule.Findings {
+			if !slices.Contains(list, f) {
+				list = append(list, f)
+			}
+		}
+	}
+	slices.SortStableFunc(list, bySeverity(r.Policy))
+	return list
+}
+
+func evaluateFindingRule(in Input, rule policies.FindingRule) RuleResult {
+	result := RuleResult{Kind: KindFindingRule, Rule: rule.Describe(), Status: Passed}
+	if !rule.Evaluable() {
+		result.Status = NotEvaluated
+		result.Explanation = "Depends on data the findings do not carry; evaluated by the platform"
+		return result
+	}
+
+	result.Findings = rule.Violations(in.Findings, in.Policy)
+	slices.SortStableFunc(result.Findings, bySeverity(in.Policy))
+
+	if len(result.Findings) == 0 {
+		result.Explanation = fmt.Sprintf("No open findings break the rule (%s)", strings.Join(rule.ScanType, ", "))
+		return result
+	}
+	overdue := 0
+	for _, f := range result.Findings {
+		if expiry, ok := graceExpiry(in.Policy, f); ok && in.Now.After(expiry) {
+			overdue++
+		}
+	}
+	result.Status = ConditionalPass
+	if overdue > 0 {
+		result.Status = DidNotPass
+	}
+	result.Explanation = fmt.Sprintf("%d open findings break the rule, %d past their grace period", len(result.Findings), overdue)
+	return result
+}
+
+func evaluateGracePeriods(in Input, breaking map[*findings.Finding]bool) RuleResult {
+	result := RuleResult{
+		Kind:   KindGracePeriod,
+		Rule:   "Findings breaking a rule are fixed within the grace period for their severity",
+		Status: Passed,
+	}
+	for i := range in.Findings {
+		f := &in.Findings[i]
+		if !breaking[f] {
+			continue
+		}
+		if expiry, ok := graceExpiry(in.Policy, f); ok && in.Now.After(expiry) {
+			result.Findings = append(result.Findings, f)
+		}
+	}
+	slices.SortStableFunc(result.Findings, bySeverity(in.Policy))
+
+	if len(result.Findings) > 0 {
+		result.Status = DidNotPass
+		result.Explanation = fmt.Sprintf("%d findings are past their grace period", len(result.Findings))
+	} else {
+		result.Explanation = "No findings are past their grace period"
+	}
+	return result
+}
+
+// graceExpiry returns when the grace period of a finding expires: the date reported by the
+// platform, or the first found date plus the policy's grace period for the finding's severity
+func graceExpiry(policy *policies.Policy, f *findings.Finding) (time.Time, bool) {
+	if f.GracePeriodExpiresDate != nil {
+		return *f.GracePeriodExpiresDate, true
+	}
+	if f.FindingStatus == nil || f.FindingStatus.FirstFoundDate == nil {
+		return time.Time{}, false
+	}
+	days := policy.GracePeriod(policy.Severity(f), f.ScanType)
+	return f.FindingStatus.FirstFoundDate.AddDate(0, 0, days), true
+}
+
+func evaluateScanFrequency(in Input, rule policies.ScanFrequencyRule) RuleResult {
+	frequency := strings.ToLower(strings.ReplaceAll(string(rule.Frequency), "_", " "))
+	result := RuleResult{
+		Kind:   KindScanFrequency,
+		Rule:   fmt.Sprintf("%s scan required %s", rule.ScanType, frequency),
+		Status: Passed,
+	}
+
+	var last *time.Time
+	for _, scan := range in.Scans {
+		if scan.ModifiedDate == nil || !(strings.EqualFold(rule.ScanType, "ANY") || strings.EqualFold(scan.ScanType, rule.ScanType)) {
+			continue
+		}
+		if last == nil || scan.ModifiedDate.After(*last) {
+			last = scan.ModifiedDate
+		}
+	}
+	if last == nil {
+		result.Status = DidNotPass
+		result.Explanation = fmt.Sprintf("No %s scan has been run", rule.ScanType)
+		return result
+	}
+
+	due, ok := nextScanDue(*last, rule.Frequency)
+	switch {
+	case !ok:
+		result.Explanation = fmt.Sprintf("Last scanned %s", last.Format("2006-01-02"))
+	case in.Now.After(due):
+		result.Status = DidNotPass
+		result.Explanation = fmt.Sprintf("Last scanned %s, the next scan was due %s", last.Format("2006-01-02"), due.Format("2006-01-02"))
+	default:
+		result.Explanation = fmt.Sprintf("Last scanned %s, next scan due %s", last.Format("2006-01-02"), due.Format("2006-01-02"))
+	}
+	return result
+}
+
+// nextScanDue returns when the scan after one run at last is due. Frequencies that only require a
+// scan to have been run once have no due date.
+func nextScanDue(last time.Time, frequency policies.Frequency) (time.Time, bool) {
+	switch frequency {
+	case policies.FrequencyDaily:
+		return last.AddDate(0, 0, 1), true
+	case policies.FrequencyWeekly:
+		return last.AddDate(0, 0, 7), true
+	case policies.FrequencyMonthly:
+		return last.AddDate(0, 1, 0), true
+	case policies.FrequencyQuarterly:
+		return last.AddDate(0, 3, 0), true
+	case policies.FrequencySemiAnnually:
+		return last.AddDate(0, 6, 0), true
+	case policies.FrequencyAnnually:
+		return last.AddDate(1, 0, 0), true
+	}
+	return time.Time{}, false
+}
+
+func bySeverity(policy *policies.Policy) func(a, b *findings.Finding) int {
+	return func(a, b *findings.Finding) int {
+		if d := policy.Severity(b) - policy.Severity(a); d != 0 {
+			return d
+		}
+		switch {
+		case a.IssueID < b.IssueID:
+			return -1
+		case a.IssueID > b.IssueID:
+			return 1
+		}
+		return 0
+	}
+}
+
+// AssumeMitigated returns a copy of the findings in which those with the given issue IDs have an
+// approved mitigation, to simulate the compliance of the application once they are mitigated
+func AssumeMitigated(list []findings.Finding, issueIDs []int64) []findings.Finding {
+	simulated := slices.Clone(list)
+	for i := range simulated {
+		if !slices.Contains(issueIDs, simulated[i].IssueID) {
+			continue
+		}
+		status := findings.FindingStatus{}
+		if simulated[i].FindingStatus != nil {
+			status = *simulated[i].FindingStatus
+		}
+		status.ResolutionStatus = findings.ResolutionApproved
+		simulated[i].FindingStatus = &status
+	}
+	return simulated
+}
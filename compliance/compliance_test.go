@@ -0,0 +1,123 @@
+package compliance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/policies"
+)
+
+var now = time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+func decodeFindings(t *testing.T, data string) []findings.Finding {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Invalid findings: %v", err)
+	}
+	return list
+}
+
+func testPolicy() *policies.Policy {
+	return &policies.Policy{
+		Name: "Recommended High",
+		FindingRules: []policies.FindingRule{
+			{Type: policies.RuleMaxSeverity, ScanType: []string{"STATIC", "DYNAMIC"}, Value: "4"},
+			{Type: policies.RuleCWE, ScanType: []string{"STATIC"}, Value: "117"},
+			{Type: policies.RuleMinScore, ScanType: []string{"STATIC"}, Value: "80"},
+		},
+		ScanFrequencyRules: []policies.ScanFrequencyRule{{ScanType: "STATIC", Frequency: policies.FrequencyQuarterly}},
+		CustomSeverities:   []policies.CustomSeverity{{CWE: 117, Severity: 4}},
+		Sev5GracePeriod:    7,
+		Sev4GracePeriod:    30,
+	}
+}
+
+const testFindings = `[
+	{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "first_found_date": "2025-06-01T00:00:00Z"},
+	 "finding_details": {"severity": 4, "cwe": {"id": 89}}},
+	{"issue_id": 2, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "first_found_date": "2025-01-01T00:00:00Z"},
+	 "finding_details": {"severity": 2, "cwe": {"id": 117}}},
+	{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "resolution_status": "APPROVED"},
+	 "finding_details": {"severity": 5, "cwe": {"id": 78}}},
+	{"issue_id": 4, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 3, "cwe": {"id": 80}}}
+]`
+
+func recentScans() []applications.ApplicationScan {
+	scanned := now.AddDate(0, -1, 0)
+	return []applications.ApplicationScan{{ScanType: "STATIC", ModifiedDate: &scanned}}
+}
+
+func TestEvaluateExplainsRules(t *testing.T) {
+	result := Evaluate(Input{Policy: testPolicy(), Findings: decodeFindings(t, testFindings), Scans: recentScans(), Now: now})
+
+	if result.Status != DidNotPass {
+		t.Fatalf("Expected the policy not to pass, got %s", result.Status)
+	}
+	if len(result.Rules) != 5 {
+		t.Fatalf("Expected 3 finding rules, grace periods and scan frequency, got %d", len(result.Rules))
+	}
+
+	// The CWE-117 finding has a custom severity of 4, so it breaks both finding rules
+	severity := result.Rules[0]
+	if severity.Status != DidNotPass || len(severity.Findings) != 2 || severity.Findings[0].IssueID != 1 {
+		t.Errorf("Unexpected max severity result %+v", severity)
+	}
+	if cwe := result.Rules[1]; cwe.Status != DidNotPass || len(cwe.Findings) != 1 || cwe.Findings[0].IssueID != 2 {
+		t.Errorf("Unexpected CWE result %+v", cwe)
+	}
+	if score := result.Rules[2]; score.Status != NotEvaluated {
+		t.Errorf("Expected the score rule not to be evaluated, got %s", score.Status)
+	}
+	if grace := result.Rules[3]; grace.Status != DidNotPass || len(grace.Findings) != 1 || grace.Findings[0].IssueID != 2 {
+		t.Errorf("Expected only the old finding past its grace period, got %+v", grace)
+	}
+	if frequency := result.Rules[4]; frequency.Status != Passed {
+		t.Errorf("Expected the scan frequency rule to pass, got %+v", frequency)
+	}
+	if toFix := result.ToFix(); len(toFix) != 2 {
+		t.Errorf("Expected two findings to fix, got %d", len(toFix))
+	}
+}
+
+func TestEvaluateWhatIfMitigated(t *testing.T) {
+	list := decodeFindings(t, testFindings)
+
+	result := Evaluate(Input{Policy: testPolicy(), Findings: AssumeMitigated(list, []int64{2}), Scans: recentScans(), Now: now})
+	if result.Status != ConditionalPass {
+		t.Errorf("Expected a conditional pass with the remaining finding within its grace period, got %s", result.Status)
+	}
+
+	result = Evaluate(Input{Policy: testPolicy(), Findings: AssumeMitigated(list, []int64{1, 2}), Scans: recentScans(), Now: now})
+	if !result.Passed() {
+		t.Errorf("Expected the policy to pass, got %s", result.Status)
+	}
+
+	if list[1].IsMitigated() {
+		t.Error("AssumeMitigated must not change the original findings")
+	}
+}
+
+func TestEvaluateScanFrequency(t *testing.T) {
+	policy := &policies.Policy{ScanFrequencyRules: []policies.ScanFrequencyRule{
+		{ScanType: "STATIC", Frequency: policies.FrequencyMonthly},
+		{ScanType: "DYNAMIC", Frequency: policies.FrequencyOnce},
+		{ScanType: "SCA", Frequency: policies.FrequencyNotRequired},
+	}}
+	scanned := now.AddDate(0, -2, 0)
+	scans := []applications.ApplicationScan{{ScanType: "STATIC", ModifiedDate: &scanned}}
+
+	result := Evaluate(Input{Policy: policy, Scans: scans, Now: now})
+	if len(result.Rules) != 3 {
+		t.Fatalf("Expected the grace period and two scan frequency results, got %d", len(result.Rules))
+	}
+	if static := result.Rules[1]; static.Status != DidNotPass {
+		t.Errorf("Expected the monthly static scan to be overdue, got %+v", static)
+	}
+	if dynamic := result.Rules[2]; dynamic.Status != DidNotPass {
+		t.Errorf("Expected the missing dynamic scan to fail, got %+v", dynamic)
+	}
+}
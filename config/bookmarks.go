@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bookmarks holds the saved findings views
+type Bookmarks struct {
+	Items []Bookmark `yaml:"bookmarks,omitempty"`
+
+	path string
+}
+
+// Bookmark is a named findings view: an application, an optional sandbox and the filters to apply
+type Bookmark struct {
+	Name        string `yaml:"name"`
+	AppGUID     string `yaml:"app-guid"`
+	AppName     string `yaml:"app-name,omitempty"`
+	SandboxGUID string `yaml:"sandbox-guid,omitempty"` // Empty for the policy scan
+	SandboxName string `yaml:"sandbox-name,omitempty"`
+	ScanType    string `yaml:"scan-type,omitempty"`    // STATIC, DYNAMIC or SCA
+	MinSeverity int    `yaml:"min-severity,omitempty"` // 0 means all severities
+	Policy      string `yaml:"policy,omitempty"`       // All, Violations or Non-Violations
+	Query       string `yaml:"query,omitempty"`        // Quick filter text or filter expression
+}
+
+// LoadBookmarks reads the bookmarks file from the state directory.
+// A missing file is not an error and returns no bookmarks.
+func LoadBookmarks() (*Bookmarks, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadBookmarksFrom(filepath.Join(dir, "bookmarks.yml"))
+}
+
+// LoadBookmarksFrom reads the bookmarks file at the given path.
+// A missing file is not an error and returns no bookmarks.
+func LoadBookmarksFrom(path string) (*Bookmarks, error) {
+	bookmarks := &Bookmarks{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return bookmarks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, bookmarks); err != nil {
+		return nil, fmt.Errorf("failed to parse bookmarks file: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+// Save writes the bookmarks back to the file they were loaded from
+func (b *Bookmarks) Save() error {
+	if b.path == "" {
+		return fmt.Errorf("bookmarks have no file path")
+	}
+
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode bookmarks: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create bookmarks directory: %w", err)
+	}
+
+	if err := os.WriteFile(b.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write bookmarks file %s: %w", b.path, err)
+	}
+
+	return nil
+}
+
+// Find returns the bookmark with the given name (case-insensitive), or nil if there is none
+func (b *Bookmarks) Find(name string) *Bookmark {
+	for i := range b.Items {
+		if strings.EqualFold(b.Items[i].Name, name) {
+			return &b.Items[i]
+		}
+	}
+	return nil
+}
+
+// Set adds a bookmark, replacing any existing bookmark with the same name
+func (b *Bookmarks) Set(bookmark Bookmark) {
+	if existing := b.Find(bookmark.Name); existing != nil {
+		*existing = bookmark
+		return
+	}
+	b.Items = append(b.Items, bookmark)
+}
+
+// Remove deletes the bookmark with the given name and reports whether it existed
+func (b *Bookmarks) Remove(name string) bool {
+	for i := range b.Items {
+		if strings.EqualFold(b.Items[i].Name, name) {
+			b.Items = append(b.Items[:i], b.Items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
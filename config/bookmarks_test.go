@@ -0,0 +1,61 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBookmarksSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "bookmarks.yml")
+
+	bookmarks, err := LoadBookmarksFrom(path)
+	if err != nil {
+		t.Fatalf("Expected no error for missing file, got: %v", err)
+	}
+	if len(bookmarks.Items) != 0 {
+		t.Fatalf("Expected no bookmarks, got %d", len(bookmarks.Items))
+	}
+
+	bookmarks.Set(Bookmark{Name: "API criticals", AppGUID: "app-1", ScanType: "STATIC", MinSeverity: 4, Query: `file~"src/api/"`})
+	bookmarks.Set(Bookmark{Name: "Sandbox SCA", AppGUID: "app-2", SandboxGUID: "sb-1", ScanType: "SCA"})
+
+	if err := bookmarks.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadBookmarksFrom(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if len(loaded.Items) != 2 {
+		t.Fatalf("Expected 2 bookmarks, got %d", len(loaded.Items))
+	}
+
+	bookmark := loaded.Find("api CRITICALS")
+	if bookmark == nil {
+		t.Fatal("Expected case-insensitive lookup to find the bookmark")
+	}
+	if bookmark.MinSeverity != 4 || bookmark.Query != `file~"src/api/"` {
+		t.Errorf("Unexpected bookmark: %+v", bookmark)
+	}
+}
+
+func TestBookmarksSetReplacesAndRemove(t *testing.T) {
+	bookmarks := &Bookmarks{}
+	bookmarks.Set(Bookmark{Name: "Daily", AppGUID: "app-1"})
+	bookmarks.Set(Bookmark{Name: "daily", AppGUID: "app-2"})
+
+	if len(bookmarks.Items) != 1 || bookmarks.Items[0].AppGUID != "app-2" {
+		t.Errorf("Expected the bookmark to be replaced, got %+v", bookmarks.Items)
+	}
+
+	if !bookmarks.Remove("DAILY") {
+		t.Error("Expected Remove to report the bookmark existed")
+	}
+	if bookmarks.Remove("daily") {
+		t.Error("Expected Remove to report a missing bookmark")
+	}
+	if err := bookmarks.Save(); err == nil {
+		t.Error("Expected Save without a path to fail")
+	}
+}
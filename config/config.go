@@ -1,9 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -21,15 +23,26 @@ type VeracodeConfig struct {
 	Packager map[string]interface{} `yaml:"packager"`
 }
 
-// LoadConfig reads and parses the Veracode configuration file
-func LoadConfig() (*VeracodeConfig, error) {
+// ConfigPath returns the path of the Veracode configuration file (~/.veracode/veracode.yml)
+func ConfigPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".veracode", "veracode.yml"), nil
+}
 
-	configPath := filepath.Join(homeDir, ".veracode", "veracode.yml")
+// LoadConfig reads and parses the Veracode configuration file
+func LoadConfig() (*VeracodeConfig, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadConfigFrom(configPath)
+}
 
+// LoadConfigFrom reads and parses the Veracode configuration file at the given path
+func LoadConfigFrom(configPath string) (*VeracodeConfig, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
@@ -51,3 +64,84 @@ func LoadConfig() (*VeracodeConfig, error) {
 func (c *VeracodeConfig) GetAPICredentials() (keyID, keySecret string) {
 	return c.API.KeyID, c.API.KeySecret
 }
+
+// UpdateAPICredentials replaces the key-id and key-secret of the api section of the configuration
+// file at configPath, keeping the rest of the file. The current file is first copied to a
+// timestamped backup next to it, whose path is returned; the new file is written to a temporary
+// file and renamed over the old one, so that it is never left half written.
+func UpdateAPICredentials(configPath, keyID, keySecret string, now time.Time) (string, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("config file %s is not a YAML mapping", configPath)
+	}
+	api := mappingValue(doc.Content[0], "api", yaml.MappingNode)
+	setScalar(api, "key-id", keyID)
+	setScalar(api, "key-secret", keySecret)
+
+	var updated bytes.Buffer
+	encoder := yaml.NewEncoder(&updated)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	backupPath := fmt.Sprintf("%s.%s.bak", configPath, now.Format("20060102-150405"))
+	if err := os.WriteFile(backupPath, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to back up config file: %w", err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(configPath), ".veracode-*.yml")
+	if err != nil {
+		return backupPath, fmt.Errorf("failed to create config file: %w", err)
+	}
+	defer os.Remove(temp.Name()) // Fails harmlessly once renamed
+	if _, err := temp.Write(updated.Bytes()); err != nil {
+		_ = temp.Close()
+		return backupPath, fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return backupPath, fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(temp.Name(), 0o600); err != nil {
+		return backupPath, fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(temp.Name(), configPath); err != nil {
+		return backupPath, fmt.Errorf("failed to replace config file: %w", err)
+	}
+	return backupPath, nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, adding it with the given kind when missing
+func mappingValue(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if value.Kind != kind {
+				*value = yaml.Node{Kind: kind}
+			}
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// setScalar sets key in a YAML mapping to a string value
+func setScalar(mapping *yaml.Node, key, value string) {
+	node := mappingValue(mapping, key, yaml.ScalarNode)
+	node.Tag = "!!str"
+	node.Value = value
+	node.Style = 0
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateAPICredentials(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "veracode.yml")
+	original := `# Veracode credentials
+api:
+  key-id: old-id # rotated yearly
+  key-secret: old-secret
+packager:
+  trust: true
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 6, 1, 9, 30, 0, 0, time.UTC)
+	backup, err := UpdateAPICredentials(path, "new-id", "new-secret", now)
+	if err != nil {
+		t.Fatalf("UpdateAPICredentials failed: %v", err)
+	}
+
+	if backup != path+".20260601-093000.bak" {
+		t.Errorf("Unexpected backup path %s", backup)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != original {
+		t.Errorf("Expected the backup to hold the original file, got %q, %v", data, err)
+	}
+
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("Failed to load the updated file: %v", err)
+	}
+	if keyID, keySecret := cfg.GetAPICredentials(); keyID != "new-id" || keySecret != "new-secret" {
+		t.Errorf("Credentials = %s, %s", keyID, keySecret)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# Veracode credentials", "# rotated yearly", "  trust: true"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected the updated file to keep %q, got:\n%s", want, data)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the file to be private, got %v, %v", info.Mode(), err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Expected only the file and its backup, got %d entries", len(entries))
+	}
+}
+
+func TestUpdateAPICredentialsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "veracode.yml")
+	if _, err := UpdateAPICredentials(path, "id", "secret", time.Now()); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
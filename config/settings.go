@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCredentialWarningDays is how many days before the API credentials expire a warning is shown
+const DefaultCredentialWarningDays = 14
+
+// Settings holds user preferences that persist between sessions
+type Settings struct {
+	Tables map[string]TableLayout `yaml:"tables,omitempty"`
+	// CredentialWarningDays overrides DefaultCredentialWarningDays when set
+	CredentialWarningDays int `yaml:"credential-warning-days,omitempty"`
+
+	path string
+}
+
+// TableLayout stores the sort order and column visibility for a table view
+type TableLayout struct {
+	SortColumn     string   `yaml:"sort-column,omitempty"`
+	SortDescending bool     `yaml:"sort-descending,omitempty"`
+	HiddenColumns  []string `yaml:"hidden-columns,omitempty"`
+}
+
+// StateDir returns the directory used for veracode-tui state (~/.veracode/veracode-tui)
+func StateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".veracode", "veracode-tui"), nil
+}
+
+// LoadSettings reads the settings file from the state directory.
+// A missing file is not an error and returns empty settings.
+func LoadSettings() (*Settings, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadSettingsFrom(filepath.Join(dir, "settings.yml"))
+}
+
+// LoadSettingsFrom reads the settings file at the given path.
+// A missing file is not an error and returns empty settings.
+func LoadSettingsFrom(path string) (*Settings, error) {
+	settings := &Settings{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Save writes the settings back to the file they were loaded from
+func (s *Settings) Save() error {
+	if s.path == "" {
+		return fmt.Errorf("settings have no file path")
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write settings file %s: %w", s.path, err)
+	}
+
+	return nil
+}
+
+// CredentialWarning returns how many days before the API credentials expire a warning is shown
+func (s *Settings) CredentialWarning() int {
+	if s == nil || s.CredentialWarningDays <= 0 {
+		return DefaultCredentialWarningDays
+	}
+	return s.CredentialWarningDays
+}
+
+// TableLayout returns the stored layout for the named table
+func (s *Settings) TableLayout(name string) TableLayout {
+	return s.Tables[name]
+}
+
+// SetTableLayout stores the layout for the named table
+func (s *Settings) SetTableLayout(name string, layout TableLayout) {
+	if s.Tables == nil {
+		s.Tables = make(map[string]TableLayout)
+	}
+	s.Tables[name] = layout
+}
+
+// IsHidden reports whether the column with the given key is hidden
+func (l TableLayout) IsHidden(key string) bool {
+	for _, hidden := range l.HiddenColumns {
+		if hidden == key {
+			return true
+		}
+	}
+	return false
+}
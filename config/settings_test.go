@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSettingsFromMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.yml")
+
+	settings, err := LoadSettingsFrom(path)
+	if err != nil {
+		t.Fatalf("Expected no error for missing file, got: %v", err)
+	}
+
+	if len(settings.Tables) != 0 {
+		t.Errorf("Expected empty tables, got %d entries", len(settings.Tables))
+	}
+}
+
+func TestSettingsSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "settings.yml")
+
+	settings, err := LoadSettingsFrom(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings.SetTableLayout("findings-static", TableLayout{
+		SortColumn:     "cwe",
+		SortDescending: true,
+		HiddenColumns:  []string{"attack-vector", "module"},
+	})
+
+	if err := settings.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadSettingsFrom(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	layout := loaded.TableLayout("findings-static")
+	if layout.SortColumn != "cwe" || !layout.SortDescending {
+		t.Errorf("Expected sort cwe descending, got %s descending=%v", layout.SortColumn, layout.SortDescending)
+	}
+	if !layout.IsHidden("module") || !layout.IsHidden("attack-vector") {
+		t.Errorf("Expected module and attack-vector hidden, got %v", layout.HiddenColumns)
+	}
+	if layout.IsHidden("cwe") {
+		t.Error("Expected cwe to be visible")
+	}
+}
+
+func TestLoadSettingsFromInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.yml")
+	if err := os.WriteFile(path, []byte("tables: [not, a, map"), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	if _, err := LoadSettingsFrom(path); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
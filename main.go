@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/dipsylala/veracode-tui/cache"
+	"github.com/dipsylala/veracode-tui/cli"
 	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/analytics"
 	"github.com/dipsylala/veracode-tui/services/annotations"
 	"github.com/dipsylala/veracode-tui/services/applications"
 	"github.com/dipsylala/veracode-tui/services/findings"
 	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/dipsylala/veracode-tui/services/reports"
+	"github.com/dipsylala/veracode-tui/snapshot"
 	"github.com/dipsylala/veracode-tui/ui"
 	"github.com/dipsylala/veracode-tui/veracode"
 )
@@ -24,6 +33,8 @@ func main() {
 	noColor := flag.Bool("no-color", false, "Disable colors (monochrome mode)")
 	theme := flag.String("theme", "default", "Color theme to use (default, bw, hotdog, matrix)")
 	debugLog := flag.String("debug-log", "", "Enable debug logging of REST requests/responses to the specified file")
+	offline := flag.Bool("offline", false, "Browse the last-synced data from the local cache without contacting the API")
+	noCache := flag.Bool("no-cache", false, "Disable the local cache of API responses")
 	flag.Parse()
 
 	if *help {
@@ -37,9 +48,16 @@ func main() {
 		fmt.Println("  veracode-tui --theme <name>        Set color theme: default, bw, hotdog, matrix (default: default)")
 		fmt.Println("  veracode-tui --help                Show this help message")
 		fmt.Println("  veracode-tui --debug-log <file>    Log all REST requests/responses to file")
+		fmt.Println("  veracode-tui --offline             Browse the last-synced data without contacting the API")
+		fmt.Println("  veracode-tui --no-cache            Disable the local cache of API responses")
+		fmt.Println()
+		fmt.Println("Commands:")
+		cli.PrintUsage(os.Stdout)
+		fmt.Println("  Run 'veracode-tui <command> --help' for command options")
 		fmt.Println()
 		fmt.Println("Configuration:")
 		fmt.Println("  Reads credentials from ~/.veracode/veracode.yml")
+		fmt.Println("  Caches API responses in ~/.veracode/veracode-tui/cache (delete it to clear the cache)")
 		fmt.Println()
 		fmt.Println("Environment Variables:")
 		fmt.Println("  NO_COLOR                           When set, disables colors (overrides --no-color)")
@@ -49,11 +67,16 @@ func main() {
 
 	if *version {
 		fmt.Printf("Veracode TUI v%s\n", Version)
-		fmt.Println("Built with Go - A read-only interface to Veracode API")
+		fmt.Println("Built with Go - A terminal interface to the Veracode API")
 		os.Exit(0)
 	}
 
-	cfg, err := config.LoadConfig()
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.LoadConfigFrom(configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		fmt.Fprintf(os.Stderr, "Please ensure ~/.veracode/veracode.yml exists with valid API credentials\n")
@@ -79,13 +102,70 @@ func main() {
 			os.Exit(1)
 		}
 		fmt.Println("✅ Healthcheck successful - API is operational and credentials are valid")
+		printCredentialExpiry(identity.NewService(client))
 		os.Exit(0)
 	}
 
-	appService := applications.NewService(client)
-	findingsService := findings.NewService(client)
-	identityService := identity.NewService(client)
-	annotationsService := annotations.NewService(client)
+	stateDir, err := config.StateDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cachingClient, err := newCachingClient(client, stateDir, keyID, *offline, *noCache)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	snapshots := snapshot.NewStore(filepath.Join(stateDir, "snapshots"))
+
+	if args := flag.Args(); len(args) > 0 {
+		// Commands always fetch current data unless offline
+		var cliClient cache.Upstream = client
+		if *offline {
+			cliClient = cachingClient
+		}
+		// Portfolio-wide commands reuse fresh cached responses, but never stale ones
+		var cachedClient cache.Upstream = cliClient
+		if cachingClient != nil {
+			cachingClient.SetServeStale(false)
+			cachedClient = cachingClient
+		}
+		bookmarks, err := config.LoadBookmarks()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		os.Exit(cli.Run(&cli.Env{
+			Applications:       applications.NewService(cliClient),
+			Findings:           findings.NewService(cliClient),
+			Policies:           policies.NewService(cliClient),
+			Analytics:          analytics.NewService(cliClient),
+			Identity:           identity.NewService(cliClient),
+			CachedApplications: applications.NewService(cachedClient),
+			CachedFindings:     findings.NewService(cachedClient),
+			Bookmarks:          bookmarks,
+			Snapshots:          snapshots,
+			Stdout:             os.Stdout,
+			Stderr:             os.Stderr,
+			Version:            Version,
+			ConfigPath:         configPath,
+			WriteCredentials:   config.UpdateAPICredentials,
+			CheckCredentials: func(keyID, keySecret string) error {
+				return veracode.NewClient(keyID, keySecret).HealthCheck()
+			},
+		}, args))
+	}
+
+	var serviceClient cache.Upstream = client
+	if cachingClient != nil {
+		serviceClient = cachingClient
+	}
+	appService := applications.NewService(serviceClient)
+	findingsService := findings.NewService(serviceClient)
+	identityService := identity.NewService(serviceClient)
+	annotationsService := annotations.NewService(serviceClient)
+	policiesService := policies.NewService(serviceClient)
+	reportsService := reports.NewService(serviceClient)
 
 	var selectedTheme *ui.Theme
 	if os.Getenv("NO_COLOR") != "" || *noColor {
@@ -103,9 +183,49 @@ func main() {
 		}
 	}
 
-	tui := ui.NewUI(appService, findingsService, identityService, annotationsService, selectedTheme)
+	tui := ui.NewUI(appService, findingsService, identityService, annotationsService, policiesService, reportsService, selectedTheme)
+	tui.SetSnapshots(snapshots)
+	tui.SetVersion(Version)
+	if cachingClient != nil {
+		tui.SetCache(cachingClient)
+	}
 	if err := tui.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// printCredentialExpiry reports when the API credentials expire, with a warning when that is
+// within the configured number of days. Failing to read the expiry does not fail the healthcheck.
+func printCredentialExpiry(identityService *identity.Service) {
+	creds, err := identityService.GetAPICredentials(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not read the API credential expiry: %v\n", err)
+		return
+	}
+
+	warningDays := config.DefaultCredentialWarningDays
+	if settings, err := config.LoadSettings(); err == nil {
+		warningDays = settings.CredentialWarning()
+	}
+
+	now := time.Now()
+	fmt.Printf("🔑 API credentials %s %s\n", creds.APIID, creds.DescribeExpiry(now))
+	if creds.ExpiresWithin(warningDays, now) {
+		fmt.Println("⚠️  Rotate them with: veracode-tui rotate-credentials --yes")
+	}
+}
+
+// newCachingClient returns the disk-backed cache in front of the API client, or nil when caching is disabled
+func newCachingClient(client *veracode.Client, stateDir, keyID string, offline, noCache bool) (*cache.Client, error) {
+	if noCache {
+		if offline {
+			return nil, fmt.Errorf("--offline cannot be combined with --no-cache")
+		}
+		return nil, nil
+	}
+
+	cachingClient := cache.NewClient(client, cache.NewStore(filepath.Join(stateDir, "cache", cache.Namespace(keyID))))
+	cachingClient.SetOffline(offline)
+	return cachingClient, nil
+}
@@ -0,0 +1,25 @@
+package portfolio
+
+import "strings"
+
+// formulaPrefixes are the leading characters that make spreadsheet applications evaluate a
+// cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// SafeCSVField returns s prefixed with a single quote when it starts with a formula character,
+// so that a spreadsheet opening the CSV shows component names, descriptions and paths from the
+// API as text instead of evaluating them
+func SafeCSVField(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// SafeCSVRecord applies SafeCSVField to each field of record, in place, and returns it
+func SafeCSVRecord(record []string) []string {
+	for i, field := range record {
+		record[i] = SafeCSVField(field)
+	}
+	return record
+}
@@ -0,0 +1,41 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestSafeCSVField(t *testing.T) {
+	tests := map[string]string{
+		"":                         "",
+		"log4j-core":               "log4j-core",
+		"=HYPERLINK(\"http://x\")": "'=HYPERLINK(\"http://x\")",
+		"+1":                       "'+1",
+		"-2+3":                     "'-2+3",
+		"@SUM(A1)":                 "'@SUM(A1)",
+		"\t=1":                     "'\t=1",
+		"a=b":                      "a=b",
+	}
+	for field, want := range tests {
+		if got := SafeCSVField(field); got != want {
+			t.Errorf("SafeCSVField(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestWriteLicensesCSVQuotesFormulas(t *testing.T) {
+	rows := []ComponentLicense{{Component: "=cmd|' /C calc'!A0", Version: "1.0", Applications: []string{"@Shop"}}}
+
+	var buf bytes.Buffer
+	if err := WriteLicensesCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteLicensesCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected a header and 1 row of valid CSV, got %d, %v", len(records), err)
+	}
+	if records[1][0] != "'=cmd|' /C calc'!A0" || records[1][7] != "'@Shop" {
+		t.Errorf("Expected the formulas to be quoted, got %v", records[1])
+	}
+}
@@ -0,0 +1,126 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// ComponentLicense is a license of a component version and the applications that use it
+type ComponentLicense struct {
+	Component    string
+	Version      string
+	License      findings.License
+	Applications []string // Sorted application names
+}
+
+// LicenseFilter selects which licenses are reported
+type LicenseFilter struct {
+	HighRisk bool // Only high risk licenses
+	Copyleft bool // Only copyleft licenses
+}
+
+// Match reports whether a license passes the filter. When both options are set, a license
+// matching either of them is reported.
+func (f LicenseFilter) Match(license findings.License) bool {
+	if !f.HighRisk && !f.Copyleft {
+		return true
+	}
+	return (f.HighRisk && license.Risk() == findings.LicenseRiskHigh) || (f.Copyleft && license.IsCopyleft())
+}
+
+// Licenses aggregates the component licenses of the SCA findings of each application.
+// Components without license information are reported with an empty license ID.
+// Results are sorted by risk, highest first, then by component, version and license.
+func Licenses(results []AppFindings, filter LicenseFilter) []ComponentLicense {
+	byKey := make(map[string]*ComponentLicense)
+	apps := make(map[string]map[string]bool)
+
+	for i := range results {
+		result := &results[i]
+		if result.Err != nil {
+			continue
+		}
+		for j := range result.Findings {
+			f := &result.Findings[j]
+			if f.ScanType != findings.ScanTypeSCA || f.Component() == "" {
+				continue
+			}
+			licenses := f.Licenses()
+			if len(licenses) == 0 {
+				licenses = []findings.License{{}}
+			}
+			for _, license := range licenses {
+				if !filter.Match(license) {
+					continue
+				}
+				key := f.Component() + "\x00" + f.ComponentVersion() + "\x00" + license.ID
+				entry, ok := byKey[key]
+				if !ok {
+					entry = &ComponentLicense{Component: f.Component(), Version: f.ComponentVersion(), License: license}
+					byKey[key] = entry
+					apps[key] = make(map[string]bool)
+				}
+				apps[key][result.AppName()] = true
+			}
+		}
+	}
+
+	rows := make([]ComponentLicense, 0, len(byKey))
+	for key, entry := range byKey {
+		for name := range apps[key] {
+			entry.Applications = append(entry.Applications, name)
+		}
+		sort.Strings(entry.Applications)
+		rows = append(rows, *entry)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.License.Risk() != b.License.Risk() {
+			return a.License.Risk() > b.License.Risk()
+		}
+		if a.Component != b.Component {
+			return strings.ToLower(a.Component) < strings.ToLower(b.Component)
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.License.ID < b.License.ID
+	})
+	return rows
+}
+
+// WriteLicensesCSV writes the component licenses as CSV, one row per component version and license
+func WriteLicensesCSV(w io.Writer, rows []ComponentLicense) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"component", "version", "license", "risk", "risk_rating", "copyleft", "application_count", "applications"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range rows {
+		record := []string{
+			row.Component,
+			row.Version,
+			row.License.ID,
+			row.License.Risk().String(),
+			row.License.RiskRating,
+			strconv.FormatBool(row.License.IsCopyleft()),
+			strconv.Itoa(len(row.Applications)),
+			strings.Join(row.Applications, "; "),
+		}
+		if err := cw.Write(SafeCSVRecord(record)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+func scaFindings(t *testing.T, data string) []findings.Finding {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	return list
+}
+
+func TestLicenses(t *testing.T) {
+	shared := `{"scan_type": "SCA", "finding_details": {"component_filename": "mysql-connector.jar", "version": "8.0.1",
+		"licenses": [{"license_id": "GPL-2.0-only", "risk_rating": "4"}]}}`
+	results := []AppFindings{
+		{App: &applications.Application{GUID: "1", Profile: &applications.ApplicationProfile{Name: "Shop"}}, Findings: scaFindings(t, `[`+shared+`,
+			{"scan_type": "SCA", "finding_details": {"component_filename": "commons-lang.jar", "version": "3.1", "licenses": [{"license_id": "Apache-2.0", "risk_rating": "1"}]}},
+			{"scan_type": "SCA", "finding_details": {"component_filename": "commons-lang.jar", "version": "3.1", "licenses": [{"license_id": "Apache-2.0", "risk_rating": "1"}]}}
+		]`)},
+		{App: &applications.Application{GUID: "2", Profile: &applications.ApplicationProfile{Name: "Billing"}}, Findings: scaFindings(t, `[`+shared+`]`)},
+	}
+
+	rows := Licenses(results, LicenseFilter{})
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 component licenses, got %d", len(rows))
+	}
+	if rows[0].Component != "mysql-connector.jar" || len(rows[0].Applications) != 2 || rows[0].Applications[0] != "Billing" {
+		t.Errorf("Expected the high risk GPL component used by both apps first, got %+v", rows[0])
+	}
+	if len(rows[1].Applications) != 1 {
+		t.Errorf("Expected duplicate findings of one app to count once, got %v", rows[1].Applications)
+	}
+
+	if filtered := Licenses(results, LicenseFilter{Copyleft: true}); len(filtered) != 1 || filtered[0].License.ID != "GPL-2.0-only" {
+		t.Errorf("Expected only the copyleft license, got %+v", filtered)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteLicensesCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteLicensesCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Expected a header and 2 rows of valid CSV, got %d, %v", len(records), err)
+	}
+	if records[1][3] != "High" || records[1][5] != "true" || records[1][7] != "Billing; Shop" {
+		t.Errorf("Unexpected first row %v", records[1])
+	}
+}
@@ -0,0 +1,98 @@
+// Package portfolio gathers findings across many applications at once, for views
+// and reports that cover the whole portfolio rather than a single application.
+package portfolio
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// DefaultWorkers is the number of applications fetched at the same time
+const DefaultWorkers = 4
+
+// FindingsSource retrieves all findings of an application, as findings.Service does
+type FindingsSource interface {
+	GetAllFindings(applicationGUID string, opts *findings.GetFindingsOptions) ([]findings.Finding, error)
+}
+
+// Fetch retrieves the findings of one application
+type Fetch func(app *applications.Application) ([]findings.Finding, error)
+
+// ByScanType returns a Fetch that requests each scan type separately with the given options.
+// Annotations are only requested for the scan types that support them.
+func ByScanType(source FindingsSource, opts findings.GetFindingsOptions, scanTypes []string) Fetch {
+	return func(app *applications.Application) ([]findings.Finding, error) {
+		var all []findings.Finding
+		for _, scanType := range scanTypes {
+			scanOpts := opts
+			scanOpts.ScanType = []string{scanType}
+			scanOpts.IncludeAnnotations = opts.IncludeAnnotations && scanType != string(findings.ScanTypeSCA)
+			list, err := source.GetAllFindings(app.GUID, &scanOpts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s findings: %w", scanType, err)
+			}
+			all = append(all, list...)
+		}
+		return all, nil
+	}
+}
+
+// AppFindings holds the findings of one application, or the error fetching them
+type AppFindings struct {
+	App      *applications.Application
+	Findings []findings.Finding
+	Err      error
+}
+
+// AppName returns the application's name, or its GUID when the profile is missing
+func (a *AppFindings) AppName() string {
+	if a.App.Profile != nil {
+		return a.App.Profile.Name
+	}
+	return a.App.GUID
+}
+
+// Collect fetches the findings of each application with up to workers requests at a time.
+// Results are returned in the order of apps. progress, if not nil, is called after each
+// application with the number done so far; it may be called from several goroutines.
+func Collect(apps []applications.Application, fetch Fetch, workers int, progress func(done, total int)) []AppFindings {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	results := make([]AppFindings, len(apps))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for range min(workers, len(apps)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				list, err := fetch(&apps[i])
+				results[i] = AppFindings{App: &apps[i], Findings: list, Err: err}
+
+				if progress != nil {
+					mu.Lock()
+					done++
+					current := done
+					mu.Unlock()
+					progress(current, len(apps))
+				}
+			}
+		}()
+	}
+
+	for i := range apps {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,56 @@
+package portfolio
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+type fakeSource struct {
+	calls atomic.Int32
+}
+
+func (f *fakeSource) GetAllFindings(applicationGUID string, opts *findings.GetFindingsOptions) ([]findings.Finding, error) {
+	f.calls.Add(1)
+	if applicationGUID == "broken" {
+		return nil, errors.New("request failed")
+	}
+	if len(opts.ScanType) != 1 || opts.IncludeAnnotations != (opts.ScanType[0] != "SCA") {
+		return nil, errors.New("expected one scan type per request, with annotations except for SCA")
+	}
+	return []findings.Finding{{IssueID: int64(len(applicationGUID)), ScanType: findings.ScanType(opts.ScanType[0])}}, nil
+}
+
+func TestCollect(t *testing.T) {
+	apps := []applications.Application{
+		{GUID: "a", Profile: &applications.ApplicationProfile{Name: "App A"}},
+		{GUID: "broken"},
+		{GUID: "ccc"},
+	}
+	source := &fakeSource{}
+	var progressCalls atomic.Int32
+
+	fetch := ByScanType(source, findings.GetFindingsOptions{IncludeAnnotations: true}, []string{"STATIC", "SCA"})
+	results := Collect(apps, fetch, 2, func(done, total int) {
+		progressCalls.Add(1)
+		if total != 3 || done < 1 || done > 3 {
+			t.Errorf("Unexpected progress %d/%d", done, total)
+		}
+	})
+
+	if len(results) != 3 || source.calls.Load() != 5 || progressCalls.Load() != 3 {
+		t.Fatalf("Expected 3 results, calls and progress updates, got %d, %d, %d", len(results), source.calls.Load(), progressCalls.Load())
+	}
+	if results[0].AppName() != "App A" || len(results[0].Findings) != 2 || results[0].Err != nil {
+		t.Errorf("Unexpected first result %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].AppName() != "broken" {
+		t.Errorf("Expected the second application to fail, got %+v", results[1])
+	}
+	if results[2].Findings[0].IssueID != 3 {
+		t.Errorf("Expected results in application order, got issue %d", results[2].Findings[0].IssueID)
+	}
+}
@@ -0,0 +1,271 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// SandboxSource lists the sandboxes of an application, as applications.Service does
+type SandboxSource interface {
+	GetSandboxes(applicationGUID string, opts *applications.GetSandboxesOptions) (*applications.PagedResourceOfSandbox, error)
+}
+
+// SandboxNames maps sandbox GUIDs to names. It is filled in by WithSandboxes as the
+// sandboxes of each application are listed, and is safe for concurrent use.
+type SandboxNames struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+// Name returns the name of a sandbox, or its GUID when it is unknown
+func (n *SandboxNames) Name(guid string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if name, ok := n.names[guid]; ok {
+		return name
+	}
+	return guid
+}
+
+func (n *SandboxNames) set(guid, name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.names == nil {
+		n.names = make(map[string]string)
+	}
+	n.names[guid] = name
+}
+
+// WithSandboxes returns a Fetch that requests the policy scan findings and then the findings of
+// every sandbox of the application, with the given options and scan types. Sandbox findings are
+// marked with their context so they can be told apart; the sandbox names are recorded in names.
+func WithSandboxes(source FindingsSource, sandboxes SandboxSource, opts findings.GetFindingsOptions, scanTypes []string, names *SandboxNames) Fetch {
+	return func(app *applications.Application) ([]findings.Finding, error) {
+		policyOpts := opts
+		policyOpts.Context = ""
+		all, err := ByScanType(source, policyOpts, scanTypes)(app)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := sandboxes.GetSandboxes(app.GUID, &applications.GetSandboxesOptions{Size: 500})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sandboxes: %w", err)
+		}
+		if result.Embedded == nil {
+			return all, nil
+		}
+
+		for _, sandbox := range result.Embedded.Sandboxes {
+			names.set(sandbox.GUID, sandbox.Name)
+			sandboxOpts := opts
+			sandboxOpts.Context = sandbox.GUID
+			list, err := ByScanType(source, sandboxOpts, scanTypes)(app)
+			if err != nil {
+				return nil, fmt.Errorf("sandbox %s: %w", sandbox.Name, err)
+			}
+			for i := range list {
+				list[i].ContextType = findings.ContextTypeSandbox
+				list[i].ContextGUID = sandbox.GUID
+			}
+			all = append(all, list...)
+		}
+		return all, nil
+	}
+}
+
+// cvePattern matches CVE identifiers such as CVE-2021-44228
+var cvePattern = regexp.MustCompile(`(?i)^CVE-\d{4}-\d+$`)
+
+// Search selects the SCA findings of a CVE, or of a component and optionally a version
+type Search struct {
+	CVE       string
+	Component string // Matched as a case-insensitive substring of the component file name
+	Version   string // Matched exactly when set
+}
+
+// ParseSearch parses a search term: a CVE identifier, a component name, or component@version
+func ParseSearch(term string) (Search, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return Search{}, fmt.Errorf("enter a CVE (e.g. CVE-2021-44228) or a component name, optionally with @version")
+	}
+	if cvePattern.MatchString(term) {
+		return Search{CVE: strings.ToUpper(term)}, nil
+	}
+	if component, version, ok := strings.Cut(term, "@"); ok && component != "" {
+		return Search{Component: strings.TrimSpace(component), Version: strings.TrimSpace(version)}, nil
+	}
+	return Search{Component: term}, nil
+}
+
+// String returns the search as it would be typed
+func (s Search) String() string {
+	if s.CVE != "" {
+		return s.CVE
+	}
+	if s.Version != "" {
+		return s.Component + "@" + s.Version
+	}
+	return s.Component
+}
+
+// Match reports whether an SCA finding is selected by the search
+func (s Search) Match(f *findings.Finding) bool {
+	if f.ScanType != findings.ScanTypeSCA {
+		return false
+	}
+	if s.CVE != "" {
+		return strings.EqualFold(f.CVE(), s.CVE)
+	}
+	if !strings.Contains(strings.ToLower(f.Component()), strings.ToLower(s.Component)) {
+		return false
+	}
+	return s.Version == "" || strings.EqualFold(f.ComponentVersion(), s.Version)
+}
+
+// Usage is a component version found in one application context by a search
+type Usage struct {
+	Application string
+	AppGUID     string
+	SandboxGUID string // Empty for the policy scan
+	Sandbox     string // Sandbox name, empty for the policy scan
+	Component   string
+	Version     string
+	CVEs        []string // Distinct matching CVEs, sorted
+	MaxSeverity int
+	Paths       []string // Component paths, outermost archive first, joined with " > "
+}
+
+// Context returns the sandbox name, or "Policy" for the policy scan
+func (u *Usage) Context() string {
+	if u.SandboxGUID == "" {
+		return "Policy"
+	}
+	return u.Sandbox
+}
+
+// FindUsages returns the component versions matching the search in each application context,
+// sorted by application, then policy scan before sandboxes, then component and version
+func FindUsages(results []AppFindings, search Search, names *SandboxNames) []Usage {
+	var usages []Usage
+	for i := range results {
+		result := &results[i]
+		if result.Err != nil {
+			continue
+		}
+
+		byKey := make(map[string]*Usage)
+		var order []string
+		for j := range result.Findings {
+			f := &result.Findings[j]
+			if !search.Match(f) {
+				continue
+			}
+			sandboxGUID := ""
+			if f.ContextType == findings.ContextTypeSandbox {
+				sandboxGUID = f.ContextGUID
+			}
+			key := sandboxGUID + "\x00" + f.Component() + "\x00" + f.ComponentVersion()
+			usage, ok := byKey[key]
+			if !ok {
+				usage = &Usage{
+					Application: result.AppName(),
+					AppGUID:     result.App.GUID,
+					SandboxGUID: sandboxGUID,
+					Component:   f.Component(),
+					Version:     f.ComponentVersion(),
+				}
+				if sandboxGUID != "" {
+					usage.Sandbox = names.Name(sandboxGUID)
+				}
+				byKey[key] = usage
+				order = append(order, key)
+			}
+			if cve := f.CVE(); cve != "" && !slices.Contains(usage.CVEs, cve) {
+				usage.CVEs = append(usage.CVEs, cve)
+			}
+			usage.MaxSeverity = max(usage.MaxSeverity, f.Severity())
+			for _, segments := range f.ComponentPaths() {
+				if path := strings.Join(segments, " > "); !slices.Contains(usage.Paths, path) {
+					usage.Paths = append(usage.Paths, path)
+				}
+			}
+		}
+
+		for _, key := range order {
+			usage := byKey[key]
+			sort.Strings(usage.CVEs)
+			sort.Strings(usage.Paths)
+			usages = append(usages, *usage)
+		}
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.Application != b.Application {
+			return strings.ToLower(a.Application) < strings.ToLower(b.Application)
+		}
+		if (a.SandboxGUID == "") != (b.SandboxGUID == "") {
+			return a.SandboxGUID == ""
+		}
+		if a.Sandbox != b.Sandbox {
+			return strings.ToLower(a.Sandbox) < strings.ToLower(b.Sandbox)
+		}
+		if a.Component != b.Component {
+			return strings.ToLower(a.Component) < strings.ToLower(b.Component)
+		}
+		return a.Version < b.Version
+	})
+	return usages
+}
+
+// UsageApplications returns the number of distinct applications in the usages
+func UsageApplications(usages []Usage) int {
+	apps := make(map[string]bool)
+	for _, usage := range usages {
+		apps[usage.AppGUID] = true
+	}
+	return len(apps)
+}
+
+// WriteUsagesCSV writes the usages as CSV, one row per component version and application context
+func WriteUsagesCSV(w io.Writer, usages []Usage) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"application", "application_guid", "context", "sandbox_guid", "component", "version", "cves", "max_severity", "paths"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for i := range usages {
+		usage := &usages[i]
+		record := []string{
+			usage.Application,
+			usage.AppGUID,
+			usage.Context(),
+			usage.SandboxGUID,
+			usage.Component,
+			usage.Version,
+			strings.Join(usage.CVEs, "; "),
+			strconv.Itoa(usage.MaxSeverity),
+			strings.Join(usage.Paths, "; "),
+		}
+		if err := cw.Write(SafeCSVRecord(record)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
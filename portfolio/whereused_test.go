@@ -0,0 +1,101 @@
+package portfolio
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+type fakeContexts struct {
+	t        *testing.T
+	findings map[string]string // Findings JSON by context GUID ("" for the policy scan)
+}
+
+func (f *fakeContexts) GetAllFindings(applicationGUID string, opts *findings.GetFindingsOptions) ([]findings.Finding, error) {
+	data, ok := f.findings[opts.Context]
+	if !ok {
+		return nil, errors.New("unknown context")
+	}
+	return scaFindings(f.t, data), nil
+}
+
+func (f *fakeContexts) GetSandboxes(applicationGUID string, opts *applications.GetSandboxesOptions) (*applications.PagedResourceOfSandbox, error) {
+	return &applications.PagedResourceOfSandbox{Embedded: &applications.EmbeddedSandbox{Sandboxes: []applications.Sandbox{
+		{GUID: "sb-1", Name: "Release"},
+	}}}, nil
+}
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		term string
+		want Search
+	}{
+		{"cve-2021-44228", Search{CVE: "CVE-2021-44228"}},
+		{" log4j-core ", Search{Component: "log4j-core"}},
+		{"log4j-core@2.14.1", Search{Component: "log4j-core", Version: "2.14.1"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSearch(tt.term)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSearch(%q) = %+v, %v; want %+v", tt.term, got, err, tt.want)
+		}
+	}
+	if _, err := ParseSearch("  "); err == nil {
+		t.Error("Expected an error for an empty search")
+	}
+}
+
+func TestFindUsages(t *testing.T) {
+	log4j := func(version, cve string, severity int) string {
+		return `{"scan_type": "SCA", "finding_details": {"component_filename": "log4j-core.jar", "version": "` + version +
+			`", "severity": ` + strconv.Itoa(severity) + `, "cve": {"name": "` + cve + `"},
+			"component_path": [{"path": "app.war#zip:WEB-INF/lib/log4j-core.jar"}]}}`
+	}
+	source := &fakeContexts{t: t, findings: map[string]string{
+		"": `[` + log4j("2.14.1", "CVE-2021-44228", 5) + `,` + log4j("2.14.1", "CVE-2021-45046", 4) + `,
+			{"scan_type": "SCA", "finding_details": {"component_filename": "commons-text.jar", "version": "1.9", "cve": {"name": "CVE-2022-42889"}}}]`,
+		"sb-1": `[` + log4j("2.17.0", "CVE-2021-44832", 3) + `]`,
+	}}
+	apps := []applications.Application{{GUID: "app-1", Profile: &applications.ApplicationProfile{Name: "Shop"}}}
+
+	var names SandboxNames
+	results := Collect(apps, WithSandboxes(source, source, findings.GetFindingsOptions{}, []string{"SCA"}, &names), 1, nil)
+	if results[0].Err != nil {
+		t.Fatalf("Unexpected error: %v", results[0].Err)
+	}
+
+	search, _ := ParseSearch("log4j")
+	usages := FindUsages(results, search, &names)
+	if len(usages) != 2 {
+		t.Fatalf("Expected the policy and sandbox versions of log4j, got %+v", usages)
+	}
+	policy, sandbox := usages[0], usages[1]
+	if policy.Context() != "Policy" || policy.Version != "2.14.1" || len(policy.CVEs) != 2 || policy.MaxSeverity != 5 {
+		t.Errorf("Unexpected policy usage %+v", policy)
+	}
+	if len(policy.Paths) != 1 || policy.Paths[0] != "app.war > log4j-core.jar" {
+		t.Errorf("Unexpected paths %q", policy.Paths)
+	}
+	if sandbox.Context() != "Release" || sandbox.Version != "2.17.0" {
+		t.Errorf("Unexpected sandbox usage %+v", sandbox)
+	}
+
+	search, _ = ParseSearch("CVE-2021-44228")
+	usages = FindUsages(results, search, &names)
+	if len(usages) != 1 || len(usages[0].CVEs) != 1 || UsageApplications(usages) != 1 {
+		t.Errorf("Expected only the policy usage with the searched CVE, got %+v", usages)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteUsagesCSV(&buf, usages); err != nil {
+		t.Fatalf("WriteUsagesCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Shop,app-1,Policy,,log4j-core.jar,2.14.1,CVE-2021-44228,5,app.war > log4j-core.jar") {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+}
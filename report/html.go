@@ -0,0 +1,86 @@
+package report
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/policies"
+)
+
+// TemplateFileName is the name of a template in the state directory that replaces the built-in
+// template, to brand the reports
+const TemplateFileName = "report.html.tmpl"
+
+// DefaultTemplate is the built-in HTML template, a starting point for custom templates
+//
+//go:embed report.html.tmpl
+var DefaultTemplate string
+
+// templateFuncs are the functions available to report templates
+var templateFuncs = template.FuncMap{
+	"severityName": policies.SeverityName,
+	"join":         strings.Join,
+	"date": func(t interface{}) string {
+		switch t := t.(type) {
+		case time.Time:
+			if !t.IsZero() {
+				return t.Format("2006-01-02")
+			}
+		case *time.Time:
+			if t != nil {
+				return t.Format("2006-01-02")
+			}
+		}
+		return "-"
+	},
+	"lower": strings.ToLower,
+	"status": func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(s), "_", " ")
+	},
+}
+
+// LoadTemplate returns the template used to render reports: the file at path when set, otherwise
+// TemplateFileName in dir when that file exists, otherwise the built-in template
+func LoadTemplate(path, dir string) (*template.Template, error) {
+	if path == "" && dir != "" {
+		candidate := filepath.Join(dir, TemplateFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read report template: %w", err)
+		}
+	}
+
+	text := DefaultTemplate
+	name := "report"
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report template: %w", err)
+		}
+		text = string(data)
+		name = filepath.Base(path)
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// Write renders the report with the template
+func Write(w io.Writer, tmpl *template.Template, r *Report) error {
+	if err := tmpl.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
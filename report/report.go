@@ -0,0 +1,395 @@
+// Package report builds a security report of an application context from its profile, findings
+// and snapshot history, and renders it as a self-contained HTML document from a template.
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/dipsylala/veracode-tui/snapshot"
+)
+
+// TrendDays is the number of days covered by the findings trend
+const TrendDays = 30
+
+// Input is what a report is built from
+type Input struct {
+	App         *applications.Application
+	SandboxName string // Empty for the policy scan
+	Findings    []findings.Finding
+	Snapshots   []*snapshot.Snapshot // History of the context, for the trend; may be empty
+	Now         time.Time
+	ToolVersion string
+}
+
+// Report is the data rendered by the report templates
+type Report struct {
+	Title       string
+	Context     string // "Policy" or the sandbox name
+	GeneratedAt time.Time
+	ToolVersion string
+	Application Profile
+	Compliance  string // Policy compliance status of the application
+
+	Severities  []SeverityRow // Very High first
+	TotalOpen   int
+	CWEs        []CWERow
+	Components  []ComponentRow
+	Mitigations []Mitigation
+	Trend       []TrendSeries // Empty without snapshot history
+	TrendStart  time.Time
+	TrendEnd    time.Time
+}
+
+// Profile is the part of the application profile shown in the report
+type Profile struct {
+	Name                string
+	Description         string
+	BusinessCriticality string
+	BusinessUnit        string
+	BusinessOwners      []string
+	Teams               []string
+	Policy              string
+	Tags                string
+	LastScan            *time.Time
+}
+
+// SeverityRow counts the findings of a severity
+type SeverityRow struct {
+	Severity  int
+	Name      string
+	Open      int // Open and not mitigated
+	Mitigated int // Open with an approved mitigation
+	Closed    int
+}
+
+// CWERow counts the open static and dynamic findings of a CWE
+type CWERow struct {
+	CWE       int
+	Name      string
+	Severity  int // Highest severity
+	Open      int
+	Mitigated int
+}
+
+// ComponentRow is an SCA component version with its open vulnerabilities and licenses
+type ComponentRow struct {
+	Name        string
+	Version     string
+	CVEs        []string
+	Severity    int // Highest severity of its vulnerabilities
+	CVSS        float64
+	Licenses    []string
+	LicenseRisk string
+}
+
+// Mitigation is a finding with a proposed or approved mitigation and its comments
+type Mitigation struct {
+	IssueID          int64
+	ScanType         string
+	Severity         int
+	Finding          string
+	Resolution       string
+	ResolutionStatus string
+	Comments         []Comment
+}
+
+// Comment is an annotation on a finding
+type Comment struct {
+	Action string
+	User   string
+	Date   *time.Time
+	Text   string
+}
+
+// TrendSeries is a line of the findings trend, with the SVG polyline points to draw it
+type TrendSeries struct {
+	Label  string
+	Class  string // CSS class of the line
+	Values []int
+	Latest int
+	Points string
+}
+
+// Build assembles the report of an application context
+func Build(in Input) *Report {
+	r := &Report{
+		Title:       appName(in.App),
+		Context:     "Policy",
+		GeneratedAt: in.Now,
+		ToolVersion: in.ToolVersion,
+		Application: newProfile(in.App),
+	}
+	if in.SandboxName != "" {
+		r.Context = in.SandboxName
+		r.Title += " / " + in.SandboxName
+	}
+	if in.App.Profile != nil && len(in.App.Profile.Policies) > 0 {
+		r.Compliance = in.App.Profile.Policies[0].PolicyComplianceStatus
+	}
+
+	r.addSeverities(in.Findings)
+	r.addCWEs(in.Findings)
+	r.addComponents(in.Findings)
+	r.addMitigations(in.Findings)
+	if len(in.Snapshots) > 0 {
+		r.addTrend(snapshot.Trend(in.Snapshots, in.Now, TrendDays))
+	}
+	return r
+}
+
+func appName(app *applications.Application) string {
+	if app.Profile != nil && app.Profile.Name != "" {
+		return app.Profile.Name
+	}
+	return app.GUID
+}
+
+func newProfile(app *applications.Application) Profile {
+	profile := Profile{Name: appName(app), LastScan: app.LastCompletedScanDate}
+	p := app.Profile
+	if p == nil {
+		return profile
+	}
+	profile.Description = p.Description
+	profile.BusinessCriticality = p.BusinessCriticality
+	profile.Tags = p.Tags
+	if p.BusinessUnit != nil {
+		profile.BusinessUnit = p.BusinessUnit.Name
+	}
+	for _, owner := range p.BusinessOwners {
+		profile.BusinessOwners = append(profile.BusinessOwners, owner.Name)
+	}
+	for _, team := range p.Teams {
+		profile.Teams = append(profile.Teams, team.TeamName)
+	}
+	if len(p.Policies) > 0 {
+		profile.Policy = p.Policies[0].Name
+	}
+	return profile
+}
+
+// isOpen reports whether a finding is open and not mitigated
+func isOpen(f *findings.Finding) bool {
+	return f.Status() != findings.StatusClosed && !f.IsMitigated()
+}
+
+func (r *Report) addSeverities(list []findings.Finding) {
+	var rows [6]SeverityRow
+	for i := range list {
+		f := &list[i]
+		row := &rows[min(max(f.Severity(), 0), len(rows)-1)]
+		switch {
+		case f.Status() == findings.StatusClosed:
+			row.Closed++
+		case f.IsMitigated():
+			row.Mitigated++
+		default:
+			row.Open++
+			r.TotalOpen++
+		}
+	}
+	for severity := len(rows) - 1; severity >= 0; severity-- {
+		rows[severity].Severity = severity
+		rows[severity].Name = policies.SeverityName(severity)
+		r.Severities = append(r.Severities, rows[severity])
+	}
+}
+
+func (r *Report) addCWEs(list []findings.Finding) {
+	byCWE := make(map[int]*CWERow)
+	for i := range list {
+		f := &list[i]
+		if f.ScanType == findings.ScanTypeSCA || f.Status() == findings.StatusClosed || f.CWEID() == 0 {
+			continue
+		}
+		row, ok := byCWE[f.CWEID()]
+		if !ok {
+			row = &CWERow{CWE: f.CWEID(), Name: f.CWEName()}
+			byCWE[f.CWEID()] = row
+		}
+		row.Severity = max(row.Severity, f.Severity())
+		if f.IsMitigated() {
+			row.Mitigated++
+		} else {
+			row.Open++
+		}
+	}
+
+	for _, row := range byCWE {
+		r.CWEs = append(r.CWEs, *row)
+	}
+	slices.SortFunc(r.CWEs, func(a, b CWERow) int {
+		if a.Severity != b.Severity {
+			return b.Severity - a.Severity
+		}
+		if a.Open != b.Open {
+			return b.Open - a.Open
+		}
+		return a.CWE - b.CWE
+	})
+}
+
+func (r *Report) addComponents(list []findings.Finding) {
+	byComponent := make(map[string]*ComponentRow)
+	risks := make(map[string]findings.LicenseRisk)
+	var order []string
+	for i := range list {
+		f := &list[i]
+		if f.ScanType != findings.ScanTypeSCA || !isOpen(f) {
+			continue
+		}
+		key := f.Component() + "@" + f.ComponentVersion()
+		row, ok := byComponent[key]
+		if !ok {
+			row = &ComponentRow{Name: f.Component(), Version: f.ComponentVersion()}
+			byComponent[key] = row
+			order = append(order, key)
+		}
+		if cve := f.CVE(); cve != "" && !slices.Contains(row.CVEs, cve) {
+			row.CVEs = append(row.CVEs, cve)
+		}
+		row.Severity = max(row.Severity, f.Severity())
+		row.CVSS = max(row.CVSS, f.CVSSBase())
+
+		for _, license := range f.Licenses() {
+			if license.ID != "" && !slices.Contains(row.Licenses, license.ID) {
+				row.Licenses = append(row.Licenses, license.ID)
+			}
+			risks[key] = max(risks[key], license.Risk())
+		}
+	}
+
+	for _, key := range order {
+		row := byComponent[key]
+		slices.Sort(row.CVEs)
+		if risk := risks[key]; risk != findings.LicenseRiskUnknown {
+			row.LicenseRisk = risk.String()
+		}
+		r.Components = append(r.Components, *row)
+	}
+	slices.SortStableFunc(r.Components, func(a, b ComponentRow) int {
+		if a.Severity != b.Severity {
+			return b.Severity - a.Severity
+		}
+		switch {
+		case a.CVSS > b.CVSS:
+			return -1
+		case a.CVSS < b.CVSS:
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+func (r *Report) addMitigations(list []findings.Finding) {
+	for i := range list {
+		f := &list[i]
+		if f.FindingStatus == nil || len(f.Annotations) == 0 {
+			continue
+		}
+		mitigation := Mitigation{
+			IssueID:          f.IssueID,
+			ScanType:         string(f.ScanType),
+			Severity:         f.Severity(),
+			Finding:          describe(f),
+			Resolution:       f.FindingStatus.Resolution,
+			ResolutionStatus: string(f.FindingStatus.ResolutionStatus),
+		}
+		for _, annotation := range f.Annotations {
+			comment := Comment{Action: annotation.Action, User: annotation.UserName, Date: annotation.Created, Text: annotation.Comment}
+			if comment.User == "" {
+				comment.User = annotation.User
+			}
+			if comment.Date == nil {
+				comment.Date = annotation.Date
+			}
+			mitigation.Comments = append(mitigation.Comments, comment)
+		}
+		r.Mitigations = append(r.Mitigations, mitigation)
+	}
+	slices.SortStableFunc(r.Mitigations, func(a, b Mitigation) int {
+		if a.Severity != b.Severity {
+			return b.Severity - a.Severity
+		}
+		switch {
+		case a.IssueID < b.IssueID:
+			return -1
+		case a.IssueID > b.IssueID:
+			return 1
+		}
+		return 0
+	})
+}
+
+// describe summarises a finding by its weakness and location, or its CVE and component
+func describe(f *findings.Finding) string {
+	var parts []string
+	switch f.ScanType {
+	case findings.ScanTypeSCA:
+		parts = append(parts, f.CVE(), strings.TrimSpace(f.Component()+" "+f.ComponentVersion()))
+	case findings.ScanTypeDynamic:
+		parts = append(parts, f.CWELabel(), f.URL())
+	default:
+		location := f.FilePath()
+		if line := f.FileLine(); line > 0 {
+			location = fmt.Sprintf("%s:%d", location, line)
+		}
+		parts = append(parts, f.CWELabel(), location)
+	}
+	return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), " - ")
+}
+
+// Trend chart dimensions in SVG user units
+const (
+	chartWidth  = 600
+	chartHeight = 120
+)
+
+func (r *Report) addTrend(points []snapshot.Point) {
+	if len(points) == 0 {
+		return
+	}
+	r.TrendStart = points[0].Day
+	r.TrendEnd = points[len(points)-1].Day
+
+	series := []struct {
+		label, class string
+		value        func(p *snapshot.Point) int
+	}{
+		{"Open", "open", func(p *snapshot.Point) int { return p.Open.Total() }},
+		{"Very High and High open", "high", func(p *snapshot.Point) int { return p.Open[5] + p.Open[4] }},
+		{"Mitigated", "mitigated", func(p *snapshot.Point) int { return p.Mitigated.Total() }},
+		{"Closed", "closed", func(p *snapshot.Point) int { return p.Closed.Total() }},
+	}
+
+	highest := 1
+	for i := range points {
+		for _, s := range series {
+			highest = max(highest, s.value(&points[i]))
+		}
+	}
+
+	for _, s := range series {
+		line := TrendSeries{Label: s.label, Class: s.class}
+		coords := make([]string, len(points))
+		for i := range points {
+			value := s.value(&points[i])
+			line.Values = append(line.Values, value)
+			x := 0.0
+			if len(points) > 1 {
+				x = float64(i) * chartWidth / float64(len(points)-1)
+			}
+			y := chartHeight - float64(value)*chartHeight/float64(highest)
+			coords[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		line.Latest = line.Values[len(line.Values)-1]
+		line.Points = strings.Join(coords, " ")
+		r.Trend = append(r.Trend, line)
+	}
+}
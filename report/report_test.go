@@ -0,0 +1,152 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/snapshot"
+)
+
+var now = time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+const testFindings = `[
+	{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN"},
+	 "finding_details": {"severity": 5, "cwe": {"id": 89, "name": "SQL Injection"}, "file_path": "src/db.java", "file_line_number": 12}},
+	{"issue_id": 2, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "resolution": "MITIGATED", "resolution_status": "APPROVED"},
+	 "finding_details": {"severity": 3, "cwe": {"id": 117, "name": "Log Injection"}},
+	 "annotations": [
+		{"action": "APPDESIGN", "comment": "Logs are <escaped>", "user_name": "dev", "created": "2025-06-01T10:00:00Z"},
+		{"action": "APPROVED", "comment": "Agreed", "user_name": "security", "created": "2025-06-02T10:00:00Z"}
+	 ]},
+	{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"}, "finding_details": {"severity": 3, "cwe": {"id": 89}}},
+	{"issue_id": 4, "scan_type": "SCA", "finding_status": {"status": "OPEN"},
+	 "finding_details": {"severity": 4, "component_filename": "log4j-core-2.14.1.jar", "version": "2.14.1",
+	  "cve": {"name": "CVE-2021-44228", "cvss3": {"score": 10.0}},
+	  "licenses": [{"license_id": "Apache-2.0", "risk_rating": "LOW"}]}},
+	{"issue_id": 5, "scan_type": "SCA", "finding_status": {"status": "OPEN"},
+	 "finding_details": {"severity": 3, "component_filename": "log4j-core-2.14.1.jar", "version": "2.14.1", "cve": {"name": "CVE-2021-45046"}}}
+]`
+
+func testInput(t *testing.T) Input {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(testFindings), &list); err != nil {
+		t.Fatalf("Invalid findings: %v", err)
+	}
+	return Input{
+		App: &applications.Application{GUID: "app-1", Profile: &applications.ApplicationProfile{
+			Name:                "Verademo",
+			BusinessCriticality: "HIGH",
+			Teams:               []applications.AppTeam{{TeamName: "Payments"}},
+			Policies:            []applications.AppPolicy{{Name: "Recommended High", PolicyComplianceStatus: "DID_NOT_PASS"}},
+		}},
+		Findings:    list,
+		Now:         now,
+		ToolVersion: "1.2.3",
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(testInput(t))
+
+	if r.Title != "Verademo" || r.Compliance != "DID_NOT_PASS" || r.Application.Policy != "Recommended High" {
+		t.Errorf("Unexpected report header %+v", r)
+	}
+	if r.TotalOpen != 3 {
+		t.Errorf("Expected 3 open findings, got %d", r.TotalOpen)
+	}
+	if medium := r.Severities[2]; medium.Severity != 3 || medium.Open != 1 || medium.Mitigated != 1 || medium.Closed != 1 {
+		t.Errorf("Unexpected medium severity counts %+v", medium)
+	}
+
+	if len(r.CWEs) != 2 || r.CWEs[0].CWE != 89 || r.CWEs[0].Open != 1 || r.CWEs[1].Mitigated != 1 {
+		t.Errorf("Unexpected CWE rows %+v", r.CWEs)
+	}
+	if len(r.Components) != 1 {
+		t.Fatalf("Expected the two log4j findings in one component, got %+v", r.Components)
+	}
+	if c := r.Components[0]; len(c.CVEs) != 2 || c.Severity != 4 || c.CVSS != 10 || c.LicenseRisk != "Low" {
+		t.Errorf("Unexpected component row %+v", c)
+	}
+	if len(r.Mitigations) != 1 || len(r.Mitigations[0].Comments) != 2 || r.Mitigations[0].Comments[1].User != "security" {
+		t.Errorf("Unexpected mitigations %+v", r.Mitigations)
+	}
+	if len(r.Trend) != 0 {
+		t.Errorf("Expected no trend without snapshots")
+	}
+}
+
+func TestBuildTrend(t *testing.T) {
+	in := testInput(t)
+	in.Snapshots = []*snapshot.Snapshot{
+		snapshot.New("app-1", "Verademo", "", "", []string{"STATIC", "SCA"}, in.Findings, now.AddDate(0, 0, -3)),
+	}
+
+	r := Build(in)
+	if len(r.Trend) != 4 {
+		t.Fatalf("Expected four trend series, got %d", len(r.Trend))
+	}
+	open := r.Trend[0]
+	if len(open.Values) != TrendDays || open.Latest != 3 {
+		t.Errorf("Unexpected open series %+v", open)
+	}
+	if !strings.HasPrefix(open.Points, "0.0,") || strings.Count(open.Points, " ") != TrendDays-1 {
+		t.Errorf("Unexpected points %q", open.Points)
+	}
+}
+
+func TestWriteDefaultTemplate(t *testing.T) {
+	tmpl, err := LoadTemplate("", t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	var out strings.Builder
+	if err := Write(&out, tmpl, Build(testInput(t))); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	html := out.String()
+	for _, want := range []string{
+		"Security Report - Verademo",
+		`class="status did_not_pass">did not pass`,
+		"CWE-89",
+		"CVE-2021-44228, CVE-2021-45046",
+		"Logs are &lt;escaped&gt;",
+		"No snapshot history",
+		"veracode-tui 1.2.3",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Report does not contain %q", want)
+		}
+	}
+}
+
+func TestLoadTemplateOverride(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, TemplateFileName)
+	if err := os.WriteFile(custom, []byte(`<h1>ACME {{.Title}}</h1>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := LoadTemplate("", dir)
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	var out strings.Builder
+	if err := Write(&out, tmpl, Build(testInput(t))); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if out.String() != "<h1>ACME Verademo</h1>" {
+		t.Errorf("Expected the template from the directory, got %q", out.String())
+	}
+
+	if _, err := LoadTemplate(filepath.Join(dir, "missing.tmpl"), ""); err == nil {
+		t.Error("Expected an error for a missing template")
+	}
+}
@@ -0,0 +1,201 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings/cvss"
+)
+
+// CycloneDXSpecVersion is the CycloneDX specification version written
+const CycloneDXSpecVersion = "1.5"
+
+type cdxDocument struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	SerialNumber    string             `json:"serialNumber"`
+	Version         int                `json:"version"`
+	Metadata        cdxMetadata        `json:"metadata"`
+	Components      []cdxComponent     `json:"components"`
+	Dependencies    []cdxDependency    `json:"dependencies"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Licenses   []cdxLicense  `json:"licenses,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxLicense struct {
+	License cdxLicenseID `json:"license"`
+}
+
+type cdxLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cdxVulnerability struct {
+	BOMRef      string      `json:"bom-ref"`
+	ID          string      `json:"id"`
+	Source      *cdxSource  `json:"source,omitempty"`
+	Ratings     []cdxRating `json:"ratings,omitempty"`
+	CWEs        []int       `json:"cwes,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Affects     []cdxAffect `json:"affects"`
+}
+
+type cdxSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type cdxRating struct {
+	Source   *cdxSource `json:"source,omitempty"`
+	Score    float64    `json:"score,omitempty"`
+	Severity string     `json:"severity"`
+	Method   string     `json:"method,omitempty"`
+	Vector   string     `json:"vector,omitempty"`
+}
+
+type cdxAffect struct {
+	Ref string `json:"ref"`
+}
+
+// spdxLicenseID matches license identifiers that can be written as SPDX IDs rather than names
+var spdxLicenseID = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// WriteCycloneDX writes the BOM as an indented CycloneDX JSON document
+func WriteCycloneDX(w io.Writer, bom *BOM) error {
+	doc := cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  CycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + bom.Serial,
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: bom.Created.Format(time.RFC3339),
+			Tools:     cdxTools{Components: []cdxComponent{{Type: "application", Name: ToolName, Version: bom.ToolVersion}}},
+			Component: cdxComponent{Type: "application", BOMRef: ApplicationRef, Name: bom.Application},
+		},
+		Components:   []cdxComponent{},
+		Dependencies: []cdxDependency{},
+	}
+
+	for _, c := range bom.Components {
+		component := cdxComponent{Type: "library", BOMRef: c.Ref, Name: c.Name, Version: c.Version}
+		if c.Archive {
+			component.Type = "file"
+		}
+		for _, license := range c.Licenses {
+			id := cdxLicenseID{Name: license.ID}
+			if spdxLicenseID.MatchString(license.ID) {
+				id = cdxLicenseID{ID: license.ID}
+			}
+			component.Licenses = append(component.Licenses, cdxLicense{License: id})
+		}
+		if c.Language != "" {
+			component.Properties = append(component.Properties, cdxProperty{Name: "veracode:language", Value: c.Language})
+		}
+		doc.Components = append(doc.Components, component)
+	}
+
+	for _, dep := range bom.Dependencies {
+		doc.Dependencies = append(doc.Dependencies, cdxDependency{Ref: dep.Ref, DependsOn: dep.DependsOn})
+	}
+
+	for _, v := range bom.Vulnerabilities {
+		vuln := cdxVulnerability{
+			BOMRef:      "vulnerability-" + v.ID,
+			ID:          v.ID,
+			Description: v.Description,
+			Ratings: []cdxRating{{
+				Source:   &cdxSource{Name: "Veracode"},
+				Severity: cdxSeverity(v.Severity),
+				Method:   "other",
+			}},
+		}
+		if strings.HasPrefix(v.ID, "CVE-") {
+			vuln.Source = &cdxSource{Name: "NVD", URL: v.Href}
+		}
+		if v.CVSSScore > 0 {
+			vuln.Ratings = append(vuln.Ratings, cdxRating{
+				Score:    v.CVSSScore,
+				Severity: strings.ToLower(cvss.Severity(v.CVSSScore, v.CVSSVersion)),
+				Method:   cvssMethod(v.CVSSVersion),
+				Vector:   v.CVSSVector,
+			})
+		}
+		if v.CWE > 0 {
+			vuln.CWEs = []int{v.CWE}
+		}
+		for _, ref := range v.Affects {
+			vuln.Affects = append(vuln.Affects, cdxAffect{Ref: ref})
+		}
+		doc.Vulnerabilities = append(doc.Vulnerabilities, vuln)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write CycloneDX: %w", err)
+	}
+	return nil
+}
+
+// cdxSeverity maps a Veracode severity (0-5) to a CycloneDX severity
+func cdxSeverity(severity int) string {
+	switch severity {
+	case 5:
+		return "critical"
+	case 4:
+		return "high"
+	case 3:
+		return "medium"
+	case 2:
+		return "low"
+	case 1:
+		return "info"
+	default:
+		return "none"
+	}
+}
+
+// cvssMethod returns the CycloneDX rating method of a CVSS version
+func cvssMethod(version cvss.Version) string {
+	switch version {
+	case cvss.Version2:
+		return "CVSSv2"
+	case cvss.Version30:
+		return "CVSSv3"
+	default:
+		return "CVSSv31"
+	}
+}
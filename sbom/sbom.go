@@ -0,0 +1,336 @@
+// Package sbom builds a software bill of materials from the SCA findings of an application
+// and writes it as CycloneDX or SPDX JSON.
+package sbom
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/findings/cvss"
+)
+
+// ToolName identifies the generator in the documents
+const ToolName = "veracode-tui"
+
+// Format is an SBOM document format
+type Format string
+
+// Supported formats
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// ParseFormat parses a format name (case-insensitive)
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case FormatCycloneDX:
+		return FormatCycloneDX, nil
+	case FormatSPDX:
+		return FormatSPDX, nil
+	}
+	return "", fmt.Errorf("unsupported SBOM format %q, use cyclonedx or spdx", name)
+}
+
+// FileExtension returns the conventional file name suffix of the format
+func (f Format) FileExtension() string {
+	if f == FormatSPDX {
+		return ".spdx.json"
+	}
+	return ".cdx.json"
+}
+
+// Write writes the BOM in the given format
+func Write(w io.Writer, bom *BOM, format Format) error {
+	if format == FormatSPDX {
+		return WriteSPDX(w, bom)
+	}
+	return WriteCycloneDX(w, bom)
+}
+
+// Options are the document details that do not come from the findings
+type Options struct {
+	ToolVersion string    // Version of veracode-tui, if known
+	Created     time.Time // Defaults to now
+}
+
+// BOM is the component inventory of an application
+type BOM struct {
+	Application     string
+	Serial          string // Random UUID identifying the document
+	Created         time.Time
+	ToolVersion     string
+	Components      []*Component     // Sorted by name and version
+	Dependencies    []Dependency     // The application's first, then by component order
+	Vulnerabilities []*Vulnerability // Sorted by ID
+}
+
+// ApplicationRef is the reference of the application itself in dependencies
+const ApplicationRef = "application"
+
+// Component is a library, or an archive that only appears in component paths
+type Component struct {
+	Ref      string // Unique in the BOM; letters, digits, '.' and '-'
+	Name     string // Component file name
+	Version  string
+	Language string
+	Licenses []findings.License
+	Archive  bool // Known only as a container in component paths, without findings of its own
+}
+
+// Dependency lists the components a component (or the application) directly depends on
+type Dependency struct {
+	Ref       string
+	DependsOn []string
+}
+
+// Vulnerability is a CVE, or an SCA issue without one, and the components it affects
+type Vulnerability struct {
+	ID          string
+	Href        string
+	Severity    int     // Veracode severity, 0-5
+	CVSSScore   float64 // Preferred CVSS base score (v3 over v2), 0 if unknown
+	CVSSVector  string
+	CVSSVersion cvss.Version
+	CWE         int
+	Description string
+	Affects     []string // Component refs, sorted
+}
+
+// Build creates the BOM of an application from its SCA findings. Components are identified by
+// file name and version; component paths become dependencies, from the application down.
+func Build(application string, list []findings.Finding, opts Options) *BOM {
+	created := opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	b := &builder{
+		bom: &BOM{
+			Application: application,
+			Serial:      newUUID(),
+			Created:     created.UTC(),
+			ToolVersion: opts.ToolVersion,
+		},
+		byKey:      make(map[string]*Component),
+		byName:     make(map[string][]*Component),
+		refs:       make(map[string]bool),
+		edges:      make(map[string]map[string]bool),
+		vulnByID:   make(map[string]*Vulnerability),
+		affectedBy: make(map[string]map[string]bool),
+	}
+	b.refs[ApplicationRef] = true
+
+	var sca []*findings.Finding
+	for i := range list {
+		if f := &list[i]; f.ScanType == findings.ScanTypeSCA && f.Component() != "" {
+			sca = append(sca, f)
+		}
+	}
+	// Sorted first so that references do not depend on the order of the findings
+	sort.SliceStable(sca, func(i, j int) bool {
+		if sca[i].Component() != sca[j].Component() {
+			return sca[i].Component() < sca[j].Component()
+		}
+		return sca[i].ComponentVersion() < sca[j].ComponentVersion()
+	})
+
+	for _, f := range sca {
+		b.addComponent(f)
+	}
+	for _, f := range sca {
+		b.addDependencies(f)
+		b.addVulnerability(f)
+	}
+	return b.finish()
+}
+
+type builder struct {
+	bom        *BOM
+	byKey      map[string]*Component   // By name and version
+	byName     map[string][]*Component // Versions of each file name
+	refs       map[string]bool
+	edges      map[string]map[string]bool
+	vulnByID   map[string]*Vulnerability
+	affectedBy map[string]map[string]bool
+}
+
+func (b *builder) addComponent(f *findings.Finding) {
+	key := f.Component() + "@" + f.ComponentVersion()
+	c, ok := b.byKey[key]
+	if !ok {
+		c = &Component{Name: f.Component(), Version: f.ComponentVersion(), Language: f.Language()}
+		c.Ref = b.newRef(c.Name, c.Version)
+		b.byKey[key] = c
+		b.byName[c.Name] = append(b.byName[c.Name], c)
+		b.bom.Components = append(b.bom.Components, c)
+	}
+	for _, license := range f.Licenses() {
+		if !containsLicense(c.Licenses, license.ID) {
+			c.Licenses = append(c.Licenses, license)
+		}
+	}
+}
+
+// addDependencies links the components along each path of the finding, starting at the application
+func (b *builder) addDependencies(f *findings.Finding) {
+	component := b.byKey[f.Component()+"@"+f.ComponentVersion()]
+	paths := f.ComponentPaths()
+	if len(paths) == 0 {
+		b.addEdge(ApplicationRef, component.Ref)
+		return
+	}
+	for _, segments := range paths {
+		parent := ApplicationRef
+		for i, name := range segments {
+			ref := component.Ref
+			if i < len(segments)-1 {
+				ref = b.containerRef(name)
+			}
+			if ref != parent {
+				b.addEdge(parent, ref)
+			}
+			parent = ref
+		}
+	}
+}
+
+// containerRef returns the reference of an archive in a component path: the component with that
+// file name when there is exactly one version of it, otherwise an archive component
+func (b *builder) containerRef(name string) string {
+	versions := b.byName[name]
+	if len(versions) == 1 {
+		return versions[0].Ref
+	}
+	key := name + "@"
+	if c, ok := b.byKey[key]; ok {
+		return c.Ref
+	}
+	c := &Component{Name: name, Archive: true}
+	c.Ref = b.newRef(name, "")
+	b.byKey[key] = c
+	b.bom.Components = append(b.bom.Components, c)
+	return c.Ref
+}
+
+func (b *builder) addEdge(from, to string) {
+	if b.edges[from] == nil {
+		b.edges[from] = make(map[string]bool)
+	}
+	b.edges[from][to] = true
+}
+
+func (b *builder) addVulnerability(f *findings.Finding) {
+	id := f.CVE()
+	if id == "" {
+		id = "VERACODE-SCA-" + strconv.FormatInt(f.IssueID, 10)
+	}
+	v, ok := b.vulnByID[id]
+	if !ok {
+		v = &Vulnerability{ID: id, Href: f.CVEHref(), CWE: scaCWE(f), Description: f.Description}
+		if score, ok := f.CVSS(); ok {
+			v.CVSSScore, v.CVSSVector, v.CVSSVersion = score.Base, score.Vector, score.Version
+		}
+		b.vulnByID[id] = v
+		b.affectedBy[id] = make(map[string]bool)
+		b.bom.Vulnerabilities = append(b.bom.Vulnerabilities, v)
+	}
+	v.Severity = max(v.Severity, f.Severity())
+	b.affectedBy[id][b.byKey[f.Component()+"@"+f.ComponentVersion()].Ref] = true
+}
+
+func (b *builder) finish() *BOM {
+	bom := b.bom
+	sort.SliceStable(bom.Components, func(i, j int) bool {
+		if bom.Components[i].Name != bom.Components[j].Name {
+			return bom.Components[i].Name < bom.Components[j].Name
+		}
+		return bom.Components[i].Version < bom.Components[j].Version
+	})
+
+	refs := []string{ApplicationRef}
+	for _, c := range bom.Components {
+		refs = append(refs, c.Ref)
+	}
+	for _, ref := range refs {
+		bom.Dependencies = append(bom.Dependencies, Dependency{Ref: ref, DependsOn: sortedKeys(b.edges[ref])})
+	}
+
+	sort.Slice(bom.Vulnerabilities, func(i, j int) bool {
+		return bom.Vulnerabilities[i].ID < bom.Vulnerabilities[j].ID
+	})
+	for _, v := range bom.Vulnerabilities {
+		v.Affects = sortedKeys(b.affectedBy[v.ID])
+	}
+	return bom
+}
+
+// refUnsafe matches the characters not allowed in references (SPDX identifiers are the strictest)
+var refUnsafe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// newRef returns a unique reference for a component
+func (b *builder) newRef(name, version string) string {
+	base := strings.Trim(refUnsafe.ReplaceAllString(name, "-"), "-")
+	if version != "" {
+		base += "-" + strings.Trim(refUnsafe.ReplaceAllString(version, "-"), "-")
+	}
+	if base == "" {
+		base = "component"
+	}
+	ref := base
+	for n := 2; b.refs[ref]; n++ {
+		ref = fmt.Sprintf("%s-%d", base, n)
+	}
+	b.refs[ref] = true
+	return ref
+}
+
+// scaCWE returns the CWE number of an SCA finding, whose CWE ID is given as "CWE-502"
+func scaCWE(f *findings.Finding) int {
+	if id := f.CWEID(); id > 0 {
+		return id
+	}
+	cwe, ok := f.Details()["cwe"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	label, _ := cwe["id"].(string)
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(label), "CWE-"))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func containsLicense(licenses []findings.License, id string) bool {
+	for _, license := range licenses {
+		if license.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
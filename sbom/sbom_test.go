@@ -0,0 +1,179 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+const testFindings = `[
+	{"issue_id": 1, "scan_type": "SCA", "description": "Remote code execution", "finding_details": {
+		"component_filename": "log4j-core-2.14.1.jar", "version": "2.14.1", "language": "JAVA", "severity": 5,
+		"cve": {"name": "CVE-2021-44228", "href": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228",
+			"cvss3": {"score": 10.0, "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}},
+		"cwe": {"id": "CWE-502", "name": "Deserialization of Untrusted Data"},
+		"licenses": [{"license_id": "Apache-2.0", "risk_rating": "1"}],
+		"component_path": [{"path": "shop.war#zip:WEB-INF/lib/spring-boot.jar#zip:BOOT-INF/lib/log4j-core-2.14.1.jar"}]}},
+	{"issue_id": 2, "scan_type": "SCA", "finding_details": {
+		"component_filename": "log4j-core-2.14.1.jar", "version": "2.14.1", "severity": 4,
+		"cve": {"name": "CVE-2021-45046"},
+		"component_path": [{"path": "shop.war#zip:WEB-INF/lib/spring-boot.jar#zip:BOOT-INF/lib/log4j-core-2.14.1.jar"}]}},
+	{"issue_id": 3, "scan_type": "SCA", "finding_details": {
+		"component_filename": "spring-boot.jar", "version": "2.5.0", "severity": 3,
+		"cve": {"name": "CVE-2022-22965"},
+		"licenses": [{"license_id": "Apache-2.0"}, {"license_id": "Custom License"}],
+		"component_path": [{"path": "shop.war#zip:WEB-INF/lib/spring-boot.jar"}]}},
+	{"issue_id": 4, "scan_type": "STATIC", "finding_details": {"severity": 5}}
+]`
+
+func buildTestBOM(t *testing.T) *BOM {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(testFindings), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	return Build("Shop", list, Options{ToolVersion: "1.2.3", Created: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)})
+}
+
+func TestBuild(t *testing.T) {
+	bom := buildTestBOM(t)
+
+	var names []string
+	for _, c := range bom.Components {
+		names = append(names, c.Ref)
+	}
+	if got := strings.Join(names, ","); got != "log4j-core-2.14.1.jar-2.14.1,shop.war,spring-boot.jar-2.5.0" {
+		t.Fatalf("Unexpected components %s", got)
+	}
+	if c := bom.Components[1]; !c.Archive || c.Version != "" {
+		t.Errorf("Expected shop.war to be an archive from the component path, got %+v", c)
+	}
+	if c := bom.Components[0]; c.Language != "JAVA" || len(c.Licenses) != 1 {
+		t.Errorf("Unexpected log4j component %+v", c)
+	}
+
+	dependsOn := make(map[string]string)
+	for _, dep := range bom.Dependencies {
+		dependsOn[dep.Ref] = strings.Join(dep.DependsOn, ",")
+	}
+	if dependsOn[ApplicationRef] != "shop.war" || dependsOn["shop.war"] != "spring-boot.jar-2.5.0" ||
+		dependsOn["spring-boot.jar-2.5.0"] != "log4j-core-2.14.1.jar-2.14.1" {
+		t.Errorf("Unexpected dependencies %v", dependsOn)
+	}
+
+	if len(bom.Vulnerabilities) != 3 {
+		t.Fatalf("Expected 3 vulnerabilities, got %d", len(bom.Vulnerabilities))
+	}
+	v := bom.Vulnerabilities[0]
+	if v.ID != "CVE-2021-44228" || v.CVSSScore != 10 || v.CWE != 502 || len(v.Affects) != 1 || v.Affects[0] != "log4j-core-2.14.1.jar-2.14.1" {
+		t.Errorf("Unexpected vulnerability %+v", v)
+	}
+}
+
+func TestWriteCycloneDX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCycloneDX(&buf, buildTestBOM(t)); err != nil {
+		t.Fatalf("WriteCycloneDX failed: %v", err)
+	}
+
+	var doc struct {
+		BOMFormat    string `json:"bomFormat"`
+		SerialNumber string `json:"serialNumber"`
+		Metadata     struct {
+			Timestamp string `json:"timestamp"`
+			Component struct {
+				Name string `json:"name"`
+			} `json:"component"`
+		} `json:"metadata"`
+		Components []struct {
+			Type     string `json:"type"`
+			Licenses []struct {
+				License struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"license"`
+			} `json:"licenses"`
+		} `json:"components"`
+		Vulnerabilities []struct {
+			ID      string `json:"id"`
+			Ratings []struct {
+				Severity string  `json:"severity"`
+				Score    float64 `json:"score"`
+				Method   string  `json:"method"`
+			} `json:"ratings"`
+			Affects []struct {
+				Ref string `json:"ref"`
+			} `json:"affects"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if doc.BOMFormat != "CycloneDX" || !strings.HasPrefix(doc.SerialNumber, "urn:uuid:") || doc.Metadata.Component.Name != "Shop" ||
+		doc.Metadata.Timestamp != "2025-06-01T12:00:00Z" {
+		t.Errorf("Unexpected document header: %s", buf.String()[:300])
+	}
+	if doc.Components[1].Type != "file" || doc.Components[2].Licenses[1].License.Name != "Custom License" {
+		t.Errorf("Unexpected components %+v", doc.Components)
+	}
+	v := doc.Vulnerabilities[0]
+	if len(v.Ratings) != 2 || v.Ratings[0].Severity != "critical" || v.Ratings[1].Score != 10 || v.Ratings[1].Method != "CVSSv31" {
+		t.Errorf("Unexpected ratings %+v", v.Ratings)
+	}
+}
+
+func TestWriteSPDX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSPDX(&buf, buildTestBOM(t)); err != nil {
+		t.Fatalf("WriteSPDX failed: %v", err)
+	}
+
+	var doc struct {
+		SPDXVersion  string `json:"spdxVersion"`
+		CreationInfo struct {
+			Creators []string `json:"creators"`
+		} `json:"creationInfo"`
+		Packages []struct {
+			SPDXID          string `json:"SPDXID"`
+			LicenseDeclared string `json:"licenseDeclared"`
+			ExternalRefs    []struct {
+				Locator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+		Relationships []struct {
+			Element string `json:"spdxElementId"`
+			Type    string `json:"relationshipType"`
+			Related string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+		ExtractedLicenses []struct {
+			LicenseID string `json:"licenseId"`
+		} `json:"hasExtractedLicensingInfos"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if doc.SPDXVersion != "SPDX-2.3" || doc.CreationInfo.Creators[0] != "Tool: veracode-tui-1.2.3" {
+		t.Errorf("Unexpected document header")
+	}
+	if len(doc.Packages) != 4 || doc.Packages[0].SPDXID != "SPDXRef-application" {
+		t.Fatalf("Expected the application and 3 component packages, got %+v", doc.Packages)
+	}
+	if log4j := doc.Packages[1]; log4j.LicenseDeclared != "Apache-2.0" || len(log4j.ExternalRefs) != 2 {
+		t.Errorf("Unexpected log4j package %+v", log4j)
+	}
+	if spring := doc.Packages[3]; spring.LicenseDeclared != "(Apache-2.0 OR LicenseRef-Custom-License)" {
+		t.Errorf("Unexpected spring license %q", spring.LicenseDeclared)
+	}
+	if len(doc.ExtractedLicenses) != 1 || doc.ExtractedLicenses[0].LicenseID != "LicenseRef-Custom-License" {
+		t.Errorf("Unexpected extracted licenses %+v", doc.ExtractedLicenses)
+	}
+	if r := doc.Relationships[0]; r.Type != "DESCRIBES" || len(doc.Relationships) != 4 {
+		t.Errorf("Unexpected relationships %+v", doc.Relationships)
+	}
+}
@@ -0,0 +1,190 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// SPDXVersion is the SPDX specification version written
+const SPDXVersion = "SPDX-2.3"
+
+// spdxNamespaceBase prefixes the unique document namespace
+const spdxNamespaceBase = "https://spdx.org/spdxdocs/veracode-tui/"
+
+const spdxNoAssertion = "NOASSERTION"
+
+type spdxDocument struct {
+	SPDXVersion       string                 `json:"spdxVersion"`
+	DataLicense       string                 `json:"dataLicense"`
+	SPDXID            string                 `json:"SPDXID"`
+	Name              string                 `json:"name"`
+	DocumentNamespace string                 `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo       `json:"creationInfo"`
+	Packages          []spdxPackage          `json:"packages"`
+	Relationships     []spdxRelationship     `json:"relationships"`
+	ExtractedLicenses []spdxExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Comment          string            `json:"comment,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+type spdxExtractedLicense struct {
+	LicenseID     string `json:"licenseId"`
+	Name          string `json:"name"`
+	ExtractedText string `json:"extractedText"`
+}
+
+// WriteSPDX writes the BOM as an indented SPDX JSON document. SPDX 2.3 has no vulnerability
+// section, so each vulnerability is attached to the packages it affects as a security
+// advisory reference.
+func WriteSPDX(w io.Writer, bom *BOM) error {
+	creators := []string{"Tool: " + ToolName}
+	if bom.ToolVersion != "" {
+		creators[0] += "-" + bom.ToolVersion
+	}
+	doc := spdxDocument{
+		SPDXVersion:       SPDXVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              bom.Application,
+		DocumentNamespace: spdxNamespaceBase + url.PathEscape(bom.Application) + "-" + bom.Serial,
+		CreationInfo: spdxCreationInfo{
+			Created:  bom.Created.Format(time.RFC3339),
+			Creators: creators,
+		},
+		Relationships: []spdxRelationship{{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", Related: spdxID(ApplicationRef)}},
+	}
+
+	doc.Packages = append(doc.Packages, spdxPackage{
+		SPDXID:           spdxID(ApplicationRef),
+		Name:             bom.Application,
+		PrimaryPurpose:   "APPLICATION",
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+	})
+
+	advisories := make(map[string][]spdxExternalRef)
+	for _, v := range bom.Vulnerabilities {
+		locator := v.Href
+		if locator == "" && strings.HasPrefix(v.ID, "CVE-") {
+			locator = "https://nvd.nist.gov/vuln/detail/" + v.ID
+		}
+		if locator == "" {
+			continue
+		}
+		comment := fmt.Sprintf("%s, Veracode severity %d", v.ID, v.Severity)
+		if v.CVSSScore > 0 {
+			comment += fmt.Sprintf(", CVSS %.1f", v.CVSSScore)
+		}
+		for _, ref := range v.Affects {
+			advisories[ref] = append(advisories[ref], spdxExternalRef{Category: "SECURITY", Type: "advisory", Locator: locator, Comment: comment})
+		}
+	}
+
+	extracted := make(map[string]string)
+	for _, c := range bom.Components {
+		pkg := spdxPackage{
+			SPDXID:           spdxID(c.Ref),
+			Name:             c.Name,
+			VersionInfo:      c.Version,
+			PrimaryPurpose:   "LIBRARY",
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxLicenseExpression(c.Licenses, extracted),
+			CopyrightText:    spdxNoAssertion,
+			ExternalRefs:     advisories[c.Ref],
+		}
+		if c.Archive {
+			pkg.PrimaryPurpose = "ARCHIVE"
+		}
+		if c.Language != "" {
+			pkg.Comment = "Language: " + c.Language
+		}
+		doc.Packages = append(doc.Packages, pkg)
+	}
+
+	for _, dep := range bom.Dependencies {
+		for _, ref := range dep.DependsOn {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{Element: spdxID(dep.Ref), Type: "DEPENDS_ON", Related: spdxID(ref)})
+		}
+	}
+
+	for id, name := range extracted {
+		doc.ExtractedLicenses = append(doc.ExtractedLicenses, spdxExtractedLicense{LicenseID: id, Name: name, ExtractedText: name})
+	}
+	sort.Slice(doc.ExtractedLicenses, func(i, j int) bool {
+		return doc.ExtractedLicenses[i].LicenseID < doc.ExtractedLicenses[j].LicenseID
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write SPDX: %w", err)
+	}
+	return nil
+}
+
+// spdxID returns the SPDX element identifier of a BOM reference
+func spdxID(ref string) string {
+	return "SPDXRef-" + ref
+}
+
+// spdxLicenseExpression joins the licenses of a component with OR, as a component reported
+// with several licenses is offered under either. Identifiers that are not valid SPDX IDs become
+// LicenseRef- identifiers, recorded in extracted with their names.
+func spdxLicenseExpression(licenses []findings.License, extracted map[string]string) string {
+	if len(licenses) == 0 {
+		return spdxNoAssertion
+	}
+	ids := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		id := license.ID
+		if !spdxLicenseID.MatchString(id) {
+			id = "LicenseRef-" + strings.Trim(refUnsafe.ReplaceAllString(id, "-"), "-")
+			extracted[id] = license.ID
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 1 {
+		return ids[0]
+	}
+	return "(" + strings.Join(ids, " OR ") + ")"
+}
@@ -0,0 +1,31 @@
+package analytics
+
+// ReportType is the kind of data a report contains
+type ReportType string
+
+// Report types
+const (
+	ReportFindings     ReportType = "FINDINGS"
+	ReportScans        ReportType = "SCANS"
+	ReportDeletedScans ReportType = "DELETEDSCANS"
+)
+
+// ReportStatus is the generation status of a report
+type ReportStatus string
+
+// Report statuses
+const (
+	StatusSubmitted  ReportStatus = "SUBMITTED"
+	StatusProcessing ReportStatus = "PROCESSING"
+	StatusCompleted  ReportStatus = "COMPLETED"
+	StatusFailed     ReportStatus = "FAILED"
+)
+
+// Values of ReportRequest.PolicySandbox
+const (
+	ContextPolicy  = "Policy"
+	ContextSandbox = "Sandbox"
+)
+
+// DateLayout is the layout of the date ranges of a report request
+const DateLayout = "2006-01-02"
@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// Finding converts a report row to a finding, with the details the findings API would report for
+// its scan type, so that report rows can be filtered, exported and recorded like findings
+func (r *FindingRow) Finding() findings.Finding {
+	details := map[string]interface{}{"severity": float64(r.Severity)}
+	if r.CWEID != 0 {
+		details["cwe"] = map[string]interface{}{"id": float64(r.CWEID), "name": r.CWEName}
+	}
+	set := func(key, value string) {
+		if value != "" {
+			details[key] = value
+		}
+	}
+	set("file_path", r.FilePath)
+	set("file_name", r.FileName)
+	set("module", r.Module)
+	set("url", r.URL)
+	set("component_filename", r.ComponentName)
+	set("version", r.ComponentVersion)
+	if r.LineNumber != 0 {
+		details["file_line_number"] = float64(r.LineNumber)
+	}
+	if r.CVE != "" {
+		details["cve"] = map[string]interface{}{"name": r.CVE}
+	}
+
+	status := &findings.FindingStatus{
+		FirstFoundDate:   ParseDate(r.FirstFoundDate),
+		LastSeenDate:     ParseDate(r.LastUpdatedDate),
+		Status:           findings.StatusOpen,
+		Resolution:       strings.ToUpper(strings.ReplaceAll(r.Resolution, " ", "_")),
+		ResolutionStatus: findings.ResolutionStatus(strings.ToUpper(strings.ReplaceAll(r.ResolutionStatus, " ", "_"))),
+	}
+	switch strings.ToUpper(r.Status) {
+	case "CLOSED", "FIXED":
+		status.Status = findings.StatusClosed
+	case "REOPENED", "REOPEN":
+		status.Status = findings.StatusReopened
+	}
+
+	return findings.Finding{
+		IssueID:        r.IssueID,
+		ScanType:       scanType(r.ScanType),
+		Description:    r.Description,
+		ViolatesPolicy: r.ViolatesPolicy,
+		FindingStatus:  status,
+		FindingDetails: details,
+	}
+}
+
+// scanType normalises a report scan type such as "Static Analysis" to the findings API's
+func scanType(value string) findings.ScanType {
+	upper := strings.ToUpper(value)
+	switch {
+	case strings.HasPrefix(upper, "STATIC"):
+		return findings.ScanTypeStatic
+	case strings.HasPrefix(upper, "DYNAMIC"):
+		return findings.ScanTypeDynamic
+	case strings.HasPrefix(upper, "MANUAL"):
+		return findings.ScanTypeManual
+	case upper == "SCA", strings.HasPrefix(upper, "SOFTWARE COMPOSITION"):
+		return findings.ScanTypeSCA
+	}
+	return findings.ScanType(upper)
+}
@@ -0,0 +1,17 @@
+// Package analytics provides a client for the Veracode Reporting API.
+//
+// The Reporting API returns portfolio-wide data in one asynchronous report instead of one request
+// per application: a report of findings, scans or deleted scans is requested for a date range,
+// generated in the background, then read page by page. A Job runs the whole request, poll and
+// collect flow and reports its progress.
+//
+// Example usage:
+//
+//	service := analytics.NewService(client)
+//	job := service.NewJob(analytics.NewReportRequest(analytics.ReportFindings, from, to))
+//	job.OnProgress = func(p analytics.Progress) { fmt.Println(p) }
+//	report, err := job.Run()
+//	for _, row := range report.Findings {
+//		fmt.Println(row.AppName, row.IssueID)
+//	}
+package analytics
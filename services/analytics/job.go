@@ -0,0 +1,146 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+// Job defaults
+const (
+	DefaultPollInterval = 15 * time.Second
+	DefaultJobTimeout   = 30 * time.Minute
+	DefaultPageSize     = 1000
+)
+
+// Stage is the step a job is at
+type Stage string
+
+// Job stages, in order
+const (
+	StageRequested  Stage = "requested"  // The report was requested
+	StageWaiting    Stage = "waiting"    // The report is being generated
+	StageCollecting Stage = "collecting" // A page of the report was read
+	StageDone       Stage = "done"
+)
+
+// Progress describes how far a job has got
+type Progress struct {
+	Stage      Stage
+	ReportID   string
+	Status     ReportStatus // Latest report status
+	Page       int          // Pages read so far
+	TotalPages int
+	Rows       int // Rows read so far
+}
+
+// String describes the progress for display, e.g. "Report 1a2b: read page 2 of 5 (2000 rows)"
+func (p Progress) String() string {
+	switch p.Stage {
+	case StageRequested:
+		return fmt.Sprintf("Requested report %s", p.ReportID)
+	case StageWaiting:
+		return fmt.Sprintf("Report %s is %s", p.ReportID, p.Status)
+	case StageCollecting:
+		return fmt.Sprintf("Report %s: read page %d of %d (%d rows)", p.ReportID, p.Page, p.TotalPages, p.Rows)
+	default:
+		return fmt.Sprintf("Report %s complete (%d rows)", p.ReportID, p.Rows)
+	}
+}
+
+// Job requests a report, polls until it has been generated, then reads all of its pages
+type Job struct {
+	Request      ReportRequest
+	PollInterval time.Duration
+	Timeout      time.Duration // How long to wait for the report to be generated
+	PageSize     int
+	OnProgress   func(Progress) // Called after each step; may be nil
+
+	service *Service
+	sleep   func(time.Duration)
+	now     func() time.Time
+}
+
+// NewJob creates a job for the report request with the default poll interval, timeout and page size
+func (s *Service) NewJob(request ReportRequest) *Job {
+	return &Job{
+		Request:      request,
+		PollInterval: DefaultPollInterval,
+		Timeout:      DefaultJobTimeout,
+		PageSize:     DefaultPageSize,
+		service:      s,
+		sleep:        time.Sleep,
+		now:          time.Now,
+	}
+}
+
+// Run requests the report and returns its rows once every page has been read
+func (j *Job) Run() (*ReportContent, error) {
+	reportID, err := j.service.RequestReport(j.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %s report: %w", j.Request.ReportType, err)
+	}
+	progress := Progress{Stage: StageRequested, ReportID: reportID, Status: StatusSubmitted}
+	j.report(progress)
+
+	first, err := j.waitForCompletion(&progress)
+	if err != nil {
+		return nil, err
+	}
+
+	content := first.Embedded
+	totalPages := 1
+	if first.Page != nil && first.Page.TotalPages > 1 {
+		totalPages = int(first.Page.TotalPages)
+	}
+	progress.Stage = StageCollecting
+	progress.Page, progress.TotalPages, progress.Rows = 1, totalPages, content.Rows()
+	j.report(progress)
+
+	for page := 1; page < totalPages; page++ {
+		next, err := j.service.GetReport(reportID, page, j.PageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d of report %s: %w", page+1, reportID, err)
+		}
+		content.Findings = append(content.Findings, next.Embedded.Findings...)
+		content.Scans = append(content.Scans, next.Embedded.Scans...)
+		content.DeletedScans = append(content.DeletedScans, next.Embedded.DeletedScans...)
+		progress.Page, progress.Rows = page+1, content.Rows()
+		j.report(progress)
+	}
+
+	progress.Stage = StageDone
+	j.report(progress)
+	return &content, nil
+}
+
+// waitForCompletion polls the report until it is completed and returns its first page
+func (j *Job) waitForCompletion(progress *Progress) (*ReportPage, error) {
+	deadline := j.now().Add(j.Timeout)
+	for {
+		page, err := j.service.GetReport(progress.ReportID, 0, j.PageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report %s: %w", progress.ReportID, err)
+		}
+
+		switch page.Embedded.Status {
+		case StatusCompleted:
+			progress.Status = StatusCompleted
+			return page, nil
+		case StatusFailed:
+			return nil, fmt.Errorf("report %s failed to generate", progress.ReportID)
+		}
+
+		progress.Stage, progress.Status = StageWaiting, page.Embedded.Status
+		j.report(*progress)
+		if !j.now().Before(deadline) {
+			return nil, fmt.Errorf("report %s was not generated within %s; it is still %s", progress.ReportID, j.Timeout, page.Embedded.Status)
+		}
+		j.sleep(j.PollInterval)
+	}
+}
+
+func (j *Job) report(progress Progress) {
+	if j.OnProgress != nil {
+		j.OnProgress(progress)
+	}
+}
@@ -0,0 +1,185 @@
+package analytics
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportRequest describes the report to generate. Each report type filters on its own date range:
+// findings by last update, scans by start date and deleted scans by deletion date.
+type ReportRequest struct {
+	ReportType           ReportType `json:"report_type"`
+	LastUpdatedStartDate string     `json:"last_updated_start_date,omitempty"` // FINDINGS
+	LastUpdatedEndDate   string     `json:"last_updated_end_date,omitempty"`
+	StartDate            string     `json:"start_date,omitempty"` // SCANS
+	EndDate              string     `json:"end_date,omitempty"`
+	DeletionStartDate    string     `json:"deletion_start_date,omitempty"` // DELETEDSCANS
+	DeletionEndDate      string     `json:"deletion_end_date,omitempty"`
+	ScanType             []string   `json:"scan_type,omitempty"`
+	PolicySandbox        string     `json:"policy_sandbox,omitempty"` // ContextPolicy or ContextSandbox
+	ApplicationID        int        `json:"application_id,omitempty"`
+	FindingStatus        string     `json:"finding_status,omitempty"` // open or closed
+}
+
+// NewReportRequest creates a request for a report of the given type covering from to to. A zero
+// to leaves the end of the range open.
+func NewReportRequest(reportType ReportType, from, to time.Time) ReportRequest {
+	start := from.Format(DateLayout)
+	end := ""
+	if !to.IsZero() {
+		end = to.Format(DateLayout)
+	}
+
+	request := ReportRequest{ReportType: reportType}
+	switch reportType {
+	case ReportScans:
+		request.StartDate, request.EndDate = start, end
+	case ReportDeletedScans:
+		request.DeletionStartDate, request.DeletionEndDate = start, end
+	default:
+		request.LastUpdatedStartDate, request.LastUpdatedEndDate = start, end
+	}
+	return request
+}
+
+// reportCreated is the response to a report request
+type reportCreated struct {
+	Embedded struct {
+		ID string `json:"id"`
+	} `json:"_embedded"`
+}
+
+// ReportPage is one page of a report, with its generation status
+type ReportPage struct {
+	Embedded ReportContent `json:"_embedded"`
+	Page     *PageMetadata `json:"page,omitempty"`
+}
+
+// PageMetadata contains pagination information
+type PageMetadata struct {
+	Number        int64 `json:"number,omitempty"`
+	Size          int64 `json:"size,omitempty"`
+	TotalElements int64 `json:"total_elements,omitempty"`
+	TotalPages    int64 `json:"total_pages,omitempty"`
+}
+
+// ReportContent is a report's status and rows. A completed Job returns the rows of all pages.
+type ReportContent struct {
+	ID                  string           `json:"id,omitempty"`
+	ReportType          ReportType       `json:"report_type,omitempty"`
+	Status              ReportStatus     `json:"status,omitempty"`
+	RequestedByUser     string           `json:"requested_by_user,omitempty"`
+	DateReportRequested string           `json:"date_report_requested,omitempty"`
+	DateReportCompleted string           `json:"date_report_completed,omitempty"`
+	Findings            []FindingRow     `json:"findings,omitempty"`
+	Scans               []ScanRow        `json:"scans,omitempty"`
+	DeletedScans        []DeletedScanRow `json:"deletedscans,omitempty"`
+}
+
+// Rows returns the number of rows of the report's type
+func (c *ReportContent) Rows() int {
+	return len(c.Findings) + len(c.Scans) + len(c.DeletedScans)
+}
+
+// FindingRow is a finding in a FINDINGS report
+type FindingRow struct {
+	AppID            int64    `json:"app_id,omitempty"`
+	AppName          string   `json:"app_name,omitempty"`
+	SandboxID        int64    `json:"sandbox_id,omitempty"`
+	SandboxName      string   `json:"sandbox_name,omitempty"`
+	IssueID          int64    `json:"finding_id,omitempty"`
+	ScanType         string   `json:"scan_type,omitempty"`
+	Severity         Severity `json:"severity"`
+	CWEID            int      `json:"cwe_id,omitempty"`
+	CWEName          string   `json:"cwe_name,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	Resolution       string   `json:"resolution,omitempty"`
+	ResolutionStatus string   `json:"resolution_status,omitempty"`
+	ViolatesPolicy   bool     `json:"violates_policy,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	FileName         string   `json:"file_name,omitempty"`
+	FilePath         string   `json:"file_path,omitempty"`
+	LineNumber       int      `json:"line_number,omitempty"`
+	Module           string   `json:"module,omitempty"`
+	URL              string   `json:"url,omitempty"`
+	ComponentName    string   `json:"component_filename,omitempty"`
+	ComponentVersion string   `json:"component_version,omitempty"`
+	CVE              string   `json:"cve_id,omitempty"`
+	FirstFoundDate   string   `json:"first_found_date,omitempty"`
+	LastUpdatedDate  string   `json:"last_updated_date,omitempty"`
+}
+
+// ScanRow is a scan in a SCANS report
+type ScanRow struct {
+	AppID                  int64  `json:"app_id,omitempty"`
+	AppName                string `json:"app_name,omitempty"`
+	SandboxID              int64  `json:"sandbox_id,omitempty"`
+	SandboxName            string `json:"sandbox_name,omitempty"`
+	AnalysisID             int64  `json:"analysis_id,omitempty"`
+	BuildID                int64  `json:"build_id,omitempty"`
+	ScanName               string `json:"scan_name,omitempty"`
+	ScanType               string `json:"scan_type,omitempty"`
+	SubmittedBy            string `json:"submitted_by,omitempty"`
+	SubmittedDate          string `json:"submitted_date,omitempty"`
+	PublishedDate          string `json:"published_date,omitempty"`
+	PolicyName             string `json:"policy_name,omitempty"`
+	PolicyComplianceStatus string `json:"policy_compliance_status,omitempty"`
+}
+
+// DeletedScanRow is a deleted scan in a DELETEDSCANS report, for audits
+type DeletedScanRow struct {
+	AppID       int64  `json:"app_id,omitempty"`
+	AppName     string `json:"app_name,omitempty"`
+	SandboxName string `json:"sandbox_name,omitempty"`
+	AnalysisID  int64  `json:"analysis_id,omitempty"`
+	ScanName    string `json:"scan_name,omitempty"`
+	ScanType    string `json:"scan_type,omitempty"`
+	DeletedBy   string `json:"deleted_by,omitempty"`
+	DeletedDate string `json:"deleted_date,omitempty"`
+}
+
+// severityLevels maps severity names to levels
+var severityLevels = map[string]int{
+	"INFORMATIONAL": 0, "VERY LOW": 1, "LOW": 2, "MEDIUM": 3, "HIGH": 4, "VERY HIGH": 5,
+}
+
+// Severity is a finding severity (0-5), which reports give either as a number or as a name
+// such as "Very High"
+type Severity int
+
+// UnmarshalJSON reads a severity number or name; unknown names are informational
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		*s = Severity(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	name = strings.ToUpper(strings.TrimSpace(strings.ReplaceAll(name, "_", " ")))
+	if number, err := strconv.Atoi(name); err == nil {
+		*s = Severity(number)
+		return nil
+	}
+	*s = Severity(severityLevels[name])
+	return nil
+}
+
+// dateLayouts are the date formats found in report rows
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05.0", "2006-01-02 15:04:05", DateLayout}
+
+// ParseDate parses a date of a report row, returning nil when it is empty or not recognised.
+// Dates without a time zone are UTC.
+func ParseDate(value string) *time.Time {
+	value = strings.TrimSuffix(strings.TrimSpace(value), " UTC")
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	reportBasePath = "/appsec/v1/analytics/report"
+)
+
+// Service provides methods to interact with the Veracode Reporting API
+type Service struct {
+	client HTTPClient
+}
+
+// HTTPClient interface for making HTTP requests
+type HTTPClient interface {
+	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error)
+}
+
+func NewService(client HTTPClient) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// RequestReport asks for a report to be generated and returns its ID
+func (s *Service) RequestReport(request ReportRequest) (string, error) {
+	if request.ReportType == "" {
+		return "", fmt.Errorf("report type is required")
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report request: %w", err)
+	}
+	response, err := s.client.DoRequestWithBody("POST", reportBasePath, body, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result reportCreated
+	if err := json.Unmarshal(response, &result); err != nil {
+		return "", fmt.Errorf("failed to parse report request response: %w", err)
+	}
+	if result.Embedded.ID == "" {
+		return "", fmt.Errorf("the report request returned no report ID")
+	}
+	return result.Embedded.ID, nil
+}
+
+// GetReport retrieves the status of a report and, once it is completed, a page of its rows.
+// Pages are numbered from 0; a size of 0 uses the API default.
+func (s *Service) GetReport(reportID string, page, size int) (*ReportPage, error) {
+	if reportID == "" {
+		return nil, fmt.Errorf("reportID is required")
+	}
+
+	params := url.Values{}
+	if page > 0 {
+		params.Add("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		params.Add("size", strconv.Itoa(size))
+	}
+
+	body, err := s.client.DoRequestWithQueryParams("GET", fmt.Sprintf("%s/%s", reportBasePath, reportID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ReportPage
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse report response: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,200 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBodyFunc        func(method, urlPath string, body []byte, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+func (m *MockHTTPClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	if m.DoRequestWithBodyFunc != nil {
+		return m.DoRequestWithBodyFunc(method, urlPath, body, params)
+	}
+	return []byte("{}"), nil
+}
+
+func TestNewReportRequest(t *testing.T) {
+	from := time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	findingsRequest := NewReportRequest(ReportFindings, from, to)
+	if findingsRequest.LastUpdatedStartDate != "2025-06-01" || findingsRequest.LastUpdatedEndDate != "2025-06-30" || findingsRequest.StartDate != "" {
+		t.Errorf("Unexpected findings request %+v", findingsRequest)
+	}
+	scans := NewReportRequest(ReportScans, from, time.Time{})
+	if scans.StartDate != "2025-06-01" || scans.EndDate != "" {
+		t.Errorf("Unexpected scans request %+v", scans)
+	}
+	deleted := NewReportRequest(ReportDeletedScans, from, to)
+	if deleted.DeletionStartDate != "2025-06-01" || deleted.DeletionEndDate != "2025-06-30" {
+		t.Errorf("Unexpected deleted scans request %+v", deleted)
+	}
+
+	body, _ := json.Marshal(scans)
+	if string(body) != `{"report_type":"SCANS","start_date":"2025-06-01"}` {
+		t.Errorf("Unexpected request body %s", body)
+	}
+}
+
+func TestRequestReport(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "POST" || urlPath != reportBasePath || !strings.Contains(string(body), `"report_type":"FINDINGS"`) {
+				t.Errorf("Unexpected request %s %s %s", method, urlPath, body)
+			}
+			return []byte(`{"_embedded": {"id": "report-1"}}`), nil
+		},
+	}
+
+	id, err := NewService(client).RequestReport(NewReportRequest(ReportFindings, time.Now(), time.Time{}))
+	if err != nil || id != "report-1" {
+		t.Errorf("RequestReport = %q, %v", id, err)
+	}
+	if _, err := NewService(client).RequestReport(ReportRequest{}); err == nil {
+		t.Error("Expected an error without a report type")
+	}
+}
+
+func TestFindingRowDecodeAndConvert(t *testing.T) {
+	var row FindingRow
+	err := json.Unmarshal([]byte(`{
+		"app_id": 12, "app_name": "Verademo", "finding_id": 42, "scan_type": "Static Analysis",
+		"severity": "Very High", "cwe_id": 89, "cwe_name": "SQL Injection", "status": "Open",
+		"resolution": "Mitigated by Design", "resolution_status": "Approved",
+		"file_path": "src/db.java", "line_number": 12, "violates_policy": true,
+		"first_found_date": "2025-06-01 10:00:00.0", "last_updated_date": "2025-06-02T10:00:00Z"
+	}`), &row)
+	if err != nil {
+		t.Fatalf("Failed to decode row: %v", err)
+	}
+
+	f := row.Finding()
+	if f.IssueID != 42 || f.ScanType != findings.ScanTypeStatic || f.Severity() != 5 || f.CWEID() != 89 {
+		t.Errorf("Unexpected finding %+v", f)
+	}
+	if f.FileLine() != 12 || f.FilePath() != "src/db.java" || !f.ViolatesPolicy {
+		t.Errorf("Unexpected location %s:%d", f.FilePath(), f.FileLine())
+	}
+	if f.Status() != findings.StatusOpen || !f.IsMitigated() || f.FindingStatus.Resolution != "MITIGATED_BY_DESIGN" {
+		t.Errorf("Unexpected status %+v", f.FindingStatus)
+	}
+	if first := f.FindingStatus.FirstFoundDate; first == nil || first.Day() != 1 {
+		t.Errorf("Unexpected first found date %v", first)
+	}
+
+	var numeric FindingRow
+	if err := json.Unmarshal([]byte(`{"severity": 3, "status": "Closed", "scan_type": "SCA"}`), &numeric); err != nil {
+		t.Fatal(err)
+	}
+	if f := numeric.Finding(); f.Severity() != 3 || f.Status() != findings.StatusClosed || f.ScanType != findings.ScanTypeSCA {
+		t.Errorf("Unexpected finding %+v", f)
+	}
+}
+
+// fakeReport serves a report that completes after the given number of polls and has rows findings
+// split into pages of pageSize
+type fakeReport struct {
+	t         *testing.T
+	polls     int
+	rows      int
+	pageSize  int
+	requested int
+	status    ReportStatus // Status once the polls are done
+}
+
+func (f *fakeReport) client() *MockHTTPClient {
+	return &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			return []byte(`{"_embedded": {"id": "r1"}}`), nil
+		},
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != reportBasePath+"/r1" {
+				f.t.Errorf("Unexpected path %s", urlPath)
+			}
+			f.requested++
+			if f.requested <= f.polls {
+				return []byte(`{"_embedded": {"id": "r1", "status": "PROCESSING"}}`), nil
+			}
+
+			page := 0
+			fmt.Sscan(params.Get("page"), &page)
+			var rows []FindingRow
+			for id := page * f.pageSize; id < min((page+1)*f.pageSize, f.rows); id++ {
+				rows = append(rows, FindingRow{IssueID: int64(id + 1)})
+			}
+			totalPages := (f.rows + f.pageSize - 1) / f.pageSize
+			return json.Marshal(ReportPage{
+				Embedded: ReportContent{ID: "r1", Status: f.status, Findings: rows},
+				Page:     &PageMetadata{Number: int64(page), TotalPages: int64(totalPages), TotalElements: int64(f.rows)},
+			})
+		},
+	}
+}
+
+// testJob creates a job with a fake clock that advances by each sleep
+func testJob(report *fakeReport) (*Job, *[]Progress) {
+	job := NewService(report.client()).NewJob(NewReportRequest(ReportFindings, time.Now(), time.Time{}))
+	job.PageSize = report.pageSize
+	clock := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return clock }
+	job.sleep = func(d time.Duration) { clock = clock.Add(d) }
+
+	var progress []Progress
+	job.OnProgress = func(p Progress) { progress = append(progress, p) }
+	return job, &progress
+}
+
+func TestJobRun(t *testing.T) {
+	job, progress := testJob(&fakeReport{t: t, polls: 2, rows: 5, pageSize: 2, status: StatusCompleted})
+
+	content, err := job.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(content.Findings) != 5 || content.Findings[4].IssueID != 5 {
+		t.Errorf("Expected the five rows of all three pages, got %+v", content.Findings)
+	}
+
+	var stages []string
+	for _, p := range *progress {
+		stages = append(stages, string(p.Stage))
+	}
+	want := "requested waiting waiting collecting collecting collecting done"
+	if strings.Join(stages, " ") != want {
+		t.Errorf("Progress stages = %v, want %s", stages, want)
+	}
+	if last := (*progress)[len(*progress)-1]; last.Rows != 5 || last.TotalPages != 3 || last.String() != "Report r1 complete (5 rows)" {
+		t.Errorf("Unexpected final progress %+v", last)
+	}
+}
+
+func TestJobRunFailures(t *testing.T) {
+	job, _ := testJob(&fakeReport{t: t, rows: 1, pageSize: 1, status: StatusFailed})
+	if _, err := job.Run(); err == nil || !strings.Contains(err.Error(), "failed to generate") {
+		t.Errorf("Expected a failed report error, got %v", err)
+	}
+
+	job, _ = testJob(&fakeReport{t: t, polls: 1000, rows: 1, pageSize: 1, status: StatusCompleted})
+	job.Timeout = time.Minute
+	if _, err := job.Run(); err == nil || !strings.Contains(err.Error(), "not generated within 1m0s") {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+}
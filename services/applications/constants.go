@@ -0,0 +1,91 @@
+package applications
+
+// PolicyComplianceStatus represents the policy compliance status of an application
+type PolicyComplianceStatus string
+
+// Policy compliance statuses accepted by the policy_compliance filter
+const (
+	PolicyComplianceDetermining     PolicyComplianceStatus = "DETERMINING"
+	PolicyComplianceNotAssessed     PolicyComplianceStatus = "NOT_ASSESSED"
+	PolicyComplianceDidNotPass      PolicyComplianceStatus = "DID_NOT_PASS"
+	PolicyComplianceConditionalPass PolicyComplianceStatus = "CONDITIONAL_PASS"
+	PolicyCompliancePassed          PolicyComplianceStatus = "PASSED"
+	PolicyComplianceVendorReview    PolicyComplianceStatus = "VENDOR_REVIEW"
+)
+
+// PolicyComplianceStatuses lists all policy compliance statuses in display order
+var PolicyComplianceStatuses = []PolicyComplianceStatus{
+	PolicyCompliancePassed,
+	PolicyComplianceConditionalPass,
+	PolicyComplianceDidNotPass,
+	PolicyComplianceNotAssessed,
+	PolicyComplianceDetermining,
+	PolicyComplianceVendorReview,
+}
+
+// BusinessCriticality represents the business criticality of an application
+type BusinessCriticality string
+
+// Business criticalities accepted in an application profile
+const (
+	BusinessCriticalityVeryHigh BusinessCriticality = "VERY_HIGH"
+	BusinessCriticalityHigh     BusinessCriticality = "HIGH"
+	BusinessCriticalityMedium   BusinessCriticality = "MEDIUM"
+	BusinessCriticalityLow      BusinessCriticality = "LOW"
+	BusinessCriticalityVeryLow  BusinessCriticality = "VERY_LOW"
+)
+
+// BusinessCriticalities lists all business criticalities, highest first
+var BusinessCriticalities = []BusinessCriticality{
+	BusinessCriticalityVeryHigh,
+	BusinessCriticalityHigh,
+	BusinessCriticalityMedium,
+	BusinessCriticalityLow,
+	BusinessCriticalityVeryLow,
+}
+
+// ScanType represents the scan type filter for applications
+type ScanType string
+
+// Scan types accepted by the scan_type filter
+const (
+	ScanTypeStatic  ScanType = "STATIC"
+	ScanTypeDynamic ScanType = "DYNAMIC"
+	ScanTypeManual  ScanType = "MANUAL"
+)
+
+// ScanTypes lists all scan types accepted by the scan_type filter
+var ScanTypes = []ScanType{ScanTypeStatic, ScanTypeDynamic, ScanTypeManual}
+
+// ScanStatus represents the status of an application's scan
+type ScanStatus string
+
+// Scan statuses accepted by the scan_status filter
+const (
+	ScanStatusCreated          ScanStatus = "CREATED"
+	ScanStatusUnpublished      ScanStatus = "UNPUBLISHED"
+	ScanStatusDeleted          ScanStatus = "DELETED"
+	ScanStatusPartialPublish   ScanStatus = "PARTIAL_PUBLISH"
+	ScanStatusIncomplete       ScanStatus = "INCOMPLETE"
+	ScanStatusScanSubmitted    ScanStatus = "SCAN_SUBMITTED"
+	ScanStatusInQueue          ScanStatus = "IN_QUEUE"
+	ScanStatusInProgress       ScanStatus = "IN_PROGRESS"
+	ScanStatusAnalysisComplete ScanStatus = "ANALYSIS_COMPLETE"
+	ScanStatusScanCanceled     ScanStatus = "SCAN_CANCELED"
+	ScanStatusPublished        ScanStatus = "PUBLISHED"
+)
+
+// ScanStatuses lists the commonly used scan statuses in display order
+var ScanStatuses = []ScanStatus{
+	ScanStatusPublished,
+	ScanStatusInProgress,
+	ScanStatusScanSubmitted,
+	ScanStatusInQueue,
+	ScanStatusAnalysisComplete,
+	ScanStatusIncomplete,
+	ScanStatusPartialPublish,
+	ScanStatusUnpublished,
+	ScanStatusCreated,
+	ScanStatusScanCanceled,
+	ScanStatusDeleted,
+}
@@ -19,6 +19,7 @@ type Service struct {
 // HTTPClient interface for making HTTP requests
 type HTTPClient interface {
 	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error)
 }
 
 func NewService(client HTTPClient) *Service {
@@ -65,6 +66,38 @@ func (s *Service) GetApplications(opts *GetApplicationsOptions) (*PagedResourceO
 	return &result, nil
 }
 
+// maxApplicationsPageSize is the page size used when retrieving all applications
+const maxApplicationsPageSize = 500
+
+// GetAllApplications retrieves every page of applications matching the options.
+// The Size and Page fields of opts are ignored.
+func (s *Service) GetAllApplications(opts *GetApplicationsOptions) ([]Application, error) {
+	pageOpts := GetApplicationsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.Size = maxApplicationsPageSize
+
+	var all []Application
+	for page := 0; ; page++ {
+		pageOpts.Page = page
+		result, err := s.GetApplications(&pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applications page %d: %w", page, err)
+		}
+
+		if result.Embedded != nil {
+			all = append(all, result.Embedded.Applications...)
+		}
+
+		if result.Page == nil || int64(page+1) >= result.Page.TotalPages {
+			break
+		}
+	}
+
+	return all, nil
+}
+
 // buildApplicationQueryParams builds URL query parameters from options
 //
 //nolint:gocyclo // Parameter building with many optional fields
@@ -150,6 +183,97 @@ func (s *Service) GetApplication(applicationGUID string) (*Application, error) {
 	return &result, nil
 }
 
+// applicationRequest is the body of requests that create or update an application
+type applicationRequest struct {
+	Profile *ApplicationProfile `json:"profile"`
+}
+
+// CreateApplication creates an application with the given profile. The profile needs a name and
+// a business criticality; the business unit, teams and policies are referenced by GUID.
+func (s *Service) CreateApplication(profile *ApplicationProfile) (*Application, error) {
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+	return s.writeApplication("POST", applicationsBasePath, profile)
+}
+
+// UpdateApplication replaces the profile of an application. Fields left empty in the profile are
+// cleared, so callers should start from the current profile and change what they need.
+func (s *Service) UpdateApplication(applicationGUID string, profile *ApplicationProfile) (*Application, error) {
+	if applicationGUID == "" {
+		return nil, fmt.Errorf("applicationGUID is required")
+	}
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+	urlPath := fmt.Sprintf("%s/%s", applicationsBasePath, applicationGUID)
+	return s.writeApplication("PUT", urlPath, profile)
+}
+
+// DeleteApplication deletes an application along with its sandboxes and scan results
+func (s *Service) DeleteApplication(applicationGUID string) error {
+	if applicationGUID == "" {
+		return fmt.Errorf("applicationGUID is required")
+	}
+
+	urlPath := fmt.Sprintf("%s/%s", applicationsBasePath, applicationGUID)
+	_, err := s.client.DoRequestWithBody("DELETE", urlPath, nil, nil)
+	return err
+}
+
+func validateProfile(profile *ApplicationProfile) error {
+	if profile == nil {
+		return fmt.Errorf("profile is required")
+	}
+	if profile.Name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if profile.BusinessCriticality == "" {
+		return fmt.Errorf("business criticality is required")
+	}
+	return nil
+}
+
+func (s *Service) writeApplication(method, urlPath string, profile *ApplicationProfile) (*Application, error) {
+	jsonBody, err := json.Marshal(applicationRequest{Profile: writableProfile(profile)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal application profile: %w", err)
+	}
+
+	body, err := s.client.DoRequestWithBody(method, urlPath, jsonBody, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Application
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse application response: %w", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// writableProfile copies a profile for a create or update request. The business unit, teams and
+// policies are sent by GUID only, and the read-only custom field values are dropped.
+func writableProfile(profile *ApplicationProfile) *ApplicationProfile {
+	out := *profile
+	out.CustomFieldValues = nil
+	if profile.BusinessUnit != nil {
+		out.BusinessUnit = &BusinessUnit{GUID: profile.BusinessUnit.GUID}
+	}
+	out.Teams = nil
+	for _, team := range profile.Teams {
+		out.Teams = append(out.Teams, AppTeam{GUID: team.GUID})
+	}
+	out.Policies = nil
+	for _, policy := range profile.Policies {
+		out.Policies = append(out.Policies, AppPolicy{GUID: policy.GUID})
+	}
+	return &out
+}
+
 // GetSandboxesOptions contains optional parameters for GetSandboxes
 type GetSandboxesOptions struct {
 	Page int
@@ -208,3 +332,94 @@ func (s *Service) GetSandbox(applicationGUID, sandboxGUID string) (*Sandbox, err
 
 	return &result, nil
 }
+
+// SandboxRequest contains the writable fields of a sandbox
+type SandboxRequest struct {
+	Name         string            `json:"name"`
+	AutoRecreate bool              `json:"auto_recreate"`
+	CustomFields []CustomNameValue `json:"custom_fields,omitempty"`
+}
+
+// CreateSandbox creates a sandbox for an application
+func (s *Service) CreateSandbox(applicationGUID string, sandbox *SandboxRequest) (*Sandbox, error) {
+	if applicationGUID == "" {
+		return nil, fmt.Errorf("applicationGUID is required")
+	}
+	if sandbox == nil || sandbox.Name == "" {
+		return nil, fmt.Errorf("sandbox name is required")
+	}
+
+	urlPath := fmt.Sprintf("%s/%s/sandboxes", applicationsBasePath, applicationGUID)
+	return s.writeSandbox("POST", urlPath, sandbox, nil)
+}
+
+// UpdateSandbox renames a sandbox and replaces its auto-recreate setting and custom fields
+func (s *Service) UpdateSandbox(applicationGUID, sandboxGUID string, sandbox *SandboxRequest) (*Sandbox, error) {
+	if applicationGUID == "" {
+		return nil, fmt.Errorf("applicationGUID is required")
+	}
+	if sandboxGUID == "" {
+		return nil, fmt.Errorf("sandboxGUID is required")
+	}
+	if sandbox == nil || sandbox.Name == "" {
+		return nil, fmt.Errorf("sandbox name is required")
+	}
+
+	urlPath := fmt.Sprintf("%s/%s/sandboxes/%s", applicationsBasePath, applicationGUID, sandboxGUID)
+	return s.writeSandbox("PUT", urlPath, sandbox, nil)
+}
+
+// DeleteSandbox deletes a sandbox and its scan results
+func (s *Service) DeleteSandbox(applicationGUID, sandboxGUID string) error {
+	if applicationGUID == "" {
+		return fmt.Errorf("applicationGUID is required")
+	}
+	if sandboxGUID == "" {
+		return fmt.Errorf("sandboxGUID is required")
+	}
+
+	urlPath := fmt.Sprintf("%s/%s/sandboxes/%s", applicationsBasePath, applicationGUID, sandboxGUID)
+	_, err := s.client.DoRequestWithBody("DELETE", urlPath, nil, nil)
+	return err
+}
+
+// PromoteSandbox promotes the latest scan of a sandbox to the policy scan of the application.
+// With deleteOnPromote the sandbox is deleted once it has been promoted.
+func (s *Service) PromoteSandbox(applicationGUID, sandboxGUID string, deleteOnPromote bool) (*Sandbox, error) {
+	if applicationGUID == "" {
+		return nil, fmt.Errorf("applicationGUID is required")
+	}
+	if sandboxGUID == "" {
+		return nil, fmt.Errorf("sandboxGUID is required")
+	}
+
+	params := url.Values{}
+	params.Add("delete_on_promote", strconv.FormatBool(deleteOnPromote))
+
+	urlPath := fmt.Sprintf("%s/%s/sandboxes/%s/promote", applicationsBasePath, applicationGUID, sandboxGUID)
+	return s.writeSandbox("POST", urlPath, nil, params)
+}
+
+func (s *Service) writeSandbox(method, urlPath string, sandbox *SandboxRequest, params url.Values) (*Sandbox, error) {
+	var jsonBody []byte
+	if sandbox != nil {
+		var err error
+		if jsonBody, err = json.Marshal(sandbox); err != nil {
+			return nil, fmt.Errorf("failed to marshal sandbox: %w", err)
+		}
+	}
+
+	body, err := s.client.DoRequestWithBody(method, urlPath, jsonBody, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Sandbox
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse sandbox response: %w", err)
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,60 @@
+package applications
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBodyFunc        func(method, urlPath string, body []byte, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+func (m *MockHTTPClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	if m.DoRequestWithBodyFunc != nil {
+		return m.DoRequestWithBodyFunc(method, urlPath, body, params)
+	}
+	return []byte("{}"), nil
+}
+
+func TestGetAllApplicationsPaging(t *testing.T) {
+	var requestedPages []string
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if params.Get("size") != "500" || params.Get("team") != "Payments" {
+				t.Errorf("Expected size 500 and the team filter, got %v", params)
+			}
+
+			page := params.Get("page")
+			if page == "" {
+				page = "0"
+			}
+			requestedPages = append(requestedPages, page)
+			return []byte(fmt.Sprintf(`{
+				"_embedded": {"applications": [{"guid": "app-%s"}]},
+				"page": {"size": 500, "total_elements": 2, "total_pages": 2}
+			}`, page)), nil
+		},
+	}
+
+	service := NewService(client)
+	all, err := service.GetAllApplications(&GetApplicationsOptions{Team: "Payments", Page: 4, Size: 10})
+	if err != nil {
+		t.Fatalf("GetAllApplications failed: %v", err)
+	}
+	if len(requestedPages) != 2 || requestedPages[0] != "0" || requestedPages[1] != "1" {
+		t.Errorf("Expected pages 0 and 1 to be requested, got %v", requestedPages)
+	}
+	if len(all) != 2 || all[1].GUID != "app-1" {
+		t.Errorf("Expected both pages of applications, got %+v", all)
+	}
+}
@@ -0,0 +1,165 @@
+package applications
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+func TestCreateApplication(t *testing.T) {
+	var sent map[string]map[string]interface{}
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "POST" || urlPath != applicationsBasePath {
+				t.Errorf("Unexpected request %s %s", method, urlPath)
+			}
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Fatalf("Invalid request body: %v", err)
+			}
+			return []byte(`{"guid": "new-app", "profile": {"name": "Payments"}}`), nil
+		},
+	}
+
+	app, err := NewService(client).CreateApplication(&ApplicationProfile{
+		Name:                "Payments",
+		BusinessCriticality: string(BusinessCriticalityHigh),
+		BusinessUnit:        &BusinessUnit{GUID: "bu-1", Name: "Finance"},
+		Teams:               []AppTeam{{GUID: "team-1", TeamName: "Core"}},
+		Policies:            []AppPolicy{{GUID: "policy-1", Name: "Default", PolicyComplianceStatus: "PASSED"}},
+		CustomFieldValues:   []AppCustomFieldValue{{}},
+	})
+	if err != nil {
+		t.Fatalf("CreateApplication failed: %v", err)
+	}
+	if app.GUID != "new-app" {
+		t.Errorf("Expected the created application, got %+v", app)
+	}
+
+	profile := sent["profile"]
+	if profile["name"] != "Payments" || profile["business_criticality"] != "HIGH" {
+		t.Errorf("Unexpected profile %v", profile)
+	}
+	if bu := profile["business_unit"].(map[string]interface{}); len(bu) != 1 || bu["guid"] != "bu-1" {
+		t.Errorf("Expected the business unit by GUID only, got %v", bu)
+	}
+	if policy := profile["policies"].([]interface{})[0].(map[string]interface{}); len(policy) != 1 {
+		t.Errorf("Expected the policy by GUID only, got %v", policy)
+	}
+	if _, ok := profile["custom_field_values"]; ok {
+		t.Error("Expected custom field values to be left out")
+	}
+}
+
+func TestUpdateApplication(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "PUT" || urlPath != applicationsBasePath+"/app-1" {
+				t.Errorf("Unexpected request %s %s", method, urlPath)
+			}
+			return nil, nil
+		},
+	}
+
+	service := NewService(client)
+	if _, err := service.UpdateApplication("app-1", &ApplicationProfile{Name: "Payments", BusinessCriticality: "LOW"}); err != nil {
+		t.Fatalf("UpdateApplication failed: %v", err)
+	}
+	if _, err := service.UpdateApplication("app-1", &ApplicationProfile{Name: "Payments"}); err == nil {
+		t.Error("Expected an error without a business criticality")
+	}
+	if _, err := service.UpdateApplication("", &ApplicationProfile{Name: "Payments", BusinessCriticality: "LOW"}); err == nil {
+		t.Error("Expected an error without an application GUID")
+	}
+}
+
+func TestDeleteApplication(t *testing.T) {
+	var deleted string
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method == "DELETE" {
+				deleted = urlPath
+			}
+			return nil, nil
+		},
+	}
+
+	if err := NewService(client).DeleteApplication("app-1"); err != nil {
+		t.Fatalf("DeleteApplication failed: %v", err)
+	}
+	if deleted != applicationsBasePath+"/app-1" {
+		t.Errorf("Expected the application to be deleted, got %q", deleted)
+	}
+}
+
+func TestCreateSandbox(t *testing.T) {
+	var sent map[string]interface{}
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "POST" || urlPath != applicationsBasePath+"/app-1/sandboxes" {
+				t.Errorf("Unexpected request %s %s", method, urlPath)
+			}
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Fatalf("Invalid request body: %v", err)
+			}
+			return []byte(`{"guid": "sandbox-1", "name": "feature"}`), nil
+		},
+	}
+
+	sandbox, err := NewService(client).CreateSandbox("app-1", &SandboxRequest{
+		Name:         "feature",
+		CustomFields: []CustomNameValue{{Name: "Custom 1", Value: "JIRA-1"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateSandbox failed: %v", err)
+	}
+	if sandbox.GUID != "sandbox-1" {
+		t.Errorf("Expected the created sandbox, got %+v", sandbox)
+	}
+	if sent["name"] != "feature" || sent["auto_recreate"] != false || len(sent["custom_fields"].([]interface{})) != 1 {
+		t.Errorf("Unexpected request body %v", sent)
+	}
+}
+
+func TestUpdateAndDeleteSandbox(t *testing.T) {
+	var requests []string
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			requests = append(requests, method+" "+urlPath)
+			return nil, nil
+		},
+	}
+
+	service := NewService(client)
+	if _, err := service.UpdateSandbox("app-1", "sandbox-1", &SandboxRequest{Name: "renamed", AutoRecreate: true}); err != nil {
+		t.Fatalf("UpdateSandbox failed: %v", err)
+	}
+	if err := service.DeleteSandbox("app-1", "sandbox-1"); err != nil {
+		t.Fatalf("DeleteSandbox failed: %v", err)
+	}
+	if _, err := service.UpdateSandbox("app-1", "sandbox-1", &SandboxRequest{}); err == nil {
+		t.Error("Expected an error without a sandbox name")
+	}
+
+	sandboxPath := applicationsBasePath + "/app-1/sandboxes/sandbox-1"
+	if len(requests) != 2 || requests[0] != "PUT "+sandboxPath || requests[1] != "DELETE "+sandboxPath {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestPromoteSandbox(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "POST" || urlPath != applicationsBasePath+"/app-1/sandboxes/sandbox-1/promote" {
+				t.Errorf("Unexpected request %s %s", method, urlPath)
+			}
+			if params.Get("delete_on_promote") != "true" || body != nil {
+				t.Errorf("Expected delete_on_promote without a body, got %v %s", params, body)
+			}
+			return []byte(`{"guid": "sandbox-1"}`), nil
+		},
+	}
+
+	if _, err := NewService(client).PromoteSandbox("app-1", "sandbox-1", true); err != nil {
+		t.Fatalf("PromoteSandbox failed: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package findings
+
+import (
+	"cmp"
+	"slices"
+)
+
+// StatusDifference is a finding reported in both the policy scan and a sandbox whose status or
+// resolution differs between them
+type StatusDifference struct {
+	Policy  *Finding
+	Sandbox *Finding
+}
+
+// ContextComparison is the difference between the findings of an application's policy scan and
+// one of its sandboxes. Findings are matched on scan type and issue ID, which Veracode keeps for
+// the same flaw across the contexts of an application.
+type ContextComparison struct {
+	SandboxOnly   []*Finding         // Reported only in the sandbox, such as flaws introduced on a branch
+	PolicyOnly    []*Finding         // Reported only in the policy scan, such as flaws fixed on a branch
+	StatusDiffers []StatusDifference // Reported in both with a different status or resolution
+	Shared        int                // Reported in both, whatever their status
+}
+
+// CompareContexts compares the findings of the policy scan with those of a sandbox. Each list is
+// sorted by severity, highest first, then by issue ID.
+func CompareContexts(policy, sandbox []Finding) *ContextComparison {
+	comparison := &ContextComparison{}
+
+	inPolicy := indexFindings(policy)
+	inSandbox := indexFindings(sandbox)
+
+	for i := range sandbox {
+		f := &sandbox[i]
+		p, shared := inPolicy[contextKey(f)]
+		if !shared {
+			comparison.SandboxOnly = append(comparison.SandboxOnly, f)
+			continue
+		}
+		comparison.Shared++
+		if !sameStatus(p, f) {
+			comparison.StatusDiffers = append(comparison.StatusDiffers, StatusDifference{Policy: p, Sandbox: f})
+		}
+	}
+	for i := range policy {
+		if _, shared := inSandbox[contextKey(&policy[i])]; !shared {
+			comparison.PolicyOnly = append(comparison.PolicyOnly, &policy[i])
+		}
+	}
+
+	slices.SortStableFunc(comparison.SandboxOnly, compareBySeverity)
+	slices.SortStableFunc(comparison.PolicyOnly, compareBySeverity)
+	slices.SortStableFunc(comparison.StatusDiffers, func(a, b StatusDifference) int {
+		return compareBySeverity(a.Sandbox, b.Sandbox)
+	})
+	return comparison
+}
+
+// Empty reports whether the contexts have the same findings with the same statuses
+func (c *ContextComparison) Empty() bool {
+	return len(c.SandboxOnly)+len(c.PolicyOnly)+len(c.StatusDiffers) == 0
+}
+
+type contextFindingKey struct {
+	scanType ScanType
+	issueID  int64
+}
+
+func contextKey(f *Finding) contextFindingKey {
+	return contextFindingKey{scanType: f.ScanType, issueID: f.IssueID}
+}
+
+func indexFindings(list []Finding) map[contextFindingKey]*Finding {
+	index := make(map[contextFindingKey]*Finding, len(list))
+	for i := range list {
+		index[contextKey(&list[i])] = &list[i]
+	}
+	return index
+}
+
+// sameStatus reports whether two findings have the same status and resolution
+func sameStatus(a, b *Finding) bool {
+	return a.Status() == b.Status() && resolution(a) == resolution(b)
+}
+
+// resolution returns the resolution status of a finding, treating a missing one as NONE
+func resolution(f *Finding) ResolutionStatus {
+	if f.FindingStatus == nil || f.FindingStatus.ResolutionStatus == "" {
+		return ResolutionNone
+	}
+	return f.FindingStatus.ResolutionStatus
+}
+
+func compareBySeverity(a, b *Finding) int {
+	if result := cmp.Compare(b.Severity(), a.Severity()); result != 0 {
+		return result
+	}
+	return cmp.Compare(a.IssueID, b.IssueID)
+}
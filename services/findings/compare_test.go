@@ -0,0 +1,53 @@
+package findings
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeFindings(t *testing.T, data string) []Finding {
+	t.Helper()
+	var list []Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	return list
+}
+
+func TestCompareContexts(t *testing.T) {
+	policy := decodeFindings(t, `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 3}},
+		{"issue_id": 2, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 4}},
+		{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "resolution_status": "NONE"}, "finding_details": {"severity": 2}},
+		{"issue_id": 4, "scan_type": "SCA", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5}}
+	]`)
+	sandbox := decodeFindings(t, `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"}, "finding_details": {"severity": 3}},
+		{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 2}},
+		{"issue_id": 4, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 2}},
+		{"issue_id": 5, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5}}
+	]`)
+
+	c := CompareContexts(policy, sandbox)
+
+	if len(c.SandboxOnly) != 2 || c.SandboxOnly[0].IssueID != 5 || c.SandboxOnly[1].IssueID != 4 {
+		t.Errorf("Unexpected sandbox-only findings %+v", c.SandboxOnly)
+	}
+	if len(c.PolicyOnly) != 2 || c.PolicyOnly[0].IssueID != 4 || c.PolicyOnly[1].IssueID != 2 {
+		t.Errorf("Unexpected policy-only findings %+v", c.PolicyOnly)
+	}
+	if len(c.StatusDiffers) != 1 || c.StatusDiffers[0].Policy.Status() != StatusOpen || c.StatusDiffers[0].Sandbox.Status() != StatusClosed {
+		t.Errorf("Unexpected status differences %+v", c.StatusDiffers)
+	}
+	if c.Shared != 2 || c.Empty() {
+		t.Errorf("Expected 2 shared findings, got %d", c.Shared)
+	}
+}
+
+func TestCompareContextsIdentical(t *testing.T) {
+	list := decodeFindings(t, `[{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}}]`)
+
+	if c := CompareContexts(list, list); !c.Empty() || c.Shared != 1 {
+		t.Errorf("Expected no differences, got %+v", c)
+	}
+}
@@ -0,0 +1,66 @@
+package findings
+
+import "github.com/dipsylala/veracode-tui/services/findings/cvss"
+
+// CVSSScores returns the CVSS scores reported with a finding, v3 before v2. SCA findings report
+// them on their CVE (cve.cvss3.score and vector for v3, cve.cvss and cve.vector for v2); other
+// findings may carry the same fields directly in their details. A score that is missing but has
+// a valid vector is calculated from the vector.
+func (f *Finding) CVSSScores() []cvss.Score {
+	details := f.Details()
+	if cve, ok := details["cve"].(map[string]interface{}); ok {
+		if scores := readCVSSScores(cve); len(scores) > 0 {
+			return scores
+		}
+	}
+	return readCVSSScores(details)
+}
+
+// CVSS returns the preferred CVSS score of a finding (v3 over v2), and whether it has one
+func (f *Finding) CVSS() (cvss.Score, bool) {
+	scores := f.CVSSScores()
+	if len(scores) == 0 {
+		return cvss.Score{}, false
+	}
+	return scores[0], true
+}
+
+// CVSSBase returns the preferred CVSS base score, or -1 if the finding has none so that
+// findings without a score sort below those scored 0
+func (f *Finding) CVSSBase() float64 {
+	if score, ok := f.CVSS(); ok {
+		return score.Base
+	}
+	return -1
+}
+
+func readCVSSScores(object map[string]interface{}) []cvss.Score {
+	var scores []cvss.Score
+	if v3, ok := object["cvss3"].(map[string]interface{}); ok {
+		base, hasBase := v3["score"].(float64)
+		vector, _ := v3["vector"].(string)
+		if score, ok := newCVSSScore(cvss.Version31, base, hasBase, vector); ok {
+			scores = append(scores, score)
+		}
+	}
+	base, hasBase := object["cvss"].(float64)
+	vector, _ := object["vector"].(string)
+	if score, ok := newCVSSScore(cvss.Version2, base, hasBase, vector); ok {
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// newCVSSScore combines a reported score and vector, taking the version from the vector when
+// it parses and calculating the score from it when none was reported
+func newCVSSScore(version cvss.Version, base float64, hasBase bool, vector string) (cvss.Score, bool) {
+	score := cvss.Score{Version: version, Base: base, Vector: vector}
+	parsed := score.Parsed()
+	if parsed != nil {
+		score.Version = parsed.Version
+		if !hasBase {
+			score.Base = parsed.BaseScore()
+		}
+	}
+	return score, hasBase || parsed != nil
+}
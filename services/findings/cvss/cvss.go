@@ -0,0 +1,313 @@
+// Package cvss parses CVSS v2 and v3.x base vectors, such as
+// CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H, and calculates their base scores.
+package cvss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Version is a CVSS specification version
+type Version string
+
+// Supported versions
+const (
+	Version2  Version = "2.0"
+	Version30 Version = "3.0"
+	Version31 Version = "3.1"
+)
+
+// IsV3 reports whether the version is one of the CVSS v3.x versions
+func (v Version) IsV3() bool {
+	return v == Version30 || v == Version31
+}
+
+// Major returns "v2" or "v3", as versions are commonly labelled
+func (v Version) Major() string {
+	if v.IsV3() {
+		return "v3"
+	}
+	return "v2"
+}
+
+// Metric is one base metric of a vector
+type Metric struct {
+	Key       string // Abbreviation, e.g. AV
+	Name      string // e.g. Attack Vector
+	Value     string // Abbreviated value, e.g. N
+	ValueName string // e.g. Network
+}
+
+// Vector is a parsed CVSS vector. Metrics holds the base metrics in specification order;
+// temporal and environmental metrics in the vector are ignored.
+type Vector struct {
+	Version Version
+	Metrics []Metric
+}
+
+// metricDef describes a base metric and the weights of its values
+type metricDef struct {
+	key     string
+	name    string
+	values  map[string]string  // Abbreviation to name
+	weights map[string]float64 // Abbreviation to weight; PR is adjusted for a changed scope
+}
+
+var v2Metrics = []metricDef{
+	{key: "AV", name: "Access Vector",
+		values:  map[string]string{"L": "Local", "A": "Adjacent Network", "N": "Network"},
+		weights: map[string]float64{"L": 0.395, "A": 0.646, "N": 1.0}},
+	{key: "AC", name: "Access Complexity",
+		values:  map[string]string{"H": "High", "M": "Medium", "L": "Low"},
+		weights: map[string]float64{"H": 0.35, "M": 0.61, "L": 0.71}},
+	{key: "Au", name: "Authentication",
+		values:  map[string]string{"M": "Multiple", "S": "Single", "N": "None"},
+		weights: map[string]float64{"M": 0.45, "S": 0.56, "N": 0.704}},
+	v2Impact("C", "Confidentiality Impact"),
+	v2Impact("I", "Integrity Impact"),
+	v2Impact("A", "Availability Impact"),
+}
+
+func v2Impact(key, name string) metricDef {
+	return metricDef{key: key, name: name,
+		values:  map[string]string{"N": "None", "P": "Partial", "C": "Complete"},
+		weights: map[string]float64{"N": 0, "P": 0.275, "C": 0.660}}
+}
+
+var v3Metrics = []metricDef{
+	{key: "AV", name: "Attack Vector",
+		values:  map[string]string{"N": "Network", "A": "Adjacent", "L": "Local", "P": "Physical"},
+		weights: map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}},
+	{key: "AC", name: "Attack Complexity",
+		values:  map[string]string{"L": "Low", "H": "High"},
+		weights: map[string]float64{"L": 0.77, "H": 0.44}},
+	{key: "PR", name: "Privileges Required",
+		values:  map[string]string{"N": "None", "L": "Low", "H": "High"},
+		weights: map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}},
+	{key: "UI", name: "User Interaction",
+		values:  map[string]string{"N": "None", "R": "Required"},
+		weights: map[string]float64{"N": 0.85, "R": 0.62}},
+	{key: "S", name: "Scope",
+		values: map[string]string{"U": "Unchanged", "C": "Changed"}},
+	v3Impact("C", "Confidentiality"),
+	v3Impact("I", "Integrity"),
+	v3Impact("A", "Availability"),
+}
+
+func v3Impact(key, name string) metricDef {
+	return metricDef{key: key, name: name,
+		values:  map[string]string{"H": "High", "L": "Low", "N": "None"},
+		weights: map[string]float64{"H": 0.56, "L": 0.22, "N": 0}}
+}
+
+// Parse parses a CVSS v2 or v3.x vector. v3 vectors are recognised by their CVSS:3.x prefix,
+// or by their metrics when the prefix is missing (as in some Veracode SCA data), in which case
+// version 3.1 is assumed. A v2 vector may be wrapped in parentheses, as NVD used to write them.
+func Parse(s string) (*Vector, error) {
+	text := strings.TrimSpace(s)
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "("), ")")
+	if text == "" {
+		return nil, fmt.Errorf("empty CVSS vector")
+	}
+
+	parts := strings.Split(text, "/")
+	var version Version
+	if prefix, ok := strings.CutPrefix(parts[0], "CVSS:"); ok {
+		switch Version(prefix) {
+		case Version30, Version31:
+			version = Version(prefix)
+		default:
+			return nil, fmt.Errorf("unsupported CVSS version %q", prefix)
+		}
+		parts = parts[1:]
+	}
+
+	values := make(map[string]string, len(parts))
+	for _, part := range parts {
+		key, value, ok := strings.Cut(part, ":")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid CVSS metric %q in %q", part, s)
+		}
+		if _, duplicate := values[key]; duplicate {
+			return nil, fmt.Errorf("duplicate CVSS metric %q in %q", key, s)
+		}
+		values[key] = value
+	}
+
+	if version == "" {
+		_, hasAu := values["Au"]
+		_, hasPR := values["PR"]
+		switch {
+		case hasAu && !hasPR:
+			version = Version2
+		case hasPR && !hasAu:
+			version = Version31
+		default:
+			return nil, fmt.Errorf("cannot tell the CVSS version of %q", s)
+		}
+	}
+
+	defs := v3Metrics
+	if version == Version2 {
+		defs = v2Metrics
+	}
+	vector := &Vector{Version: version}
+	for _, def := range defs {
+		value, ok := values[def.key]
+		if !ok {
+			return nil, fmt.Errorf("CVSS %s vector %q has no %s metric", version, s, def.key)
+		}
+		name, ok := def.values[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid CVSS %s value %q in %q", def.key, value, s)
+		}
+		vector.Metrics = append(vector.Metrics, Metric{Key: def.key, Name: def.name, Value: value, ValueName: name})
+	}
+	return vector, nil
+}
+
+// Get returns the value of a base metric, or "" if the vector does not have it
+func (v *Vector) Get(key string) string {
+	for _, m := range v.Metrics {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// String returns the vector in its canonical form
+func (v *Vector) String() string {
+	parts := make([]string, 0, len(v.Metrics)+1)
+	if v.Version.IsV3() {
+		parts = append(parts, "CVSS:"+string(v.Version))
+	}
+	for _, m := range v.Metrics {
+		parts = append(parts, m.Key+":"+m.Value)
+	}
+	return strings.Join(parts, "/")
+}
+
+// BaseScore calculates the base score of the vector
+func (v *Vector) BaseScore() float64 {
+	if v.Version == Version2 {
+		return v.v2BaseScore()
+	}
+	return v.v3BaseScore()
+}
+
+func (v *Vector) weight(defs []metricDef, key string) float64 {
+	for _, def := range defs {
+		if def.key == key {
+			return def.weights[v.Get(key)]
+		}
+	}
+	return 0
+}
+
+func (v *Vector) v2BaseScore() float64 {
+	w := func(key string) float64 { return v.weight(v2Metrics, key) }
+	impact := 10.41 * (1 - (1-w("C"))*(1-w("I"))*(1-w("A")))
+	exploitability := 20 * w("AV") * w("AC") * w("Au")
+	if impact == 0 {
+		return 0
+	}
+	score := (0.6*impact + 0.4*exploitability - 1.5) * 1.176
+	return math.Round(score*10) / 10
+}
+
+func (v *Vector) v3BaseScore() float64 {
+	w := func(key string) float64 { return v.weight(v3Metrics, key) }
+	changed := v.Get("S") == "C"
+
+	privileges := w("PR")
+	if changed {
+		switch v.Get("PR") {
+		case "L":
+			privileges = 0.68
+		case "H":
+			privileges = 0.5
+		}
+	}
+
+	iss := 1 - (1-w("C"))*(1-w("I"))*(1-w("A"))
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0
+	}
+	exploitability := 8.22 * w("AV") * w("AC") * privileges * w("UI")
+
+	score := impact + exploitability
+	if changed {
+		score *= 1.08
+	}
+	return v.roundUp(min(score, 10))
+}
+
+// roundUp rounds up to one decimal place. CVSS 3.1 defines the rounding on integers to avoid
+// floating point artefacts such as 4.000000001 becoming 4.1.
+func (v *Vector) roundUp(x float64) float64 {
+	if v.Version == Version30 {
+		return math.Ceil(x*10) / 10
+	}
+	n := int64(math.Round(x * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
+
+// Severity returns the qualitative rating of a base score: None, Low, Medium, High or Critical
+// for v3, and Low, Medium or High for v2
+func Severity(score float64, version Version) string {
+	if version == Version2 {
+		switch {
+		case score >= 7:
+			return "High"
+		case score >= 4:
+			return "Medium"
+		default:
+			return "Low"
+		}
+	}
+	switch {
+	case score >= 9:
+		return "Critical"
+	case score >= 7:
+		return "High"
+	case score >= 4:
+		return "Medium"
+	case score > 0:
+		return "Low"
+	default:
+		return "None"
+	}
+}
+
+// Score is a base score as reported with a finding, with its vector when one was reported
+type Score struct {
+	Version Version
+	Base    float64
+	Vector  string // As reported; may be empty
+}
+
+// Severity returns the qualitative rating of the score
+func (s Score) Severity() string {
+	return Severity(s.Base, s.Version)
+}
+
+// Parsed returns the parsed vector of the score, or nil if it has none or it is invalid
+func (s Score) Parsed() *Vector {
+	if s.Vector == "" {
+		return nil
+	}
+	vector, err := Parse(s.Vector)
+	if err != nil {
+		return nil
+	}
+	return vector
+}
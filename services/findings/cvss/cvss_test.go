@@ -0,0 +1,93 @@
+package cvss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAndScore(t *testing.T) {
+	tests := []struct {
+		vector  string
+		version Version
+		score   float64
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", Version31, 10.0},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Version31, 9.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:R/S:C/C:L/I:L/A:N", Version31, 5.4},
+		{"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", Version30, 1.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", Version31, 0},
+		{"AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H", Version31, 7.5},
+		{"CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O", Version31, 8.1},
+		{"AV:N/AC:L/Au:N/C:P/I:P/A:P", Version2, 7.5},
+		{"(AV:N/AC:M/Au:N/C:N/I:P/A:N)", Version2, 4.3},
+		{"AV:L/AC:L/Au:N/C:C/I:C/A:C", Version2, 7.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vector, func(t *testing.T) {
+			v, err := Parse(tt.vector)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if v.Version != tt.version {
+				t.Errorf("Version = %s, want %s", v.Version, tt.version)
+			}
+			if got := v.BaseScore(); got != tt.score {
+				t.Errorf("BaseScore() = %.1f, want %.1f", got, tt.score)
+			}
+		})
+	}
+}
+
+func TestParseMetrics(t *testing.T) {
+	v, err := Parse("AV:A/AC:H/PR:L/UI:R/S:C/C:H/I:L/A:N")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var keys []string
+	for _, m := range v.Metrics {
+		keys = append(keys, m.Key)
+	}
+	if got := strings.Join(keys, ","); got != "AV,AC,PR,UI,S,C,I,A" {
+		t.Errorf("Unexpected metric order %s", got)
+	}
+	if m := v.Metrics[0]; m.Name != "Attack Vector" || m.ValueName != "Adjacent" {
+		t.Errorf("Unexpected attack vector metric %+v", m)
+	}
+	if v.Get("S") != "C" || v.Get("Au") != "" {
+		t.Errorf("Unexpected Get results")
+	}
+	if got := v.String(); got != "CVSS:3.1/AV:A/AC:H/PR:L/UI:R/S:C/C:H/I:L/A:N" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, vector := range []string{
+		"",
+		"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",
+		"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"AV:N/AC:L/C:P/I:P/A:P",
+		"AV:N/AV:N/AC:L/Au:N/C:P/I:P/A:P",
+		"AV:N/AC",
+	} {
+		if _, err := Parse(vector); err == nil {
+			t.Errorf("Expected an error for %q", vector)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	if got := Severity(9.8, Version31); got != "Critical" {
+		t.Errorf("Severity(9.8, v3) = %s", got)
+	}
+	if got := Severity(9.8, Version2); got != "High" {
+		t.Errorf("Severity(9.8, v2) = %s", got)
+	}
+	if got := Severity(0, Version31); got != "None" {
+		t.Errorf("Severity(0, v3) = %s", got)
+	}
+	if got := (Score{Version: Version2, Base: 4.3}).Severity(); got != "Medium" {
+		t.Errorf("Score.Severity() = %s", got)
+	}
+}
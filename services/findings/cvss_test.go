@@ -0,0 +1,58 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/findings/cvss"
+)
+
+func TestCVSSScores(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"scan_type": "SCA",
+		"finding_details": {
+			"cve": {
+				"name": "CVE-2022-22965",
+				"cvss": 7.5,
+				"vector": "AV:N/AC:L/Au:N/C:P/I:P/A:P",
+				"cvss3": {"score": 9.8, "vector": "AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}
+			}
+		}
+	}`)
+
+	scores := finding.CVSSScores()
+	if len(scores) != 2 {
+		t.Fatalf("Expected v3 and v2 scores, got %+v", scores)
+	}
+	if s := scores[0]; s.Version != cvss.Version31 || s.Base != 9.8 || s.Severity() != "Critical" {
+		t.Errorf("Unexpected v3 score %+v", s)
+	}
+	if s := scores[1]; s.Version != cvss.Version2 || s.Base != 7.5 || s.Parsed() == nil {
+		t.Errorf("Unexpected v2 score %+v", s)
+	}
+	if best, ok := finding.CVSS(); !ok || best.Base != 9.8 {
+		t.Errorf("CVSS() = %+v, %v", best, ok)
+	}
+}
+
+func TestCVSSScoreFromVector(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"scan_type": "DYNAMIC",
+		"finding_details": {"cvss3": {"vector": "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H"}}
+	}`)
+
+	score, ok := finding.CVSS()
+	if !ok || score.Version != cvss.Version30 || score.Base != 7.5 {
+		t.Errorf("Expected a v3.0 score of 7.5 calculated from the vector, got %+v", score)
+	}
+}
+
+func TestNoCVSSScore(t *testing.T) {
+	finding := decodeFinding(t, `{"scan_type": "SCA", "finding_details": {"cve": {"name": "CVE-2021-1"}}}`)
+
+	if _, ok := finding.CVSS(); ok {
+		t.Error("Expected no CVSS score")
+	}
+	if got := finding.CVSSBase(); got != -1 {
+		t.Errorf("CVSSBase() = %v, want -1", got)
+	}
+}
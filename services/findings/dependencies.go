@@ -0,0 +1,161 @@
+package findings
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathSeparator splits a component path into the archives it is nested in. Paths use " > " or
+// "->" between dependencies, and "#zip:" or "!/" for a file inside an archive.
+var pathSeparator = regexp.MustCompile(`\s*(?:>|->|→|#zip:|!/)\s*`)
+
+// ComponentPaths returns the component paths of an SCA finding, each as the list of
+// dependencies from the outermost to the component itself
+func (f *Finding) ComponentPaths() [][]string {
+	details := f.Details()
+	if details == nil {
+		return nil
+	}
+	entries, ok := details["component_path"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var paths [][]string
+	for _, entry := range entries {
+		object, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		text, ok := object["path"].(string)
+		if !ok {
+			continue
+		}
+
+		var segments []string
+		for _, segment := range pathSeparator.Split(text, -1) {
+			// Archives are shown by file name, without their location in the parent
+			if name := path.Base(strings.ReplaceAll(strings.TrimSpace(segment), `\`, "/")); name != "" && name != "." && name != "/" {
+				segments = append(segments, name)
+			}
+		}
+		// Make sure the path ends with the component itself
+		if component := f.Component(); component != "" && (len(segments) == 0 || segments[len(segments)-1] != component) {
+			segments = append(segments, component)
+		}
+		if len(segments) > 0 {
+			paths = append(paths, segments)
+		}
+	}
+	return paths
+}
+
+// DependencyNode is a component in the dependency tree, annotated with the vulnerabilities
+// of the component and everything it pulls in
+type DependencyNode struct {
+	Name        string
+	Children    []*DependencyNode
+	Findings    []*Finding // Findings of this component itself
+	CVEs        []string   // Distinct CVEs of this component and its dependencies, sorted; "issue-<id>" without a CVE
+	MaxSeverity int        // Highest severity of this component and its dependencies
+
+	children map[string]*DependencyNode
+}
+
+// DependencyTree merges the component paths of SCA findings into a single tree
+type DependencyTree struct {
+	Roots []*DependencyNode
+}
+
+// BuildDependencyTree merges the component paths of SCA findings, from the outermost archive or
+// direct dependency down to the vulnerable component. Findings without a component path are
+// placed at the top level. Children are sorted by CVE count, most first.
+func BuildDependencyTree(list []Finding) *DependencyTree {
+	root := &DependencyNode{children: make(map[string]*DependencyNode)}
+
+	for i := range list {
+		f := &list[i]
+		if f.ScanType != ScanTypeSCA {
+			continue
+		}
+		paths := f.ComponentPaths()
+		if len(paths) == 0 && f.Component() != "" {
+			paths = [][]string{{f.Component()}}
+		}
+		for _, segments := range paths {
+			node := root
+			for _, name := range segments {
+				node = node.child(name)
+			}
+			node.Findings = append(node.Findings, f)
+		}
+	}
+
+	root.summarize()
+	return &DependencyTree{Roots: root.Children}
+}
+
+func (n *DependencyNode) child(name string) *DependencyNode {
+	if child, ok := n.children[name]; ok {
+		return child
+	}
+	child := &DependencyNode{Name: name, children: make(map[string]*DependencyNode)}
+	n.children[name] = child
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// summarize computes the CVEs and maximum severity of every node and sorts the children.
+// It returns the set of CVEs in the subtree.
+func (n *DependencyNode) summarize() map[string]bool {
+	cves := make(map[string]bool)
+	for _, f := range n.Findings {
+		cves[findingKey(f)] = true
+		n.MaxSeverity = max(n.MaxSeverity, f.Severity())
+	}
+	for _, child := range n.Children {
+		for cve := range child.summarize() {
+			cves[cve] = true
+		}
+		n.MaxSeverity = max(n.MaxSeverity, child.MaxSeverity)
+	}
+
+	n.CVEs = make([]string, 0, len(cves))
+	for cve := range cves {
+		n.CVEs = append(n.CVEs, cve)
+	}
+	sort.Strings(n.CVEs)
+
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if len(a.CVEs) != len(b.CVEs) {
+			return len(a.CVEs) > len(b.CVEs)
+		}
+		if a.MaxSeverity != b.MaxSeverity {
+			return a.MaxSeverity > b.MaxSeverity
+		}
+		return a.Name < b.Name
+	})
+	return cves
+}
+
+// findingKey identifies a vulnerability by CVE, falling back to the issue for findings without one
+func findingKey(f *Finding) string {
+	if cve := f.CVE(); cve != "" {
+		return cve
+	}
+	return "issue-" + strconv.FormatInt(f.IssueID, 10)
+}
+
+// DirectDependencies returns the direct dependencies of the application, most CVEs first.
+// When every path starts in the same archive without findings of its own (the scanned
+// application), its children are the direct dependencies; otherwise the top level is.
+func (t *DependencyTree) DirectDependencies() []*DependencyNode {
+	if len(t.Roots) == 1 && len(t.Roots[0].Findings) == 0 && len(t.Roots[0].Children) > 0 {
+		return t.Roots[0].Children
+	}
+	return t.Roots
+}
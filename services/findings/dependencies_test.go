@@ -0,0 +1,67 @@
+package findings
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestComponentPaths(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"scan_type": "SCA",
+		"finding_details": {
+			"component_filename": "log4j-core-2.14.1.jar",
+			"component_path": [
+				{"path": "app.war#zip:WEB-INF/lib/spring-boot.jar#zip:BOOT-INF/lib/log4j-core-2.14.1.jar"},
+				{"path": "lib > logging-bridge.jar"}
+			]
+		}
+	}`)
+
+	paths := finding.ComponentPaths()
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 paths, got %v", paths)
+	}
+	if want := []string{"app.war", "spring-boot.jar", "log4j-core-2.14.1.jar"}; !slices.Equal(paths[0], want) {
+		t.Errorf("First path = %v, want %v", paths[0], want)
+	}
+	if want := []string{"lib", "logging-bridge.jar", "log4j-core-2.14.1.jar"}; !slices.Equal(paths[1], want) {
+		t.Errorf("Second path = %v, want %v (component appended)", paths[1], want)
+	}
+}
+
+func TestBuildDependencyTree(t *testing.T) {
+	var list []Finding
+	data := `[
+		{"issue_id": 1, "scan_type": "SCA", "finding_details": {"severity": 5, "component_filename": "log4j-core.jar", "cve": {"name": "CVE-2021-44228"},
+			"component_path": [{"path": "app.war#zip:WEB-INF/lib/spring-boot.jar#zip:log4j-core.jar"}]}},
+		{"issue_id": 2, "scan_type": "SCA", "finding_details": {"severity": 3, "component_filename": "log4j-core.jar", "cve": {"name": "CVE-2021-45046"},
+			"component_path": [{"path": "app.war#zip:WEB-INF/lib/spring-boot.jar#zip:log4j-core.jar"}]}},
+		{"issue_id": 3, "scan_type": "SCA", "finding_details": {"severity": 4, "component_filename": "jackson.jar", "cve": {"name": "CVE-2020-1"},
+			"component_path": [{"path": "app.war#zip:WEB-INF/lib/spring-boot.jar#zip:jackson.jar"}, {"path": "app.war#zip:WEB-INF/lib/jackson.jar"}]}},
+		{"issue_id": 4, "scan_type": "STATIC"}
+	]`
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+
+	tree := BuildDependencyTree(list)
+	if len(tree.Roots) != 1 || tree.Roots[0].Name != "app.war" {
+		t.Fatalf("Expected a single app.war root, got %d roots", len(tree.Roots))
+	}
+
+	direct := tree.DirectDependencies()
+	if len(direct) != 2 {
+		t.Fatalf("Expected 2 direct dependencies, got %d", len(direct))
+	}
+	spring := direct[0]
+	if spring.Name != "spring-boot.jar" || len(spring.CVEs) != 3 || spring.MaxSeverity != 5 {
+		t.Errorf("Expected spring-boot.jar first with 3 CVEs and severity 5, got %s with %v, %d", spring.Name, spring.CVEs, spring.MaxSeverity)
+	}
+	if direct[1].Name != "jackson.jar" || len(direct[1].CVEs) != 1 || len(direct[1].Findings) != 1 {
+		t.Errorf("Expected jackson.jar as a direct dependency with its own finding, got %+v", direct[1])
+	}
+	if log4j := spring.Children[0]; log4j.Name != "log4j-core.jar" || len(log4j.Findings) != 2 {
+		t.Errorf("Expected log4j-core.jar with 2 findings under spring-boot.jar, got %s", log4j.Name)
+	}
+}
@@ -0,0 +1,192 @@
+package findings
+
+import (
+	"fmt"
+	"path"
+)
+
+// Details returns the finding details as a map, or nil if they are missing or not an object.
+// The shape of the map depends on the scan type (static, dynamic, SCA).
+func (f *Finding) Details() map[string]interface{} {
+	details, ok := f.FindingDetails.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return details
+}
+
+// detailString returns a string value from the finding details
+func (f *Finding) detailString(key string) string {
+	if value, ok := f.Details()[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// detailInt returns a numeric value from the finding details
+func (f *Finding) detailInt(key string) int {
+	if value, ok := f.Details()[key].(float64); ok {
+		return int(value)
+	}
+	return 0
+}
+
+// detailObjectString returns a string value from a nested object in the finding details
+func (f *Finding) detailObjectString(object, key string) string {
+	if nested, ok := f.Details()[object].(map[string]interface{}); ok {
+		if value, ok := nested[key].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// Severity returns the finding severity (0-5), or 0 if unknown
+func (f *Finding) Severity() int {
+	return f.detailInt("severity")
+}
+
+// CWEID returns the CWE identifier, or 0 if unknown
+func (f *Finding) CWEID() int {
+	if cwe, ok := f.Details()["cwe"].(map[string]interface{}); ok {
+		if id, ok := cwe["id"].(float64); ok {
+			return int(id)
+		}
+	}
+	return 0
+}
+
+// CWEName returns the CWE name, e.g. "Improper Neutralization of Special Elements used in an SQL Command"
+func (f *Finding) CWEName() string {
+	return f.detailObjectString("cwe", "name")
+}
+
+// CWELabel returns the CWE as "CWE-<id> <name>", or an empty string if unknown
+func (f *Finding) CWELabel() string {
+	id := f.CWEID()
+	if id == 0 {
+		return ""
+	}
+	if name := f.CWEName(); name != "" {
+		return fmt.Sprintf("CWE-%d %s", id, name)
+	}
+	return fmt.Sprintf("CWE-%d", id)
+}
+
+// FilePath returns the source file path of a static finding, falling back to the file name
+func (f *Finding) FilePath() string {
+	if filePath := f.detailString("file_path"); filePath != "" {
+		return filePath
+	}
+	return f.detailString("file_name")
+}
+
+// FileName returns the base name of the source file of a static finding
+func (f *Finding) FileName() string {
+	if fileName := f.detailString("file_name"); fileName != "" {
+		return fileName
+	}
+	if filePath := f.FilePath(); filePath != "" {
+		return path.Base(filePath)
+	}
+	return ""
+}
+
+// FileLine returns the source line number of a static finding, or 0 if unknown
+func (f *Finding) FileLine() int {
+	return f.detailInt("file_line_number")
+}
+
+// Module returns the module of a static finding
+func (f *Finding) Module() string {
+	return f.detailString("module")
+}
+
+// Procedure returns the procedure (function) of a static finding
+func (f *Finding) Procedure() string {
+	return f.detailString("procedure")
+}
+
+// AttackVector returns the attack vector of a static finding
+func (f *Finding) AttackVector() string {
+	return f.detailString("attack_vector")
+}
+
+// URL returns the URL of a dynamic finding
+func (f *Finding) URL() string {
+	return f.detailString("url")
+}
+
+// VulnerableParameter returns the vulnerable parameter of a dynamic finding
+func (f *Finding) VulnerableParameter() string {
+	return f.detailString("vulnerable_parameter")
+}
+
+// Component returns the component file name of an SCA finding
+func (f *Finding) Component() string {
+	return f.detailString("component_filename")
+}
+
+// ComponentVersion returns the component version of an SCA finding
+func (f *Finding) ComponentVersion() string {
+	return f.detailString("version")
+}
+
+// CVE returns the CVE identifier of an SCA finding
+func (f *Finding) CVE() string {
+	return f.detailObjectString("cve", "name")
+}
+
+// CVEHref returns the link to the CVE details of an SCA finding
+func (f *Finding) CVEHref() string {
+	return f.detailObjectString("cve", "href")
+}
+
+// ComponentID returns the Veracode identifier of an SCA finding's component
+func (f *Finding) ComponentID() string {
+	return f.detailString("component_id")
+}
+
+// Language returns the language of an SCA finding's component, e.g. JAVA
+func (f *Finding) Language() string {
+	return f.detailString("language")
+}
+
+// SearchFields returns the text fields used to search for a finding
+func (f *Finding) SearchFields() []string {
+	fields := []string{
+		f.CWELabel(),
+		f.FilePath(),
+		f.Module(),
+		f.Procedure(),
+		f.Component(),
+		f.CVE(),
+		f.URL(),
+	}
+
+	nonEmpty := fields[:0]
+	for _, field := range fields {
+		if field != "" {
+			nonEmpty = append(nonEmpty, field)
+		}
+	}
+	return nonEmpty
+}
+
+// Status returns the finding status (OPEN, CLOSED, REOPENED), or an empty string if unknown
+func (f *Finding) Status() Status {
+	if f.FindingStatus == nil {
+		return ""
+	}
+	return f.FindingStatus.Status
+}
+
+// IsNew reports whether the finding was first found in the latest scan
+func (f *Finding) IsNew() bool {
+	return f.FindingStatus != nil && f.FindingStatus.New
+}
+
+// IsMitigated reports whether the finding has an approved mitigation
+func (f *Finding) IsMitigated() bool {
+	return f.FindingStatus != nil && f.FindingStatus.ResolutionStatus == ResolutionApproved
+}
@@ -0,0 +1,97 @@
+package findings
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeFinding(t *testing.T, data string) *Finding {
+	t.Helper()
+	var finding Finding
+	if err := json.Unmarshal([]byte(data), &finding); err != nil {
+		t.Fatalf("Failed to decode finding: %v", err)
+	}
+	return &finding
+}
+
+func TestStaticFindingDetails(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"issue_id": 42,
+		"scan_type": "STATIC",
+		"finding_details": {
+			"severity": 4,
+			"cwe": {"id": 89, "name": "SQL Injection"},
+			"file_path": "src/main/java/com/example/OrderController.java",
+			"file_line_number": 120,
+			"module": "app.war",
+			"procedure": "com.example.OrderController.find",
+			"attack_vector": "java.sql.Statement.executeQuery"
+		}
+	}`)
+
+	if got := finding.Severity(); got != 4 {
+		t.Errorf("Severity() = %d, want 4", got)
+	}
+	if got := finding.CWEID(); got != 89 {
+		t.Errorf("CWEID() = %d, want 89", got)
+	}
+	if got := finding.CWELabel(); got != "CWE-89 SQL Injection" {
+		t.Errorf("CWELabel() = %q", got)
+	}
+	if got := finding.FileName(); got != "OrderController.java" {
+		t.Errorf("FileName() = %q, want OrderController.java", got)
+	}
+	if got := finding.FileLine(); got != 120 {
+		t.Errorf("FileLine() = %d, want 120", got)
+	}
+	if got := finding.Module(); got != "app.war" {
+		t.Errorf("Module() = %q", got)
+	}
+	if got := finding.AttackVector(); got != "java.sql.Statement.executeQuery" {
+		t.Errorf("AttackVector() = %q", got)
+	}
+
+	fields := finding.SearchFields()
+	if len(fields) != 4 {
+		t.Errorf("Expected 4 search fields (CWE, file, module, procedure), got %d: %v", len(fields), fields)
+	}
+}
+
+func TestSCAFindingDetails(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"scan_type": "SCA",
+		"finding_details": {
+			"severity": 5,
+			"component_filename": "log4j-core-2.14.1.jar",
+			"version": "2.14.1",
+			"cve": {"name": "CVE-2021-44228", "href": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}
+		}
+	}`)
+
+	if got := finding.Component(); got != "log4j-core-2.14.1.jar" {
+		t.Errorf("Component() = %q", got)
+	}
+	if got := finding.ComponentVersion(); got != "2.14.1" {
+		t.Errorf("ComponentVersion() = %q", got)
+	}
+	if got := finding.CVE(); got != "CVE-2021-44228" {
+		t.Errorf("CVE() = %q", got)
+	}
+	if got := finding.CWELabel(); got != "" {
+		t.Errorf("CWELabel() = %q, want empty", got)
+	}
+}
+
+func TestMissingFindingDetails(t *testing.T) {
+	finding := &Finding{IssueID: 1}
+
+	if finding.Details() != nil {
+		t.Error("Expected nil details")
+	}
+	if finding.Severity() != 0 || finding.CWEID() != 0 || finding.FilePath() != "" {
+		t.Error("Expected zero values for missing details")
+	}
+	if len(finding.SearchFields()) != 0 {
+		t.Errorf("Expected no search fields, got %v", finding.SearchFields())
+	}
+}
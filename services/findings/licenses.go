@@ -0,0 +1,94 @@
+package findings
+
+import (
+	"strings"
+)
+
+// License is a license of an SCA component
+type License struct {
+	ID         string // SPDX identifier, e.g. Apache-2.0
+	RiskRating string // As reported by the API
+}
+
+// LicenseRisk is a normalised license risk level
+type LicenseRisk int
+
+// License risk levels, ordered from least to most risky
+const (
+	LicenseRiskUnknown LicenseRisk = iota
+	LicenseRiskLow
+	LicenseRiskMedium
+	LicenseRiskHigh
+)
+
+// String returns the name of the risk level
+func (r LicenseRisk) String() string {
+	switch r {
+	case LicenseRiskLow:
+		return "Low"
+	case LicenseRiskMedium:
+		return "Medium"
+	case LicenseRiskHigh:
+		return "High"
+	default:
+		return "Unknown"
+	}
+}
+
+// Risk normalises the risk rating, which the API reports either by name or as a number
+// from 1 (low) to 4 (high)
+func (l License) Risk() LicenseRisk {
+	switch strings.ToUpper(strings.TrimSpace(l.RiskRating)) {
+	case "LOW", "1":
+		return LicenseRiskLow
+	case "MEDIUM", "2":
+		return LicenseRiskMedium
+	case "HIGH", "3", "4":
+		return LicenseRiskHigh
+	default:
+		return LicenseRiskUnknown
+	}
+}
+
+// copyleftPrefixes are the SPDX identifier prefixes of copyleft licenses
+var copyleftPrefixes = []string{
+	"GPL", "AGPL", "LGPL", "MPL", "EPL", "CDDL", "EUPL", "OSL", "CPAL", "SSPL", "CECILL", "CC-BY-SA", "APSL", "MS-RL",
+}
+
+// IsCopyleft reports whether the license requires derived works to be shared under the same terms
+func (l License) IsCopyleft() bool {
+	id := strings.ToUpper(l.ID)
+	for _, prefix := range copyleftPrefixes {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Licenses returns the licenses of an SCA finding's component
+func (f *Finding) Licenses() []License {
+	details := f.Details()
+	if details == nil {
+		return nil
+	}
+	entries, ok := details["licenses"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var licenses []License
+	for _, entry := range entries {
+		object, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := object["license_id"].(string)
+		if id == "" {
+			continue
+		}
+		risk, _ := object["risk_rating"].(string)
+		licenses = append(licenses, License{ID: id, RiskRating: risk})
+	}
+	return licenses
+}
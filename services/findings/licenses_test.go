@@ -0,0 +1,30 @@
+package findings
+
+import "testing"
+
+func TestLicenses(t *testing.T) {
+	finding := decodeFinding(t, `{
+		"scan_type": "SCA",
+		"finding_details": {
+			"licenses": [
+				{"license_id": "GPL-2.0-only", "risk_rating": "4"},
+				{"license_id": "MIT", "risk_rating": "Low"},
+				{"risk_rating": "2"}
+			]
+		}
+	}`)
+
+	licenses := finding.Licenses()
+	if len(licenses) != 2 {
+		t.Fatalf("Expected 2 licenses with IDs, got %v", licenses)
+	}
+	if licenses[0].Risk() != LicenseRiskHigh || !licenses[0].IsCopyleft() {
+		t.Errorf("Expected GPL-2.0-only to be high risk copyleft, got %s, %v", licenses[0].Risk(), licenses[0].IsCopyleft())
+	}
+	if licenses[1].Risk() != LicenseRiskLow || licenses[1].IsCopyleft() {
+		t.Errorf("Expected MIT to be low risk permissive, got %s, %v", licenses[1].Risk(), licenses[1].IsCopyleft())
+	}
+	if got := (License{ID: "X", RiskRating: "unassessed"}).Risk(); got != LicenseRiskUnknown {
+		t.Errorf("Expected an unrecognised rating to be Unknown, got %s", got)
+	}
+}
@@ -0,0 +1,4 @@
+// Package query implements a small filter expression language for findings.
+// Expressions compare finding fields with values and combine them with and, or and not,
+// for example: severity>=4 and cwe in (89,79) and not mitigated and file~"src/api/".
+package query
@@ -0,0 +1,33 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error describes a problem parsing a query, with the position where it was found
+type Error struct {
+	Input   string
+	Pos     int // Byte offset in the input
+	Message string
+}
+
+func newError(input string, pos int, format string, args ...interface{}) *Error {
+	return &Error{Input: input, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Column returns the 1-based character column of the error
+func (e *Error) Column() int {
+	return utf8.RuneCountInString(e.Input[:e.Pos]) + 1
+}
+
+// Error returns the message with the column where the problem was found
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at column %d)", e.Message, e.Column())
+}
+
+// Caret returns the query with a caret on the following line pointing at the problem
+func (e *Error) Caret() string {
+	return e.Input + "\n" + strings.Repeat(" ", e.Column()-1) + "^"
+}
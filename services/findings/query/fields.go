@@ -0,0 +1,202 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// Kind is the value type of a query field
+type Kind int
+
+// Field kinds
+const (
+	KindInt Kind = iota
+	KindString
+	KindBool
+	KindDate
+	KindDecimal
+)
+
+// String returns the name of the kind for help and error messages
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "number"
+	case KindString:
+		return "text"
+	case KindBool:
+		return "boolean"
+	case KindDate:
+		return "date"
+	case KindDecimal:
+		return "decimal number"
+	default:
+		return "unknown"
+	}
+}
+
+// Field describes a finding attribute that can be used in a query
+type Field struct {
+	Name        string
+	Aliases     []string
+	Kind        Kind
+	Description string
+
+	intValue     func(f *findings.Finding) (int, bool)
+	decimalValue func(f *findings.Finding) (float64, bool)
+	stringValue  func(f *findings.Finding) string
+	boolValue    func(f *findings.Finding) bool
+	dateValue    func(f *findings.Finding) *time.Time
+}
+
+// dateFormat is the format of date values in queries
+const dateFormat = "2006-01-02"
+
+// knownInt wraps an int accessor whose zero value means unknown
+func knownInt(value func(f *findings.Finding) int) func(f *findings.Finding) (int, bool) {
+	return func(f *findings.Finding) (int, bool) {
+		v := value(f)
+		return v, v != 0
+	}
+}
+
+// fields lists all queryable finding attributes
+var fields = []*Field{
+	{Name: "id", Kind: KindInt, Description: "Issue ID",
+		intValue: func(f *findings.Finding) (int, bool) { return int(f.IssueID), true }},
+	{Name: "severity", Aliases: []string{"sev"}, Kind: KindInt, Description: "Severity, 0 (informational) to 5 (very high)",
+		intValue: func(f *findings.Finding) (int, bool) { return f.Severity(), f.Details() != nil }},
+	{Name: "cwe", Kind: KindInt, Description: "CWE ID; CWE-89 and 89 are equivalent",
+		intValue: knownInt((*findings.Finding).CWEID)},
+	{Name: "cvss", Kind: KindDecimal, Description: "CVSS base score, v3 where reported, otherwise v2 (SCA, dynamic)",
+		decimalValue: func(f *findings.Finding) (float64, bool) {
+			score, ok := f.CVSS()
+			return score.Base, ok
+		}},
+	{Name: "cvss_vector", Kind: KindString, Description: "CVSS vector, e.g. cvss_vector~\"AV:N\"",
+		stringValue: func(f *findings.Finding) string {
+			score, _ := f.CVSS()
+			return score.Vector
+		}},
+	{Name: "line", Kind: KindInt, Description: "Source line number (static)",
+		intValue: knownInt((*findings.Finding).FileLine)},
+	{Name: "status", Kind: KindString, Description: "Finding status: OPEN, CLOSED or REOPENED",
+		stringValue: func(f *findings.Finding) string { return string(f.Status()) }},
+	{Name: "resolution", Kind: KindString, Description: "Resolution status, e.g. APPROVED, PROPOSED, REJECTED",
+		stringValue: func(f *findings.Finding) string {
+			if f.FindingStatus == nil {
+				return ""
+			}
+			return string(f.FindingStatus.ResolutionStatus)
+		}},
+	{Name: "scan_type", Aliases: []string{"type"}, Kind: KindString, Description: "Scan type: STATIC, DYNAMIC, SCA or MANUAL",
+		stringValue: func(f *findings.Finding) string { return string(f.ScanType) }},
+	{Name: "cwe_name", Kind: KindString, Description: "CWE name",
+		stringValue: (*findings.Finding).CWEName},
+	{Name: "file", Aliases: []string{"path"}, Kind: KindString, Description: "Source file path (static)",
+		stringValue: (*findings.Finding).FilePath},
+	{Name: "module", Kind: KindString, Description: "Module (static)",
+		stringValue: (*findings.Finding).Module},
+	{Name: "procedure", Kind: KindString, Description: "Procedure or function (static)",
+		stringValue: (*findings.Finding).Procedure},
+	{Name: "attack_vector", Kind: KindString, Description: "Attack vector (static)",
+		stringValue: (*findings.Finding).AttackVector},
+	{Name: "url", Kind: KindString, Description: "URL (dynamic)",
+		stringValue: (*findings.Finding).URL},
+	{Name: "parameter", Aliases: []string{"param"}, Kind: KindString, Description: "Vulnerable parameter (dynamic)",
+		stringValue: (*findings.Finding).VulnerableParameter},
+	{Name: "component", Kind: KindString, Description: "Component file name (SCA)",
+		stringValue: (*findings.Finding).Component},
+	{Name: "version", Kind: KindString, Description: "Component version (SCA)",
+		stringValue: (*findings.Finding).ComponentVersion},
+	{Name: "cve", Kind: KindString, Description: "CVE identifier (SCA)",
+		stringValue: (*findings.Finding).CVE},
+	{Name: "description", Aliases: []string{"desc"}, Kind: KindString, Description: "Finding description",
+		stringValue: func(f *findings.Finding) string { return f.Description }},
+	{Name: "new", Kind: KindBool, Description: "Found in the latest scan",
+		boolValue: (*findings.Finding).IsNew},
+	{Name: "mitigated", Kind: KindBool, Description: "Has an approved mitigation",
+		boolValue: (*findings.Finding).IsMitigated},
+	{Name: "violates_policy", Aliases: []string{"policy"}, Kind: KindBool, Description: "Violates the application's policy",
+		boolValue: func(f *findings.Finding) bool { return f.ViolatesPolicy }},
+	{Name: "first_found", Kind: KindDate, Description: "Date first found (yyyy-mm-dd)",
+		dateValue: func(f *findings.Finding) *time.Time {
+			if f.FindingStatus == nil {
+				return nil
+			}
+			return f.FindingStatus.FirstFoundDate
+		}},
+	{Name: "last_seen", Kind: KindDate, Description: "Date last seen (yyyy-mm-dd)",
+		dateValue: func(f *findings.Finding) *time.Time {
+			if f.FindingStatus == nil {
+				return nil
+			}
+			return f.FindingStatus.LastSeenDate
+		}},
+}
+
+// Fields returns the queryable fields sorted by name, for help output
+func Fields() []Field {
+	result := make([]Field, len(fields))
+	for i, field := range fields {
+		result[i] = *field
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// lookupField finds a field by name or alias (case-insensitive)
+func lookupField(name string) *Field {
+	name = strings.ToLower(name)
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+		for _, alias := range field.Aliases {
+			if alias == name {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// suggestField returns the closest field name to an unknown name, or an empty string
+func suggestField(name string) string {
+	name = strings.ToLower(name)
+	best := ""
+	bestDistance := 3 // Only suggest names within two edits
+	for _, field := range fields {
+		for _, candidate := range append([]string{field.Name}, field.Aliases...) {
+			if d := editDistance(name, candidate); d < bestDistance {
+				best, bestDistance = field.Name, d
+			}
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr := make([]int, len(br)+1)
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the type of a lexical token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a lexical token with its position in the input
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // Byte offset in the input
+}
+
+// describe returns a human-readable description of a token for error messages
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return "string \"" + t.text + "\""
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// operators lists the comparison operators, longest first so that ">=" wins over ">"
+var operators = []string{">=", "<=", "!=", "!~", "==", "=", ">", "<", "~"}
+
+// isWordRune reports whether r can appear in an unquoted word such as OPEN, log4j-core or 2024-01-31
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:*@+", r)
+}
+
+// lex splits the input into tokens
+func lex(input string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(input); {
+		r := rune(input[pos])
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			pos++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			pos++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			pos++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			pos++
+		case r == '"' || r == '\'':
+			text, next, err := lexString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			pos = next
+		case strings.ContainsRune("=!<>~", r):
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(input[pos:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, newError(input, pos, "unexpected character '%c'; did you mean '!=' or '!~'?", r)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		default:
+			start := pos
+			for pos < len(input) {
+				wr, size := utf8.DecodeRuneInString(input[pos:])
+				if !isWordRune(wr) {
+					break
+				}
+				pos += size
+			}
+			if pos == start {
+				wr, _ := utf8.DecodeRuneInString(input[pos:])
+				return nil, newError(input, pos, "unexpected character '%c'", wr)
+			}
+			text := input[start:pos]
+			kind := tokenWord
+			if isNumber(text) {
+				kind = tokenNumber
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: start})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// lexString reads a quoted string starting at pos, handling backslash escapes
+func lexString(input string, pos int) (string, int, error) {
+	quote := input[pos]
+	var sb strings.Builder
+
+	for i := pos + 1; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '\\' && i+1 < len(input):
+			i++
+			sb.WriteByte(input[i])
+		case c == quote:
+			return sb.String(), i + 1, nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", 0, newError(input, pos, "unterminated string; add a closing %c", quote)
+}
+
+// isNumber reports whether text is an optionally signed integer
+func isNumber(text string) bool {
+	digits := strings.TrimPrefix(text, "-")
+	if digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,236 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parser is a recursive descent parser for the query grammar:
+//
+//	expr       = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | primary
+//	primary    = "(" expr ")" | comparison
+//	comparison = field [ op value | "in" "(" value { "," value } ")" ]
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword reports whether a token is the given unquoted keyword (case-insensitive)
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *parser) errorAt(tok token, format string, args ...interface{}) *Error {
+	return newError(p.input, tok.pos, format, args...)
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if isKeyword(p.peek(), "not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+
+	switch {
+	case tok.kind == tokenLParen:
+		p.next()
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			opening := newError(p.input, tok.pos, "")
+			return nil, p.errorAt(closing, "expected ')' to close the '(' at column %d, found %s", opening.Column(), closing.describe())
+		}
+		return inner, nil
+	case tok.kind == tokenEOF:
+		return nil, p.errorAt(tok, "expected a condition such as severity>=4, found end of input")
+	case tok.kind != tokenWord || isKeyword(tok, "and") || isKeyword(tok, "or") || isKeyword(tok, "in"):
+		return nil, p.errorAt(tok, "expected a field name, found %s", tok.describe())
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	fieldTok := p.next()
+	field := lookupField(fieldTok.text)
+	if field == nil {
+		if suggestion := suggestField(fieldTok.text); suggestion != "" {
+			return nil, p.errorAt(fieldTok, "unknown field %q; did you mean %q?", fieldTok.text, suggestion)
+		}
+		return nil, p.errorAt(fieldTok, "unknown field %q", fieldTok.text)
+	}
+
+	opTok := p.peek()
+	switch {
+	case isKeyword(opTok, "in"):
+		p.next()
+		return p.parseIn(field)
+	case opTok.kind == tokenOperator:
+		p.next()
+		return p.parseOperator(field, opTok)
+	case field.Kind == KindBool:
+		// A bare boolean field such as "mitigated" means "mitigated=true"
+		return compareNode{field: field, op: "=", values: []value{{b: true}}}, nil
+	default:
+		return nil, p.errorAt(opTok, "expected an operator after %q (one of = != > >= < <= ~ !~ in), found %s", field.Name, opTok.describe())
+	}
+}
+
+func (p *parser) parseOperator(field *Field, opTok token) (node, error) {
+	op := opTok.text
+	if op == "==" {
+		op = "="
+	}
+
+	if err := p.checkOperator(field, opTok, op); err != nil {
+		return nil, err
+	}
+
+	valueTok := p.next()
+	val, err := p.parseValue(field, valueTok)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareNode{field: field, op: op, values: []value{val}}, nil
+}
+
+// checkOperator verifies that an operator is valid for the field's kind
+func (p *parser) checkOperator(field *Field, opTok token, op string) error {
+	switch op {
+	case "~", "!~":
+		if field.Kind != KindString {
+			return p.errorAt(opTok, "'%s' only applies to text fields; %q is a %s", op, field.Name, field.Kind)
+		}
+	case ">", ">=", "<", "<=":
+		if field.Kind == KindString || field.Kind == KindBool {
+			return p.errorAt(opTok, "'%s' only applies to number and date fields; %q is %s, use = or ~", op, field.Name, field.Kind)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseIn(field *Field) (node, error) {
+	open := p.next()
+	if open.kind != tokenLParen {
+		return nil, p.errorAt(open, "expected '(' after 'in', e.g. %s in (a, b)", field.Name)
+	}
+
+	var values []value
+	for {
+		valueTok := p.next()
+		val, err := p.parseValue(field, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+
+		sep := p.next()
+		if sep.kind == tokenRParen {
+			break
+		}
+		if sep.kind != tokenComma {
+			return nil, p.errorAt(sep, "expected ',' or ')' in the list for %q, found %s", field.Name, sep.describe())
+		}
+	}
+
+	return compareNode{field: field, op: "in", values: values}, nil
+}
+
+// parseValue converts a token into a value of the field's kind
+func (p *parser) parseValue(field *Field, tok token) (value, error) {
+	if tok.kind != tokenWord && tok.kind != tokenNumber && tok.kind != tokenString {
+		return value{}, p.errorAt(tok, "expected a value for %q, found %s", field.Name, tok.describe())
+	}
+
+	switch field.Kind {
+	case KindInt:
+		text := tok.text
+		if field.Name == "cwe" {
+			text = strings.TrimPrefix(strings.ToLower(text), "cwe-")
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return value{}, p.errorAt(tok, "%q expects a number, found %s", field.Name, tok.describe())
+		}
+		return value{n: n}, nil
+	case KindDecimal:
+		d, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return value{}, p.errorAt(tok, "%q expects a number like 7.5, found %s", field.Name, tok.describe())
+		}
+		return value{d: d}, nil
+	case KindBool:
+		b, err := strconv.ParseBool(strings.ToLower(tok.text))
+		if err != nil {
+			return value{}, p.errorAt(tok, "%q expects true or false, found %s", field.Name, tok.describe())
+		}
+		return value{b: b}, nil
+	case KindDate:
+		t, err := time.Parse(dateFormat, tok.text)
+		if err != nil {
+			return value{}, p.errorAt(tok, "%q expects a date like 2024-01-31, found %s", field.Name, tok.describe())
+		}
+		return value{t: t}, nil
+	default:
+		return value{s: strings.ToLower(tok.text)}, nil
+	}
+}
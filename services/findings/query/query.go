@@ -0,0 +1,170 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// Query is a parsed filter expression that can be evaluated against findings
+type Query struct {
+	source string
+	root   node
+}
+
+// Parse parses a filter expression such as
+//
+//	severity>=4 and cwe in (89,79) and status=OPEN and not mitigated and file~"src/api/"
+//
+// Parse errors are returned as *Error, which reports the position of the problem.
+func Parse(input string) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{input: input, tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, newError(input, 0, "empty query")
+	}
+
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorAt(tok, "unexpected %s; combine conditions with 'and' or 'or'", tok.describe())
+	}
+
+	return &Query{source: strings.TrimSpace(input), root: root}, nil
+}
+
+// String returns the query as it was written
+func (q *Query) String() string {
+	return q.source
+}
+
+// Match reports whether the finding satisfies the query
+func (q *Query) Match(f *findings.Finding) bool {
+	return q.root.eval(f)
+}
+
+// Filter returns the findings that satisfy the query
+func (q *Query) Filter(list []findings.Finding) []findings.Finding {
+	var matched []findings.Finding
+	for i := range list {
+		if q.Match(&list[i]) {
+			matched = append(matched, list[i])
+		}
+	}
+	return matched
+}
+
+// node is an element of the parsed expression tree
+type node interface {
+	eval(f *findings.Finding) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(f *findings.Finding) bool { return n.left.eval(f) && n.right.eval(f) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(f *findings.Finding) bool { return n.left.eval(f) || n.right.eval(f) }
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(f *findings.Finding) bool { return !n.operand.eval(f) }
+
+// value is a literal in a comparison; only the member matching the field's kind is set
+type value struct {
+	n int
+	d float64
+	s string // Lower-cased
+	b bool
+	t time.Time
+}
+
+// compareNode compares a field with one value, or with a list of values for "in"
+type compareNode struct {
+	field  *Field
+	op     string
+	values []value
+}
+
+func (n compareNode) eval(f *findings.Finding) bool {
+	if n.op == "in" {
+		for _, v := range n.values {
+			if n.compare(f, "=", v) {
+				return true
+			}
+		}
+		return false
+	}
+	return n.compare(f, n.op, n.values[0])
+}
+
+// compare evaluates a single comparison. Findings with an unknown value for a number
+// or date field never match, so "cwe!=89" does not select SCA findings that have no CWE.
+func (n compareNode) compare(f *findings.Finding, op string, v value) bool {
+	switch n.field.Kind {
+	case KindInt:
+		actual, ok := n.field.intValue(f)
+		if !ok {
+			return false
+		}
+		return compareOrdered(actual, v.n, op)
+	case KindDecimal:
+		actual, ok := n.field.decimalValue(f)
+		if !ok {
+			return false
+		}
+		return compareOrdered(actual, v.d, op)
+	case KindDate:
+		actual := n.field.dateValue(f)
+		if actual == nil {
+			return false
+		}
+		// Compare whole days so that first_found=2024-01-31 matches any time that day
+		day := time.Date(actual.Year(), actual.Month(), actual.Day(), 0, 0, 0, 0, time.UTC)
+		return compareOrdered(day.Unix(), v.t.Unix(), op)
+	case KindBool:
+		actual := n.field.boolValue(f)
+		if op == "!=" {
+			return actual != v.b
+		}
+		return actual == v.b
+	default:
+		actual := strings.ToLower(n.field.stringValue(f))
+		switch op {
+		case "~":
+			return strings.Contains(actual, v.s)
+		case "!~":
+			return !strings.Contains(actual, v.s)
+		case "!=":
+			return actual != v.s
+		default:
+			return actual == v.s
+		}
+	}
+}
+
+func compareOrdered[T int | int64 | float64](actual, expected T, op string) bool {
+	switch op {
+	case "!=":
+		return actual != expected
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	case "<":
+		return actual < expected
+	case "<=":
+		return actual <= expected
+	default:
+		return actual == expected
+	}
+}
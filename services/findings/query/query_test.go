@@ -0,0 +1,176 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+func testFindings(t *testing.T) []findings.Finding {
+	t.Helper()
+	data := `[
+		{
+			"issue_id": 1,
+			"scan_type": "STATIC",
+			"description": "SQL injection in order lookup",
+			"violates_policy": true,
+			"finding_status": {"status": "OPEN", "new": true, "first_found_date": "2024-03-10T12:00:00Z"},
+			"finding_details": {
+				"severity": 4,
+				"cwe": {"id": 89, "name": "SQL Injection"},
+				"file_path": "src/api/OrderController.java",
+				"file_line_number": 42,
+				"module": "app.war"
+			}
+		},
+		{
+			"issue_id": 2,
+			"scan_type": "STATIC",
+			"finding_status": {"status": "OPEN", "resolution_status": "APPROVED", "first_found_date": "2023-11-01T08:00:00Z"},
+			"finding_details": {
+				"severity": 3,
+				"cwe": {"id": 79, "name": "Cross-site Scripting"},
+				"file_path": "src/web/view.jsp",
+				"module": "app.war"
+			}
+		},
+		{
+			"issue_id": 3,
+			"scan_type": "SCA",
+			"violates_policy": true,
+			"finding_status": {"status": "CLOSED"},
+			"finding_details": {
+				"severity": 5,
+				"component_filename": "log4j-core-2.14.1.jar",
+				"version": "2.14.1",
+				"cve": {"name": "CVE-2021-44228", "cvss3": {"score": 10.0, "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}}
+			}
+		}
+	]`
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode test findings: %v", err)
+	}
+	return list
+}
+
+func matchingIDs(t *testing.T, expr string) []int64 {
+	t.Helper()
+	q, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", expr, err)
+	}
+	var ids []int64
+	for _, f := range q.Filter(testFindings(t)) {
+		ids = append(ids, f.IssueID)
+	}
+	return ids
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []int64
+	}{
+		{`severity>=4`, []int64{1, 3}},
+		{`sev = 3`, []int64{2}},
+		{`cwe in (89, 79)`, []int64{1, 2}},
+		{`cwe=CWE-89`, []int64{1}},
+		{`cwe!=89`, []int64{2}},
+		{`status=open`, []int64{1, 2}},
+		{`not mitigated`, []int64{1, 3}},
+		{`mitigated=true`, []int64{2}},
+		{`new`, []int64{1}},
+		{`file~"src/api/"`, []int64{1}},
+		{`file!~"src/api/"`, []int64{2, 3}},
+		{`component~log4j or cve=CVE-2021-44228`, []int64{3}},
+		{`policy and not (scan_type=SCA)`, []int64{1}},
+		{`severity>=4 and cwe in (89,79) and status=OPEN and not mitigated and file~"src/api/"`, []int64{1}},
+		{`first_found>=2024-01-01`, []int64{1}},
+		{`first_found=2023-11-01`, []int64{2}},
+		{`description~'order'`, []int64{1}},
+		{`severity=3 or severity=5 and scan_type=SCA`, []int64{2, 3}},
+		{`cvss>=9.5`, []int64{3}},
+		{`not cvss>0`, []int64{1, 2}},
+		{`cvss_vector~"S:C"`, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got := matchingIDs(t, tt.expr)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got IDs %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got IDs %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		column  int
+		message string
+	}{
+		{``, 1, "empty query"},
+		{`sevrity>=4`, 1, `did you mean "severity"?`},
+		{`severity>=`, 11, "expected a value"},
+		{`severity>=high`, 11, "expects a number"},
+		{`file>3`, 5, "only applies to number and date fields"},
+		{`severity~4`, 9, "only applies to text fields"},
+		{`cwe in (89, 79`, 15, "expected ',' or ')'"},
+		{`(severity=4`, 12, "expected ')'"},
+		{`severity=4 cwe=89`, 12, "combine conditions with 'and' or 'or'"},
+		{`file~"src`, 6, "unterminated string"},
+		{`severity=4 and`, 15, "expected a condition"},
+		{`first_found>2024/01/01`, 13, "expects a date"},
+		{`cvss>=high`, 7, "expects a number like 7.5"},
+		{`file`, 5, "expected an operator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var qerr *Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if qerr.Column() != tt.column {
+				t.Errorf("column = %d, want %d (%v)", qerr.Column(), tt.column, err)
+			}
+			if !strings.Contains(qerr.Message, tt.message) {
+				t.Errorf("message %q does not contain %q", qerr.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestErrorCaret(t *testing.T) {
+	_, err := Parse(`severity>=4 and cwe in 89`)
+	var qerr *Error
+	if !errors.As(err, &qerr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+
+	want := "severity>=4 and cwe in 89\n                       ^"
+	if got := qerr.Caret(); got != want {
+		t.Errorf("Caret() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	q, err := Parse("  severity >= 4  ")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if q.String() != "severity >= 4" {
+		t.Errorf("String() = %q", q.String())
+	}
+}
@@ -88,6 +88,38 @@ func (s *Service) GetFindings(applicationGUID string, opts *GetFindingsOptions)
 	return &result, nil
 }
 
+// maxFindingsPageSize is the largest page size accepted by the Findings API
+const maxFindingsPageSize = 500
+
+// GetAllFindings retrieves every page of findings for an application.
+// The Size and Page fields of opts are ignored.
+func (s *Service) GetAllFindings(applicationGUID string, opts *GetFindingsOptions) ([]Finding, error) {
+	pageOpts := GetFindingsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.Size = maxFindingsPageSize
+
+	var all []Finding
+	for page := 0; ; page++ {
+		pageOpts.Page = page
+		result, err := s.GetFindings(applicationGUID, &pageOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get findings page %d: %w", page, err)
+		}
+
+		if result.Embedded != nil {
+			all = append(all, result.Embedded.Findings...)
+		}
+
+		if result.Page == nil || int64(page+1) >= result.Page.TotalPages {
+			break
+		}
+	}
+
+	return all, nil
+}
+
 // GetStaticFlawInfo retrieves detailed data path information for a static flaw
 func (s *Service) GetStaticFlawInfo(applicationGUID string, issueID int64, context string) (*StaticFlawInfo, error) {
 	if applicationGUID == "" {
@@ -0,0 +1,78 @@
+package findings
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+func TestGetAllFindingsPaging(t *testing.T) {
+	var requestedPages []string
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != "/appsec/v2/applications/app-guid/findings" {
+				t.Errorf("Unexpected path: %s", urlPath)
+			}
+			if params.Get("size") != "500" {
+				t.Errorf("Expected size 500, got %s", params.Get("size"))
+			}
+			if params.Get("scan_type") != "STATIC" {
+				t.Errorf("Expected scan_type STATIC, got %s", params.Get("scan_type"))
+			}
+
+			page := params.Get("page")
+			if page == "" {
+				page = "0"
+			}
+			requestedPages = append(requestedPages, page)
+
+			var number int
+			_, _ = fmt.Sscanf(page, "%d", &number)
+			return []byte(fmt.Sprintf(`{
+				"_embedded": {"findings": [{"issue_id": %d}, {"issue_id": %d}]},
+				"page": {"number": %d, "size": 500, "total_elements": 6, "total_pages": 3}
+			}`, number*10+1, number*10+2, number)), nil
+		},
+	}
+
+	service := NewService(client)
+	all, err := service.GetAllFindings("app-guid", &GetFindingsOptions{ScanType: []string{"STATIC"}, Size: 10, Page: 7})
+	if err != nil {
+		t.Fatalf("GetAllFindings failed: %v", err)
+	}
+
+	if len(requestedPages) != 3 || requestedPages[0] != "0" || requestedPages[2] != "2" {
+		t.Errorf("Expected pages 0,1,2 to be requested, got %v", requestedPages)
+	}
+	if len(all) != 6 {
+		t.Fatalf("Expected 6 findings, got %d", len(all))
+	}
+	if all[5].IssueID != 22 {
+		t.Errorf("Expected last finding ID 22, got %d", all[5].IssueID)
+	}
+}
+
+func TestGetAllFindingsError(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			return nil, fmt.Errorf("boom")
+		},
+	}
+
+	service := NewService(client)
+	if _, err := service.GetAllFindings("app-guid", nil); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,81 @@
+package findings
+
+import (
+	"sort"
+	"time"
+)
+
+// DueSoonDays is the number of days before its grace period expires that a finding is due soon
+const DueSoonDays = 7
+
+// GraceDaysRemaining returns the number of calendar days until the grace period expires, in the
+// location of now. It is 0 on the day of expiry and negative once overdue. The second result is
+// false if the finding has no grace period.
+func (f *Finding) GraceDaysRemaining(now time.Time) (int, bool) {
+	if f.GracePeriodExpiresDate == nil {
+		return 0, false
+	}
+	expires := f.GracePeriodExpiresDate.In(now.Location())
+	expiryDay := time.Date(expires.Year(), expires.Month(), expires.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(expiryDay.Sub(today).Hours() / 24), true
+}
+
+// IsSLATracked reports whether the finding counts towards the remediation SLA: it violates
+// policy, is still open, has no approved mitigation and has a grace period
+func (f *Finding) IsSLATracked() bool {
+	return f.ViolatesPolicy && f.Status() != StatusClosed && !f.IsMitigated() && f.GracePeriodExpiresDate != nil
+}
+
+// IsOverdue reports whether an SLA-tracked finding is past its grace period
+func (f *Finding) IsOverdue(now time.Time) bool {
+	days, ok := f.GraceDaysRemaining(now)
+	return ok && f.IsSLATracked() && days < 0
+}
+
+// SLASummary counts the SLA-tracked findings of a list
+type SLASummary struct {
+	Tracked    int        // Open, unmitigated policy violations with a grace period
+	Overdue    int        // Past their grace period
+	DueSoon    int        // Expiring within DueSoonDays
+	NextExpiry *time.Time // Earliest grace period expiry that is not yet overdue
+}
+
+// SummarizeSLA counts the SLA-tracked findings by how close they are to their grace period expiry
+func SummarizeSLA(list []Finding, now time.Time) SLASummary {
+	var summary SLASummary
+	for i := range list {
+		f := &list[i]
+		if !f.IsSLATracked() {
+			continue
+		}
+		summary.Tracked++
+
+		days, _ := f.GraceDaysRemaining(now)
+		switch {
+		case days < 0:
+			summary.Overdue++
+		case days <= DueSoonDays:
+			summary.DueSoon++
+		}
+		if days >= 0 && (summary.NextExpiry == nil || f.GracePeriodExpiresDate.Before(*summary.NextExpiry)) {
+			summary.NextExpiry = f.GracePeriodExpiresDate
+		}
+	}
+	return summary
+}
+
+// SLAFindings returns the SLA-tracked findings of a list, ordered by grace period expiry,
+// most overdue first
+func SLAFindings(list []Finding) []Finding {
+	var tracked []Finding
+	for i := range list {
+		if list[i].IsSLATracked() {
+			tracked = append(tracked, list[i])
+		}
+	}
+	sort.SliceStable(tracked, func(i, j int) bool {
+		return tracked[i].GracePeriodExpiresDate.Before(*tracked[j].GracePeriodExpiresDate)
+	})
+	return tracked
+}
@@ -0,0 +1,62 @@
+package findings
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGraceDaysRemaining(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expires string
+		want    int
+	}{
+		{"2025-06-10T09:00:00Z", 0},
+		{"2025-06-11T01:00:00Z", 1},
+		{"2025-06-03T23:59:00Z", -7},
+	}
+	for _, tt := range tests {
+		finding := decodeFinding(t, `{"grace_period_expires_date": "`+tt.expires+`"}`)
+		got, ok := finding.GraceDaysRemaining(now)
+		if !ok || got != tt.want {
+			t.Errorf("GraceDaysRemaining() for %s = %d, %v, want %d", tt.expires, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := decodeFinding(t, `{}`).GraceDaysRemaining(now); ok {
+		t.Error("Expected no grace period for a finding without an expiry date")
+	}
+}
+
+func TestSummarizeSLA(t *testing.T) {
+	var list []Finding
+	data := `[
+		{"issue_id": 1, "violates_policy": true, "grace_period_expires_date": "2025-06-01T00:00:00Z", "finding_status": {"status": "OPEN"}},
+		{"issue_id": 2, "violates_policy": true, "grace_period_expires_date": "2025-06-15T00:00:00Z", "finding_status": {"status": "OPEN"}},
+		{"issue_id": 3, "violates_policy": true, "grace_period_expires_date": "2025-08-01T00:00:00Z", "finding_status": {"status": "REOPENED"}},
+		{"issue_id": 4, "violates_policy": true, "grace_period_expires_date": "2025-05-01T00:00:00Z", "finding_status": {"status": "CLOSED"}},
+		{"issue_id": 5, "violates_policy": true, "grace_period_expires_date": "2025-05-01T00:00:00Z", "finding_status": {"status": "OPEN", "resolution_status": "APPROVED"}},
+		{"issue_id": 6, "violates_policy": false, "grace_period_expires_date": "2025-05-01T00:00:00Z", "finding_status": {"status": "OPEN"}}
+	]`
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	summary := SummarizeSLA(list, now)
+	if summary.Tracked != 3 || summary.Overdue != 1 || summary.DueSoon != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if summary.NextExpiry == nil || summary.NextExpiry.Day() != 15 {
+		t.Errorf("Expected the next expiry on June 15, got %v", summary.NextExpiry)
+	}
+
+	tracked := SLAFindings(list)
+	if len(tracked) != 3 || tracked[0].IssueID != 1 || tracked[2].IssueID != 3 {
+		t.Errorf("Expected issues 1, 2, 3 in expiry order, got %d findings", len(tracked))
+	}
+	if !tracked[0].IsOverdue(now) || tracked[1].IsOverdue(now) {
+		t.Error("Expected only issue 1 to be overdue")
+	}
+}
@@ -0,0 +1,91 @@
+package identity
+
+import (
+	"slices"
+	"strings"
+)
+
+// Capability is an action in the UI that depends on the principal's roles and permissions
+type Capability string
+
+const (
+	// CapabilityApproveMitigations allows accepting or rejecting proposed mitigations
+	CapabilityApproveMitigations Capability = "approve-mitigations"
+	// CapabilityManageSandboxes allows creating, editing, deleting and promoting sandboxes
+	CapabilityManageSandboxes Capability = "manage-sandboxes"
+)
+
+// AllCapabilities lists every capability in display order
+var AllCapabilities = []Capability{
+	CapabilityApproveMitigations,
+	CapabilityManageSandboxes,
+}
+
+// Description returns a short description of the capability for display
+func (c Capability) Description() string {
+	switch c {
+	case CapabilityApproveMitigations:
+		return "Approve mitigations"
+	case CapabilityManageSandboxes:
+		return "Manage sandboxes"
+	}
+	return string(c)
+}
+
+// PermissionApproveMitigations is the principal permission that allows approving mitigations
+const PermissionApproveMitigations = "approveMitigations"
+
+// roleCapabilities maps normalized role names to the capabilities that need an explicit grant.
+// Only the Mitigation Approver role is documented by Veracode as granting approval; other roles
+// are not listed, as the API decides what they allow.
+var roleCapabilities = map[string][]Capability{
+	"mitigationapprover": {CapabilityApproveMitigations},
+}
+
+// Capabilities describes what the principal may do: approving mitigations needs the
+// approveMitigations permission or a role that grants it, and managing sandboxes needs sandboxes
+// to be enabled for the organization. Other actions are left to the API. The zero value is used
+// while the principal is unknown and allows managing sandboxes but not approving.
+type Capabilities struct {
+	granted     map[Capability]bool
+	noSandboxes bool // Sandboxes are not enabled for the organization
+}
+
+// NewCapabilities derives the capabilities of a principal from its roles, permissions and
+// whether sandboxes are enabled for the organization
+func NewCapabilities(p *Principal) Capabilities {
+	if p == nil {
+		return Capabilities{}
+	}
+
+	caps := Capabilities{granted: make(map[Capability]bool), noSandboxes: !p.SandboxEnabled}
+	for _, role := range p.Roles {
+		for _, c := range roleCapabilities[normalizeRole(role)] {
+			caps.granted[c] = true
+		}
+	}
+	if slices.Contains(p.Permissions, PermissionApproveMitigations) {
+		caps.granted[CapabilityApproveMitigations] = true
+	}
+	return caps
+}
+
+// Can reports whether the capability is allowed
+func (c Capabilities) Can(capability Capability) bool {
+	if capability == CapabilityManageSandboxes {
+		return !c.noSandboxes
+	}
+	return c.granted[capability]
+}
+
+// normalizeRole reduces a role name such as "extmitigationapprover" or "Mitigation Approver" to
+// the lowercase letters of the role, without the "ext" prefix of API role names
+func normalizeRole(role string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(role) {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimPrefix(b.String(), "ext")
+}
@@ -0,0 +1,105 @@
+package identity
+
+import "testing"
+
+// allExceptApprove are the capabilities no role or permission restricts
+var allExceptApprove = []Capability{CapabilityManageSandboxes}
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		allowed   []Capability
+		denied    []Capability
+	}{
+		{
+			name:      "unknown principal",
+			principal: nil,
+			allowed:   allExceptApprove,
+			denied:    []Capability{CapabilityApproveMitigations},
+		},
+		{
+			name:      "approve permission",
+			principal: &Principal{Roles: []string{"extreviewer"}, Permissions: []string{"approveMitigations"}, SandboxEnabled: true},
+			allowed:   AllCapabilities,
+		},
+		{
+			name:      "sandboxes disabled",
+			principal: &Principal{Roles: []string{"extseclead"}},
+			denied:    []Capability{CapabilityApproveMitigations, CapabilityManageSandboxes},
+		},
+		{
+			name:      "no roles",
+			principal: &Principal{SandboxEnabled: true},
+			allowed:   allExceptApprove,
+			denied:    []Capability{CapabilityApproveMitigations},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCapabilities(t, NewCapabilities(tt.principal), tt.allowed, tt.denied)
+		})
+	}
+}
+
+// TestRoleCapabilities pins what each Veracode role allows on its own. Roles never take away an
+// action; only the Mitigation Approver role grants approval.
+func TestRoleCapabilities(t *testing.T) {
+	roles := map[string]bool{
+		"extseclead":            false,
+		"extcreator":            false,
+		"extsubmitter":          false,
+		"extreviewer":           false,
+		"extmitigationapprover": true,
+		"Mitigation Approver":   true,
+		"extsandboxadmin":       false,
+		"extsandboxuser":        false,
+		"extadmin":              false,
+		"extexecutive":          false,
+		"extpolicyadmin":        false,
+		"extelearn":             false,
+		"extresultsapi":         false,
+		"extuploadapi":          false,
+		"extmitigationapi":      false,
+	}
+
+	for role, approves := range roles {
+		t.Run(role, func(t *testing.T) {
+			caps := NewCapabilities(&Principal{Roles: []string{role}, SandboxEnabled: true})
+			if approves {
+				assertCapabilities(t, caps, AllCapabilities, nil)
+			} else {
+				assertCapabilities(t, caps, allExceptApprove, []Capability{CapabilityApproveMitigations})
+			}
+		})
+	}
+}
+
+func assertCapabilities(t *testing.T, caps Capabilities, allowed, denied []Capability) {
+	t.Helper()
+	for _, c := range allowed {
+		if !caps.Can(c) {
+			t.Errorf("Expected %s to be allowed", c)
+		}
+	}
+	for _, c := range denied {
+		if caps.Can(c) {
+			t.Errorf("Expected %s to be denied", c)
+		}
+	}
+}
+
+func TestNormalizeRole(t *testing.T) {
+	tests := map[string]string{
+		"extmitigationapprover": "mitigationapprover",
+		"Mitigation Approver":   "mitigationapprover",
+		"Sandbox Administrator": "sandboxadministrator",
+		"extexecutive":          "executive",
+	}
+	for role, want := range tests {
+		if got := normalizeRole(role); got != want {
+			t.Errorf("normalizeRole(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
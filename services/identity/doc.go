@@ -1,2 +1,3 @@
-// Package identity provides access to the Veracode Identity API
+// Package identity provides access to the Veracode Identity API: the current principal and API
+// credentials, and the organization's users, teams and business units
 package identity
@@ -1,6 +1,11 @@
 package identity
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Principal represents the current API user's information
 type Principal struct {
@@ -27,9 +32,186 @@ type Principal struct {
 type APICredentials struct {
 	APIID          string    `json:"api_id"`
 	APISecret      string    `json:"api_secret,omitempty"`
-	ExpirationTS   time.Time `json:"expiration_ts"`
+	ExpirationTS   Timestamp `json:"expiration_ts"`
 	OrgID          string    `json:"org_id,omitempty"`
-	RevocationTS   time.Time `json:"revocation_ts,omitempty"`
+	RevocationTS   Timestamp `json:"revocation_ts,omitempty"`
 	RevocationUser string    `json:"revocation_user,omitempty"`
 	UserID         string    `json:"user_id,omitempty"`
 }
+
+// DaysUntilExpiry returns the number of whole days before the credentials expire, negative once
+// they have expired, and false when they have no expiry date
+func (c *APICredentials) DaysUntilExpiry(now time.Time) (int, bool) {
+	if c.ExpirationTS.IsZero() {
+		return 0, false
+	}
+	remaining := c.ExpirationTS.Sub(now)
+	days := int(remaining / (24 * time.Hour))
+	if remaining < 0 {
+		days--
+	}
+	return days, true
+}
+
+// ExpiresWithin reports whether the credentials expire within the given number of days
+func (c *APICredentials) ExpiresWithin(days int, now time.Time) bool {
+	remaining, ok := c.DaysUntilExpiry(now)
+	return ok && remaining < days
+}
+
+// DescribeExpiry describes when the credentials expire, e.g. "expire on 2026-06-30 (in 29 days)"
+func (c *APICredentials) DescribeExpiry(now time.Time) string {
+	days, ok := c.DaysUntilExpiry(now)
+	switch {
+	case !ok:
+		return "have no expiry date"
+	case days < 0:
+		return fmt.Sprintf("expired on %s", c.ExpirationTS.Local().Format("2006-01-02"))
+	case days == 0:
+		return fmt.Sprintf("expire today (%s)", c.ExpirationTS.Local().Format("2006-01-02 15:04"))
+	case days == 1:
+		return fmt.Sprintf("expire on %s (in 1 day)", c.ExpirationTS.Local().Format("2006-01-02"))
+	}
+	return fmt.Sprintf("expire on %s (in %d days)", c.ExpirationTS.Local().Format("2006-01-02"), days)
+}
+
+// timestampLayouts are the formats of Identity API timestamps, which usually have no colon in
+// the zone offset (2025-06-30T12:00:00.000+0000)
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999-0700", "2006-01-02T15:04:05.999Z0700"}
+
+// Timestamp is an Identity API timestamp; null or empty values are the zero time
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON parses the timestamp formats of the Identity API
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil || *value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, *value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid timestamp %q", *value)
+}
+
+// PageMetadata contains pagination information
+type PageMetadata struct {
+	Number        int64 `json:"number,omitempty"`
+	Size          int64 `json:"size,omitempty"`
+	TotalElements int64 `json:"total_elements,omitempty"`
+	TotalPages    int64 `json:"total_pages,omitempty"`
+}
+
+// ListOptions selects a page of a list; Page is numbered from 0 and a Size of 0 uses the API default
+type ListOptions struct {
+	Page int
+	Size int
+}
+
+// PagedUsers is a page of users
+type PagedUsers struct {
+	Embedded struct {
+		Users []User `json:"users,omitempty"`
+	} `json:"_embedded"`
+	Page *PageMetadata `json:"page,omitempty"`
+}
+
+// User is a user of the organization. Lists return a summary; GetUser adds the roles and teams.
+type User struct {
+	UserID       string           `json:"user_id"`
+	UserName     string           `json:"user_name,omitempty"`
+	FirstName    string           `json:"first_name,omitempty"`
+	LastName     string           `json:"last_name,omitempty"`
+	EmailAddress string           `json:"email_address,omitempty"`
+	Title        string           `json:"title,omitempty"`
+	LoginEnabled bool             `json:"login_enabled,omitempty"`
+	SAMLUser     bool             `json:"saml_user,omitempty"`
+	AccountType  string           `json:"login_account_type,omitempty"`
+	Roles        []Role           `json:"roles,omitempty"`
+	Teams        []TeamRef        `json:"teams,omitempty"`
+	BusinessUnit *BusinessUnitRef `json:"business_unit,omitempty"`
+}
+
+// FullName returns the user's first and last name, or the user name when they are not set
+func (u *User) FullName() string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		return u.UserName
+	}
+	return name
+}
+
+// Role is a role granted to a user
+type Role struct {
+	RoleID          string `json:"role_id,omitempty"`
+	RoleName        string `json:"role_name,omitempty"`
+	RoleDescription string `json:"role_description,omitempty"`
+}
+
+// PagedTeams is a page of teams
+type PagedTeams struct {
+	Embedded struct {
+		Teams []Team `json:"teams,omitempty"`
+	} `json:"_embedded"`
+	Page *PageMetadata `json:"page,omitempty"`
+}
+
+// Team is a group of users who share access to applications. Lists return a summary; GetTeam
+// adds the members.
+type Team struct {
+	TeamID       string           `json:"team_id"`
+	TeamName     string           `json:"team_name,omitempty"`
+	TeamLegacyID int              `json:"team_legacy_id,omitempty"`
+	UserCount    int              `json:"user_count,omitempty"`
+	BusinessUnit *BusinessUnitRef `json:"business_unit,omitempty"`
+	Users        []TeamMember     `json:"users,omitempty"`
+}
+
+// TeamMember is a user in a team
+type TeamMember struct {
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	Relationship *struct {
+		Name string `json:"name,omitempty"` // MEMBER or ADMIN
+	} `json:"relationship,omitempty"`
+}
+
+// TeamRef identifies a team
+type TeamRef struct {
+	TeamID   string `json:"team_id"`
+	TeamName string `json:"team_name,omitempty"`
+}
+
+// PagedBusinessUnits is a page of business units
+type PagedBusinessUnits struct {
+	Embedded struct {
+		BusinessUnits []BusinessUnit `json:"business_units,omitempty"`
+	} `json:"_embedded"`
+	Page *PageMetadata `json:"page,omitempty"`
+}
+
+// BusinessUnit is a division of the organization that owns applications and teams
+type BusinessUnit struct {
+	BUID       string    `json:"bu_id"`
+	BUName     string    `json:"bu_name,omitempty"`
+	BULegacyID int       `json:"bu_legacy_id,omitempty"`
+	IsDefault  bool      `json:"is_default,omitempty"`
+	Teams      []TeamRef `json:"teams,omitempty"`
+}
+
+// BusinessUnitRef identifies a business unit
+type BusinessUnitRef struct {
+	BUID   string `json:"bu_id"`
+	BUName string `json:"bu_name,omitempty"`
+}
@@ -5,16 +5,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+)
+
+const identityBasePath = "/api/authn/v2"
 
-	"github.com/dipsylala/veracode-tui/veracode"
+// Paths of the current principal and API credentials, for invalidating cached responses
+const (
+	PrincipalPath      = identityBasePath + "/principal"
+	APICredentialsPath = identityBasePath + "/api_credentials"
 )
 
+// maxPageSize is the largest page the list endpoints return
+const maxPageSize = 500
+
 // Service provides access to the Veracode Identity API
 type Service struct {
-	client *veracode.Client
+	client HTTPClient
+}
+
+// HTTPClient interface for making HTTP requests
+type HTTPClient interface {
+	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error)
 }
 
-func NewService(client *veracode.Client) *Service {
+func NewService(client HTTPClient) *Service {
 	return &Service{
 		client: client,
 	}
@@ -22,22 +38,26 @@ func NewService(client *veracode.Client) *Service {
 
 // GetPrincipal retrieves the current API user's principal information
 func (s *Service) GetPrincipal(ctx context.Context) (*Principal, error) {
-	body, err := s.client.DoRequestWithQueryParams("GET", "/api/authn/v2/principal", url.Values{})
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
-	}
-
 	var principal Principal
-	if err := json.Unmarshal(body, &principal); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+	if err := s.get(PrincipalPath, url.Values{}, &principal); err != nil {
+		return nil, err
 	}
-
 	return &principal, nil
 }
 
 // GetAPICredentials retrieves the current user's API credentials (without the secret)
 func (s *Service) GetAPICredentials(ctx context.Context) (*APICredentials, error) {
-	body, err := s.client.DoRequestWithQueryParams("GET", "/api/authn/v2/api_credentials", url.Values{})
+	var creds APICredentials
+	if err := s.get(APICredentialsPath, url.Values{}, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// GenerateAPICredentials generates new API credentials for the current user. The new secret is
+// only returned by this call, and the credentials used to make it stop working.
+func (s *Service) GenerateAPICredentials(ctx context.Context) (*APICredentials, error) {
+	body, err := s.client.DoRequestWithBody("POST", APICredentialsPath, []byte("{}"), nil)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -46,6 +66,153 @@ func (s *Service) GetAPICredentials(ctx context.Context) (*APICredentials, error
 	if err := json.Unmarshal(body, &creds); err != nil {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
-
+	if creds.APIID == "" || creds.APISecret == "" {
+		return nil, fmt.Errorf("the response contained no API ID or secret")
+	}
 	return &creds, nil
 }
+
+// GetUsers retrieves a page of the organization's users
+func (s *Service) GetUsers(ctx context.Context, opts *ListOptions) (*PagedUsers, error) {
+	var result PagedUsers
+	if err := s.get(identityBasePath+"/users", pageParams(opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllUsers retrieves every page of users
+func (s *Service) GetAllUsers(ctx context.Context) ([]User, error) {
+	var all []User
+	err := getAllPages(func(opts *ListOptions) (*PageMetadata, error) {
+		result, err := s.GetUsers(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Embedded.Users...)
+		return result.Page, nil
+	})
+	return all, err
+}
+
+// GetUser retrieves a user with their roles and teams
+func (s *Service) GetUser(ctx context.Context, userID string) (*User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+	var user User
+	if err := s.get(identityBasePath+"/users/"+url.PathEscape(userID), url.Values{"detailed": {"true"}}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetTeams retrieves a page of the organization's teams
+func (s *Service) GetTeams(ctx context.Context, opts *ListOptions) (*PagedTeams, error) {
+	var result PagedTeams
+	if err := s.get(identityBasePath+"/teams", pageParams(opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllTeams retrieves every page of teams
+func (s *Service) GetAllTeams(ctx context.Context) ([]Team, error) {
+	var all []Team
+	err := getAllPages(func(opts *ListOptions) (*PageMetadata, error) {
+		result, err := s.GetTeams(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Embedded.Teams...)
+		return result.Page, nil
+	})
+	return all, err
+}
+
+// GetTeam retrieves a team with its members
+func (s *Service) GetTeam(ctx context.Context, teamID string) (*Team, error) {
+	if teamID == "" {
+		return nil, fmt.Errorf("teamID is required")
+	}
+	var team Team
+	if err := s.get(identityBasePath+"/teams/"+url.PathEscape(teamID), url.Values{}, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// GetBusinessUnits retrieves a page of the organization's business units
+func (s *Service) GetBusinessUnits(ctx context.Context, opts *ListOptions) (*PagedBusinessUnits, error) {
+	var result PagedBusinessUnits
+	if err := s.get(identityBasePath+"/business_units", pageParams(opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAllBusinessUnits retrieves every page of business units
+func (s *Service) GetAllBusinessUnits(ctx context.Context) ([]BusinessUnit, error) {
+	var all []BusinessUnit
+	err := getAllPages(func(opts *ListOptions) (*PageMetadata, error) {
+		result, err := s.GetBusinessUnits(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Embedded.BusinessUnits...)
+		return result.Page, nil
+	})
+	return all, err
+}
+
+// GetBusinessUnit retrieves a business unit with its teams
+func (s *Service) GetBusinessUnit(ctx context.Context, buID string) (*BusinessUnit, error) {
+	if buID == "" {
+		return nil, fmt.Errorf("buID is required")
+	}
+	var bu BusinessUnit
+	if err := s.get(identityBasePath+"/business_units/"+url.PathEscape(buID), url.Values{}, &bu); err != nil {
+		return nil, err
+	}
+	return &bu, nil
+}
+
+// get performs a GET request and decodes the response into result
+func (s *Service) get(urlPath string, params url.Values, result interface{}) error {
+	body, err := s.client.DoRequestWithQueryParams("GET", urlPath, params)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// pageParams builds the page and size query parameters
+func pageParams(opts *ListOptions) url.Values {
+	params := url.Values{}
+	if opts == nil {
+		return params
+	}
+	if opts.Page > 0 {
+		params.Add("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size > 0 {
+		params.Add("size", strconv.Itoa(opts.Size))
+	}
+	return params
+}
+
+// getAllPages calls fetch for each page of maxPageSize items until the last page
+func getAllPages(fetch func(opts *ListOptions) (*PageMetadata, error)) error {
+	for page := 0; ; page++ {
+		meta, err := fetch(&ListOptions{Page: page, Size: maxPageSize})
+		if err != nil {
+			return fmt.Errorf("page %d: %w", page, err)
+		}
+		if meta == nil || int64(page+1) >= meta.TotalPages {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+	DoRequestWithBodyFunc        func(method, urlPath string, body []byte, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	if m.DoRequestWithBodyFunc != nil {
+		return m.DoRequestWithBodyFunc(method, urlPath, body, params)
+	}
+	return []byte("{}"), nil
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+func TestGetAllTeamsPages(t *testing.T) {
+	var pages []string
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != identityBasePath+"/teams" || params.Get("size") != "500" {
+				t.Errorf("Unexpected request %s %v", urlPath, params)
+			}
+			page := params.Get("page")
+			pages = append(pages, page)
+			return []byte(fmt.Sprintf(`{
+				"_embedded": {"teams": [{"team_id": "team-%s", "team_name": "Team %s", "business_unit": {"bu_id": "bu-1", "bu_name": "Payments"}}]},
+				"page": {"number": 0, "size": 500, "total_elements": 2, "total_pages": 2}
+			}`, page, page)), nil
+		},
+	}
+
+	teams, err := NewService(client).GetAllTeams(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllTeams failed: %v", err)
+	}
+	if len(teams) != 2 || teams[1].TeamID != "team-1" || teams[0].BusinessUnit.BUName != "Payments" {
+		t.Errorf("Unexpected teams %+v", teams)
+	}
+	if len(pages) != 2 || pages[0] != "" || pages[1] != "1" {
+		t.Errorf("Expected pages 0 and 1 to be requested, got %q", pages)
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != identityBasePath+"/users/user-1" || params.Get("detailed") != "true" {
+				t.Errorf("Unexpected request %s %v", urlPath, params)
+			}
+			return []byte(`{
+				"user_id": "user-1", "user_name": "jdoe", "first_name": "Jo", "last_name": "Doe",
+				"roles": [{"role_name": "securityLead", "role_description": "Security Lead"}],
+				"teams": [{"team_id": "team-1", "team_name": "Payments"}]
+			}`), nil
+		},
+	}
+
+	user, err := NewService(client).GetUser(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if user.FullName() != "Jo Doe" || len(user.Roles) != 1 || user.Teams[0].TeamName != "Payments" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+	if (&User{UserName: "api-user"}).FullName() != "api-user" {
+		t.Error("Expected the user name when there is no first or last name")
+	}
+	if _, err := NewService(client).GetUser(context.Background(), ""); err == nil {
+		t.Error("Expected an error without a user ID")
+	}
+}
+
+func TestGenerateAPICredentials(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithBodyFunc: func(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+			if method != "POST" || urlPath != identityBasePath+"/api_credentials" {
+				t.Errorf("Unexpected request %s %s", method, urlPath)
+			}
+			return []byte(`{"api_id": "new-id", "api_secret": "new-secret", "expiration_ts": "2026-06-30T12:00:00.000+0000"}`), nil
+		},
+	}
+
+	creds, err := NewService(client).GenerateAPICredentials(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAPICredentials failed: %v", err)
+	}
+	if creds.APIID != "new-id" || creds.APISecret != "new-secret" {
+		t.Errorf("Unexpected credentials %+v", creds)
+	}
+	if want := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC); !creds.ExpirationTS.Equal(want) {
+		t.Errorf("ExpirationTS = %v, want %v", creds.ExpirationTS, want)
+	}
+}
+
+func TestCredentialExpiry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	creds := APICredentials{ExpirationTS: Timestamp{now.Add(10*24*time.Hour + time.Hour)}}
+	if days, ok := creds.DaysUntilExpiry(now); !ok || days != 10 {
+		t.Errorf("DaysUntilExpiry = %d, %v; want 10", days, ok)
+	}
+	if !creds.ExpiresWithin(14, now) || creds.ExpiresWithin(10, now) {
+		t.Error("Expected the credentials to expire within 14 days but not within 10")
+	}
+
+	expired := APICredentials{ExpirationTS: Timestamp{now.Add(-time.Hour)}}
+	if days, _ := expired.DaysUntilExpiry(now); days != -1 {
+		t.Errorf("Expected -1 days for expired credentials, got %d", days)
+	}
+
+	var none APICredentials
+	if err := json.Unmarshal([]byte(`{"api_id": "id", "expiration_ts": null}`), &none); err != nil {
+		t.Fatalf("Failed to decode credentials without expiry: %v", err)
+	}
+	if _, ok := none.DaysUntilExpiry(now); ok || none.ExpiresWithin(14, now) {
+		t.Error("Expected no expiry")
+	}
+}
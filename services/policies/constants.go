@@ -0,0 +1,40 @@
+package policies
+
+// RuleType is the type of a policy finding rule
+type RuleType string
+
+// Finding rule types
+const (
+	RuleFailAll          RuleType = "FAIL_ALL"
+	RuleCWE              RuleType = "CWE"
+	RuleCategory         RuleType = "CATEGORY"
+	RuleMaxSeverity      RuleType = "MAX_SEVERITY"
+	RuleCVSS             RuleType = "CVSS"
+	RuleCVE              RuleType = "CVE"
+	RuleBlocklist        RuleType = "BLACKLIST"
+	RuleMinScore         RuleType = "MIN_SCORE"
+	RuleSecurityStandard RuleType = "SECURITY_STANDARD"
+	RuleLicenseRisk      RuleType = "LICENSE_RISK"
+)
+
+// Frequency is how often a policy requires a scan type to be run
+type Frequency string
+
+// Scan frequencies
+const (
+	FrequencyNotRequired  Frequency = "NOT_REQUIRED"
+	FrequencyOnce         Frequency = "ONCE"
+	FrequencyDaily        Frequency = "DAILY"
+	FrequencyWeekly       Frequency = "WEEKLY"
+	FrequencyMonthly      Frequency = "MONTHLY"
+	FrequencyQuarterly    Frequency = "QUARTERLY"
+	FrequencySemiAnnually Frequency = "SEMI_ANNUALLY"
+	FrequencyAnnually     Frequency = "ANNUALLY"
+	FrequencyEveryScan    Frequency = "EVERY_SCAN"
+)
+
+// Policy categories, for GetPoliciesOptions.Category
+const (
+	CategoryApplication = "APPLICATION"
+	CategoryComponent   = "COMPONENT"
+)
@@ -0,0 +1,13 @@
+// Package policies provides a client for the Veracode Policy API.
+//
+// It retrieves the security policies assigned to applications, with their finding rules, required
+// scan frequencies and remediation grace periods, and describes which findings a rule covers.
+//
+// Example usage:
+//
+//	service := policies.NewService(client)
+//	policy, err := service.GetPolicy(app.Profile.Policies[0].GUID)
+//	for _, rule := range policy.FindingRules {
+//		fmt.Println(rule.Describe())
+//	}
+package policies
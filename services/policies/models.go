@@ -0,0 +1,108 @@
+package policies
+
+import "time"
+
+// PagedResourceOfPolicy represents a paginated list of policies
+type PagedResourceOfPolicy struct {
+	Embedded *EmbeddedPolicy `json:"_embedded,omitempty"`
+	Page     *PageMetadata   `json:"page,omitempty"`
+}
+
+// EmbeddedPolicy contains the policies array
+type EmbeddedPolicy struct {
+	PolicyVersions []Policy `json:"policy_versions,omitempty"`
+}
+
+// PageMetadata contains pagination information
+type PageMetadata struct {
+	Number        int64 `json:"number,omitempty"`
+	Size          int64 `json:"size,omitempty"`
+	TotalElements int64 `json:"total_elements,omitempty"`
+	TotalPages    int64 `json:"total_pages,omitempty"`
+}
+
+// Policy is a version of a security policy
+type Policy struct {
+	GUID               string              `json:"guid,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	Type               string              `json:"type,omitempty"`
+	Version            int                 `json:"version,omitempty"`
+	Created            *time.Time          `json:"created,omitempty"`
+	ModifiedBy         string              `json:"modified_by,omitempty"`
+	OrganizationID     int                 `json:"organization_id,omitempty"`
+	Category           string              `json:"category,omitempty"`
+	VendorPolicy       bool                `json:"vendor_policy,omitempty"`
+	ScanFrequencyRules []ScanFrequencyRule `json:"scan_frequency_rules,omitempty"`
+	FindingRules       []FindingRule       `json:"finding_rules,omitempty"`
+	CustomSeverities   []CustomSeverity    `json:"custom_severities,omitempty"`
+	EvaluationDate     *time.Time          `json:"evaluation_date,omitempty"`
+	EvaluationDateType string              `json:"evaluation_date_type,omitempty"`
+
+	// Grace periods in days for findings of each severity, 5 (very high) to 0 (informational)
+	Sev5GracePeriod         int              `json:"sev5_grace_period"`
+	Sev4GracePeriod         int              `json:"sev4_grace_period"`
+	Sev3GracePeriod         int              `json:"sev3_grace_period"`
+	Sev2GracePeriod         int              `json:"sev2_grace_period"`
+	Sev1GracePeriod         int              `json:"sev1_grace_period"`
+	Sev0GracePeriod         int              `json:"sev0_grace_period"`
+	ScoreGracePeriod        int              `json:"score_grace_period"`
+	SCABlocklistGracePeriod int              `json:"sca_blacklist_grace_period"`
+	SCAGracePeriods         *SCAGracePeriods `json:"sca_grace_periods,omitempty"`
+}
+
+// ScanFrequencyRule is how often a scan type must be run
+type ScanFrequencyRule struct {
+	ScanType  string    `json:"scan_type,omitempty"`
+	Frequency Frequency `json:"frequency,omitempty"`
+}
+
+// FindingRule is a rule that findings of the listed scan types must not break
+type FindingRule struct {
+	Type            RuleType         `json:"type,omitempty"`
+	ScanType        []string         `json:"scan_type,omitempty"`
+	Value           string           `json:"value,omitempty"`
+	AdvancedOptions *AdvancedOptions `json:"advanced_options,omitempty"`
+}
+
+// AdvancedOptions are the options of SCA license and component rules
+type AdvancedOptions struct {
+	AllLicensesMustMeetRequirement bool              `json:"all_licenses_must_meet_requirement,omitempty"`
+	AllowedNonOSSLicenses          bool              `json:"allowed_nonoss_licenses,omitempty"`
+	IsBlocklist                    bool              `json:"is_blocklist,omitempty"`
+	SelectedLicenses               []SelectedLicense `json:"selected_licenses,omitempty"`
+}
+
+// SelectedLicense is a license listed by a license rule
+type SelectedLicense struct {
+	Name      string `json:"name,omitempty"`
+	LicenseID string `json:"license_id,omitempty"`
+	SPDXID    string `json:"spdx_id,omitempty"`
+	RiskLevel string `json:"risk_level,omitempty"`
+}
+
+// CustomSeverity overrides the severity of a CWE
+type CustomSeverity struct {
+	CWE      int `json:"cwe"`
+	Severity int `json:"severity"`
+}
+
+// SCAGracePeriods are the grace periods in days for SCA findings
+type SCAGracePeriods struct {
+	Sev5GracePeriod         int                      `json:"sev5_grace_period"`
+	Sev4GracePeriod         int                      `json:"sev4_grace_period"`
+	Sev3GracePeriod         int                      `json:"sev3_grace_period"`
+	Sev2GracePeriod         int                      `json:"sev2_grace_period"`
+	Sev1GracePeriod         int                      `json:"sev1_grace_period"`
+	Sev0GracePeriod         int                      `json:"sev0_grace_period"`
+	Score                   int                      `json:"score"`
+	SCABlocklistGracePeriod int                      `json:"sca_blacklist_grace_period"`
+	LicenseRiskGracePeriods *LicenseRiskGracePeriods `json:"license,omitempty"`
+}
+
+// LicenseRiskGracePeriods are the grace periods in days for license findings by risk
+type LicenseRiskGracePeriods struct {
+	High   int `json:"HIGH"`
+	Medium int `json:"MEDIUM"`
+	Low    int `json:"LOW"`
+}
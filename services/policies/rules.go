@@ -0,0 +1,152 @@
+package policies
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// severityNames are the names of the finding severities, indexed by severity
+var severityNames = []string{"Informational", "Very Low", "Low", "Medium", "High", "Very High"}
+
+// SeverityName returns the name of a finding severity (0-5)
+func SeverityName(severity int) string {
+	if severity < 0 || severity >= len(severityNames) {
+		return strconv.Itoa(severity)
+	}
+	return severityNames[severity]
+}
+
+// AppliesTo reports whether the rule covers findings of a scan type
+func (r FindingRule) AppliesTo(scanType findings.ScanType) bool {
+	return slices.ContainsFunc(r.ScanType, func(s string) bool {
+		return strings.EqualFold(s, string(scanType))
+	})
+}
+
+// Describe explains the requirement of a rule, e.g. "No High or higher severity findings"
+func (r FindingRule) Describe() string {
+	switch r.Type {
+	case RuleFailAll:
+		return "No findings allowed"
+	case RuleMaxSeverity:
+		if severity, err := strconv.Atoi(r.Value); err == nil {
+			return fmt.Sprintf("No %s or higher severity findings", SeverityName(severity))
+		}
+	case RuleCWE:
+		return fmt.Sprintf("No CWE-%s findings", r.Value)
+	case RuleCategory:
+		return fmt.Sprintf("No findings in the %s category", r.Value)
+	case RuleCVSS:
+		return fmt.Sprintf("No vulnerabilities with a CVSS score of %s or higher", r.Value)
+	case RuleCVE:
+		return fmt.Sprintf("No components affected by %s", r.Value)
+	case RuleBlocklist:
+		return "No blocklisted components"
+	case RuleMinScore:
+		return fmt.Sprintf("Security score of at least %s", r.Value)
+	case RuleSecurityStandard:
+		return fmt.Sprintf("No findings covered by the %s standard", strings.ReplaceAll(r.Value, "_", " "))
+	case RuleLicenseRisk:
+		return fmt.Sprintf("No component licenses of %s or higher risk", strings.ToLower(r.Value))
+	}
+	return fmt.Sprintf("%s %s", r.Type, r.Value)
+}
+
+// Evaluable reports whether the rule can be checked from the findings of an application.
+// Score, security standard and blocklist rules depend on data the findings API does not return.
+func (r FindingRule) Evaluable() bool {
+	switch r.Type {
+	case RuleFailAll, RuleMaxSeverity, RuleCWE, RuleCategory, RuleCVSS, RuleCVE, RuleLicenseRisk:
+		return true
+	}
+	return false
+}
+
+// Matches reports whether a finding breaks the rule, ignoring its status and mitigation.
+// Severity rules use the finding's severity under policy, which may give a CWE a custom severity;
+// policy may be nil. Findings of scan types the rule does not cover and rules that are not
+// evaluable never match.
+func (r FindingRule) Matches(f *findings.Finding, policy *Policy) bool {
+	if !r.AppliesTo(f.ScanType) {
+		return false
+	}
+	switch r.Type {
+	case RuleFailAll:
+		return true
+	case RuleMaxSeverity:
+		threshold, err := strconv.Atoi(r.Value)
+		return err == nil && policy.Severity(f) >= threshold
+	case RuleCWE:
+		return strconv.Itoa(f.CWEID()) == strings.TrimPrefix(strings.ToUpper(r.Value), "CWE-")
+	case RuleCategory:
+		return strings.EqualFold(findingCategory(f), r.Value)
+	case RuleCVSS:
+		threshold, err := strconv.ParseFloat(r.Value, 64)
+		return err == nil && f.CVSSBase() >= threshold
+	case RuleCVE:
+		return strings.EqualFold(f.CVE(), r.Value)
+	case RuleLicenseRisk:
+		threshold := findings.License{RiskRating: r.Value}.Risk()
+		return threshold != findings.LicenseRiskUnknown && slices.ContainsFunc(f.Licenses(), func(l findings.License) bool {
+			return l.Risk() >= threshold
+		})
+	}
+	return false
+}
+
+// Violations returns the open, unmitigated findings of a list that break the rule under policy,
+// which may be nil
+func (r FindingRule) Violations(list []findings.Finding, policy *Policy) []*findings.Finding {
+	var violations []*findings.Finding
+	for i := range list {
+		f := &list[i]
+		if f.Status() != findings.StatusClosed && !f.IsMitigated() && r.Matches(f, policy) {
+			violations = append(violations, f)
+		}
+	}
+	return violations
+}
+
+// GracePeriod returns the number of days a policy allows to fix a finding of a severity. SCA
+// findings use the SCA grace periods when the policy defines them.
+func (p *Policy) GracePeriod(severity int, scanType findings.ScanType) int {
+	periods := []int{p.Sev0GracePeriod, p.Sev1GracePeriod, p.Sev2GracePeriod, p.Sev3GracePeriod, p.Sev4GracePeriod, p.Sev5GracePeriod}
+	if scanType == findings.ScanTypeSCA && p.SCAGracePeriods != nil {
+		sca := p.SCAGracePeriods
+		periods = []int{sca.Sev0GracePeriod, sca.Sev1GracePeriod, sca.Sev2GracePeriod, sca.Sev3GracePeriod, sca.Sev4GracePeriod, sca.Sev5GracePeriod}
+	}
+	if severity < 0 || severity >= len(periods) {
+		return 0
+	}
+	return periods[severity]
+}
+
+// Severity returns the severity of a finding under the policy, which may override the severity
+// of a CWE with a custom severity. A nil policy returns the finding's own severity.
+func (p *Policy) Severity(f *findings.Finding) int {
+	if cwe := f.CWEID(); p != nil && cwe != 0 {
+		for _, custom := range p.CustomSeverities {
+			if custom.CWE == cwe {
+				return custom.Severity
+			}
+		}
+	}
+	return f.Severity()
+}
+
+// findingCategory returns the name of a static finding's category, which the API reports either
+// as an object with a name or as a plain string
+func findingCategory(f *findings.Finding) string {
+	switch category := f.Details()["finding_category"].(type) {
+	case map[string]interface{}:
+		name, _ := category["name"].(string)
+		return name
+	case string:
+		return category
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package policies
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+func decodeFindings(t *testing.T, data string) []findings.Finding {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Invalid findings: %v", err)
+	}
+	return list
+}
+
+func TestRuleViolations(t *testing.T) {
+	list := decodeFindings(t, `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN"},
+		 "finding_details": {"severity": 4, "cwe": {"id": 89}, "finding_category": {"name": "SQL Injection"}}},
+		{"issue_id": 2, "scan_type": "STATIC", "finding_status": {"status": "OPEN"},
+		 "finding_details": {"severity": 2, "cwe": {"id": 117}}},
+		{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"},
+		 "finding_details": {"severity": 5, "cwe": {"id": 89}}},
+		{"issue_id": 4, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "resolution_status": "APPROVED"},
+		 "finding_details": {"severity": 5, "cwe": {"id": 78}}},
+		{"issue_id": 5, "scan_type": "SCA", "finding_status": {"status": "OPEN"},
+		 "finding_details": {"severity": 3, "cve": {"name": "CVE-2021-44228", "cvss3": {"score": 10.0}},
+		  "licenses": [{"license_id": "GPL-3.0", "risk_rating": "HIGH"}]}}
+	]`)
+
+	// CWE-117 is raised from Low to High
+	policy := &Policy{CustomSeverities: []CustomSeverity{{CWE: 117, Severity: 4}}}
+
+	tests := []struct {
+		rule   FindingRule
+		policy *Policy
+		want   []int64
+	}{
+		{FindingRule{Type: RuleMaxSeverity, ScanType: []string{"STATIC"}, Value: "4"}, nil, []int64{1}},
+		{FindingRule{Type: RuleMaxSeverity, ScanType: []string{"STATIC"}, Value: "4"}, policy, []int64{1, 2}},
+		{FindingRule{Type: RuleMaxSeverity, ScanType: []string{"STATIC", "SCA"}, Value: "3"}, nil, []int64{1, 5}},
+		{FindingRule{Type: RuleCWE, ScanType: []string{"STATIC"}, Value: "117"}, nil, []int64{2}},
+		{FindingRule{Type: RuleCategory, ScanType: []string{"STATIC"}, Value: "sql injection"}, nil, []int64{1}},
+		{FindingRule{Type: RuleCVSS, ScanType: []string{"SCA"}, Value: "9.0"}, nil, []int64{5}},
+		{FindingRule{Type: RuleCVE, ScanType: []string{"SCA"}, Value: "CVE-2021-44228"}, nil, []int64{5}},
+		{FindingRule{Type: RuleLicenseRisk, ScanType: []string{"SCA"}, Value: "HIGH"}, nil, []int64{5}},
+		{FindingRule{Type: RuleFailAll, ScanType: []string{"DYNAMIC"}}, nil, nil},
+		{FindingRule{Type: RuleMinScore, ScanType: []string{"STATIC"}, Value: "80"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule.Describe(), func(t *testing.T) {
+			var got []int64
+			for _, f := range tt.rule.Violations(list, tt.policy) {
+				got = append(got, f.IssueID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Violations() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Violations() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := map[string]FindingRule{
+		"No High or higher severity findings":                 {Type: RuleMaxSeverity, Value: "4"},
+		"No CWE-89 findings":                                  {Type: RuleCWE, Value: "89"},
+		"No vulnerabilities with a CVSS score of 7 or higher": {Type: RuleCVSS, Value: "7"},
+		"No findings covered by the OWASP 2021 standard":      {Type: RuleSecurityStandard, Value: "OWASP_2021"},
+		"No component licenses of high or higher risk":        {Type: RuleLicenseRisk, Value: "HIGH"},
+	}
+	for want, rule := range tests {
+		if got := rule.Describe(); got != want {
+			t.Errorf("Describe() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	policiesBasePath = "/appsec/v1/policies"
+)
+
+// Service provides methods to interact with the Veracode Policy API
+type Service struct {
+	client HTTPClient
+}
+
+// HTTPClient interface for making HTTP requests
+type HTTPClient interface {
+	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+}
+
+func NewService(client HTTPClient) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// GetPoliciesOptions contains optional parameters for GetPolicies
+type GetPoliciesOptions struct {
+	Category string // CategoryApplication or CategoryComponent
+	Name     string
+	Page     int
+	Size     int
+}
+
+// GetPolicies retrieves a list of policies with optional filtering
+func (s *Service) GetPolicies(opts *GetPoliciesOptions) (*PagedResourceOfPolicy, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Category != "" {
+			params.Add("category", opts.Category)
+		}
+		if opts.Name != "" {
+			params.Add("name", opts.Name)
+		}
+		if opts.Page > 0 {
+			params.Add("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Size > 0 {
+			params.Add("size", strconv.Itoa(opts.Size))
+		}
+	}
+
+	body, err := s.client.DoRequestWithQueryParams("GET", policiesBasePath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PagedResourceOfPolicy
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse policies response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// maxPoliciesPageSize is the largest page GetAllPolicies requests
+const maxPoliciesPageSize = 500
+
+// GetAllPolicies retrieves every policy of a category (CategoryApplication or CategoryComponent,
+// or "" for all) by reading all pages of GetPolicies
+func (s *Service) GetAllPolicies(category string) ([]Policy, error) {
+	opts := GetPoliciesOptions{Category: category, Size: maxPoliciesPageSize}
+
+	var all []Policy
+	for page := 0; ; page++ {
+		opts.Page = page
+		result, err := s.GetPolicies(&opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get policies page %d: %w", page, err)
+		}
+
+		if result.Embedded != nil {
+			all = append(all, result.Embedded.PolicyVersions...)
+		}
+
+		if result.Page == nil || int64(page+1) >= result.Page.TotalPages {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// GetPolicy retrieves the latest version of a policy by GUID
+func (s *Service) GetPolicy(policyGUID string) (*Policy, error) {
+	if policyGUID == "" {
+		return nil, fmt.Errorf("policyGUID is required")
+	}
+
+	urlPath := fmt.Sprintf("%s/%s", policiesBasePath, policyGUID)
+	body, err := s.client.DoRequestWithQueryParams("GET", urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Policy
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse policy response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,109 @@
+package policies
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+func TestGetPolicy(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != policiesBasePath+"/policy-1" {
+				t.Errorf("Unexpected path %s", urlPath)
+			}
+			return []byte(`{
+				"guid": "policy-1",
+				"name": "Veracode Recommended High",
+				"version": 3,
+				"finding_rules": [
+					{"type": "MAX_SEVERITY", "scan_type": ["STATIC", "DYNAMIC"], "value": "4"},
+					{"type": "LICENSE_RISK", "scan_type": ["SCA"], "value": "HIGH", "advanced_options": {"all_licenses_must_meet_requirement": true}}
+				],
+				"scan_frequency_rules": [{"scan_type": "STATIC", "frequency": "QUARTERLY"}],
+				"sev5_grace_period": 0,
+				"sev4_grace_period": 30,
+				"sca_grace_periods": {"sev4_grace_period": 60}
+			}`), nil
+		},
+	}
+
+	policy, err := NewService(client).GetPolicy("policy-1")
+	if err != nil {
+		t.Fatalf("GetPolicy failed: %v", err)
+	}
+	if policy.Name != "Veracode Recommended High" || len(policy.FindingRules) != 2 {
+		t.Fatalf("Unexpected policy %+v", policy)
+	}
+	if rule := policy.FindingRules[1]; rule.Type != RuleLicenseRisk || rule.AdvancedOptions == nil || !rule.AdvancedOptions.AllLicensesMustMeetRequirement {
+		t.Errorf("Unexpected license rule %+v", rule)
+	}
+	if rule := policy.ScanFrequencyRules[0]; rule.Frequency != FrequencyQuarterly {
+		t.Errorf("Unexpected scan frequency rule %+v", rule)
+	}
+	if got := policy.GracePeriod(4, "STATIC"); got != 30 {
+		t.Errorf("GracePeriod(4, STATIC) = %d, want 30", got)
+	}
+	if got := policy.GracePeriod(4, "SCA"); got != 60 {
+		t.Errorf("GracePeriod(4, SCA) = %d, want 60", got)
+	}
+}
+
+func TestGetPolicies(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != policiesBasePath || params.Get("name") != "Recommended" || params.Get("size") != "50" {
+				t.Errorf("Unexpected request %s %v", urlPath, params)
+			}
+			return []byte(`{"_embedded": {"policy_versions": [{"guid": "policy-1"}, {"guid": "policy-2"}]}}`), nil
+		},
+	}
+
+	result, err := NewService(client).GetPolicies(&GetPoliciesOptions{Name: "Recommended", Size: 50})
+	if err != nil {
+		t.Fatalf("GetPolicies failed: %v", err)
+	}
+	if result.Embedded == nil || len(result.Embedded.PolicyVersions) != 2 {
+		t.Errorf("Expected two policies, got %+v", result)
+	}
+}
+
+func TestGetAllPoliciesPages(t *testing.T) {
+	var pages []string
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if params.Get("category") != "APPLICATION" || params.Get("size") != "500" {
+				t.Errorf("Unexpected request %s %v", urlPath, params)
+			}
+			page := params.Get("page")
+			pages = append(pages, page)
+			return []byte(fmt.Sprintf(`{
+				"_embedded": {"policy_versions": [{"guid": "policy-%s", "name": "Policy %s"}]},
+				"page": {"number": 0, "size": 500, "total_elements": 2, "total_pages": 2}
+			}`, page, page)), nil
+		},
+	}
+
+	all, err := NewService(client).GetAllPolicies("APPLICATION")
+	if err != nil {
+		t.Fatalf("GetAllPolicies failed: %v", err)
+	}
+	if len(all) != 2 || all[1].GUID != "policy-1" {
+		t.Errorf("Unexpected policies %+v", all)
+	}
+	if len(pages) != 2 || pages[0] != "" || pages[1] != "1" {
+		t.Errorf("Expected pages 0 and 1 to be requested, got %q", pages)
+	}
+}
@@ -0,0 +1,12 @@
+// Package reports provides a client for the Veracode Summary Report API.
+//
+// A summary report describes the latest scans of an application or sandbox: the score and rating
+// of the static, dynamic and manual analyses, flaw counts by severity and category, flaw status
+// changes since the previous scan, SCA component results and the policy evaluation.
+//
+// Example usage:
+//
+//	service := reports.NewService(client)
+//	report, err := service.GetSummaryReport(app.GUID, "")
+//	fmt.Println(report.PolicyComplianceStatus, report.StaticAnalysis.Score)
+package reports
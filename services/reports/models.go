@@ -0,0 +1,211 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SummaryReport is the summary of the latest scans of an application or sandbox
+type SummaryReport struct {
+	AppID                  int64  `json:"app_id,omitempty"`
+	AppName                string `json:"app_name,omitempty"`
+	BuildID                int64  `json:"build_id,omitempty"`
+	Version                string `json:"version,omitempty"`
+	SandboxID              int64  `json:"sandbox_id,omitempty"`
+	SandboxName            string `json:"sandbox_name,omitempty"`
+	IsLatestBuild          bool   `json:"is_latest_build,omitempty"`
+	BusinessCriticality    string `json:"business_criticality,omitempty"`
+	BusinessUnit           string `json:"business_unit,omitempty"`
+	BusinessOwner          string `json:"business_owner,omitempty"`
+	Teams                  string `json:"teams,omitempty"`
+	Tags                   string `json:"tags,omitempty"`
+	GenerationDate         string `json:"generation_date,omitempty"`
+	LastUpdateTime         string `json:"last_update_time,omitempty"`
+	PolicyName             string `json:"policy_name,omitempty"`
+	PolicyVersion          int    `json:"policy_version,omitempty"`
+	PolicyComplianceStatus string `json:"policy_compliance_status,omitempty"`
+	PolicyRulesStatus      string `json:"policy_rules_status,omitempty"`
+	GracePeriodExpired     bool   `json:"grace_period_expired,omitempty"`
+	ScanOverdue            string `json:"scan_overdue,omitempty"` // "true" or "false"
+
+	StaticAnalysis  *Analysis         `json:"static-analysis,omitempty"`
+	DynamicAnalysis *Analysis         `json:"dynamic-analysis,omitempty"`
+	ManualAnalysis  *Analysis         `json:"manual-analysis,omitempty"`
+	Severities      []SeverityLevel   `json:"severity,omitempty"`
+	FlawStatus      *FlawStatus       `json:"flaw-status,omitempty"`
+	SCA             *SoftwareAnalysis `json:"software_composition_analysis,omitempty"`
+}
+
+// Analysis is the result of the static, dynamic or manual analysis of a scan
+type Analysis struct {
+	Rating            string   `json:"rating,omitempty"`
+	Score             int      `json:"score,omitempty"`
+	Version           string   `json:"version,omitempty"`
+	SubmittedDate     string   `json:"submitted_date,omitempty"`
+	PublishedDate     string   `json:"published_date,omitempty"`
+	NextScanDue       string   `json:"next_scan_due,omitempty"`
+	AnalysisSizeBytes int64    `json:"analysis_size_bytes,omitempty"`
+	EngineVersion     string   `json:"engine_version,omitempty"`
+	DynamicScanType   string   `json:"dynamic_scan_type,omitempty"`
+	ScanExitStatus    string   `json:"scan_exit_status,omitempty"`
+	Modules           *Modules `json:"modules,omitempty"`
+}
+
+// Modules wraps the modules of an analysis
+type Modules struct {
+	Module []Module `json:"module,omitempty"`
+}
+
+// Module is a scanned module with its flaw counts by severity
+type Module struct {
+	Name         string `json:"name,omitempty"`
+	Compiler     string `json:"compiler,omitempty"`
+	OS           string `json:"os,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
+	LOC          int64  `json:"loc,omitempty"`
+	Score        int    `json:"score,omitempty"`
+	NumFlawsSev0 int    `json:"numflawssev0"`
+	NumFlawsSev1 int    `json:"numflawssev1"`
+	NumFlawsSev2 int    `json:"numflawssev2"`
+	NumFlawsSev3 int    `json:"numflawssev3"`
+	NumFlawsSev4 int    `json:"numflawssev4"`
+	NumFlawsSev5 int    `json:"numflawssev5"`
+}
+
+// SeverityLevel lists the flaw categories found at a severity level (0-5)
+type SeverityLevel struct {
+	Level    int        `json:"level"`
+	Category []Category `json:"category,omitempty"`
+}
+
+// Category is the number of flaws of a category at a severity
+type Category struct {
+	CategoryName string `json:"categoryname,omitempty"`
+	Severity     string `json:"severity,omitempty"`
+	Count        int    `json:"count"`
+}
+
+// FlawStatus counts flaws by their status relative to the previous scan
+type FlawStatus struct {
+	New                      int `json:"new"`
+	Reopen                   int `json:"reopen"`
+	Open                     int `json:"open"`
+	CannotReproduce          int `json:"cannot-reproduce"`
+	Fixed                    int `json:"fixed"`
+	Total                    int `json:"total"`
+	NotMitigated             int `json:"not_mitigated"`
+	Sev1Change               int `json:"sev-1-change"`
+	Sev2Change               int `json:"sev-2-change"`
+	Sev3Change               int `json:"sev-3-change"`
+	Sev4Change               int `json:"sev-4-change"`
+	Sev5Change               int `json:"sev-5-change"`
+	ConformsToGuidelines     int `json:"conforms_to_guidelines"`
+	DeviatesFromGuidelines   int `json:"deviates_from_guidelines"`
+	TotalReviewedMitigations int `json:"total_reviewed_mitigations"`
+}
+
+// SoftwareAnalysis is the result of the software composition analysis of a scan
+type SoftwareAnalysis struct {
+	ThirdPartyComponents     int                   `json:"third_party_components"`
+	ViolatePolicy            bool                  `json:"violate_policy"`
+	ComponentsViolatedPolicy int                   `json:"components_violated_policy"`
+	BlocklistedComponents    int                   `json:"blacklisted_components"`
+	VulnerableComponents     *VulnerableComponents `json:"vulnerable_components,omitempty"`
+}
+
+// VulnerableComponents wraps the vulnerable components of a software composition analysis
+type VulnerableComponents struct {
+	Component []Component `json:"component,omitempty"`
+}
+
+// Component is a vulnerable third party component
+type Component struct {
+	ComponentID                      string               `json:"component_id,omitempty"`
+	FileName                         string               `json:"file_name,omitempty"`
+	Library                          string               `json:"library,omitempty"`
+	Vendor                           string               `json:"vendor,omitempty"`
+	Version                          string               `json:"version,omitempty"`
+	MaxCVSSScore                     string               `json:"max_cvss_score,omitempty"`
+	Vulnerabilities                  int                  `json:"vulnerabilities"`
+	ComponentAffectsPolicyCompliance bool                 `json:"component_affects_policy_compliance,omitempty"`
+	ViolatedPolicyRules              *ViolatedPolicyRules `json:"violated_policy_rules,omitempty"`
+}
+
+// ViolatedPolicyRules wraps the policy rules a component violates
+type ViolatedPolicyRules struct {
+	ComponentPolicyRule []ComponentPolicyRule `json:"component_policy_rule,omitempty"`
+}
+
+// ComponentPolicyRule is a policy rule violated by a component
+type ComponentPolicyRule struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+	Desc  string `json:"desc,omitempty"`
+}
+
+// IsScanOverdue reports whether a scan required by the policy is overdue
+func (r *SummaryReport) IsScanOverdue() bool {
+	return strings.EqualFold(r.ScanOverdue, "true")
+}
+
+// Analyses returns the analyses present in the report by name: Static, Dynamic and Manual
+func (r *SummaryReport) Analyses() []NamedAnalysis {
+	var list []NamedAnalysis
+	for _, a := range []NamedAnalysis{{"Static", r.StaticAnalysis}, {"Dynamic", r.DynamicAnalysis}, {"Manual", r.ManualAnalysis}} {
+		if a.Analysis != nil {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+// NamedAnalysis is an analysis with the name of its kind
+type NamedAnalysis struct {
+	Name string
+	*Analysis
+}
+
+// FlawCounts returns the number of flaws at each severity, indexed by severity (0-5)
+func (r *SummaryReport) FlawCounts() [6]int {
+	var counts [6]int
+	for _, level := range r.Severities {
+		if level.Level < 0 || level.Level >= len(counts) {
+			continue
+		}
+		for _, category := range level.Category {
+			counts[level.Level] += category.Count
+		}
+	}
+	return counts
+}
+
+// PolicyRuleResult is the status of one of the policy requirements reported by the summary report
+type PolicyRuleResult struct {
+	Rule   string
+	Passed bool
+	Detail string
+}
+
+// PolicyRules returns the status of the policy requirements: the finding rules, the grace periods,
+// the scan frequency and, when there is a software composition analysis, the component rules
+func (r *SummaryReport) PolicyRules() []PolicyRuleResult {
+	rules := []PolicyRuleResult{
+		{Rule: "Finding rules", Passed: strings.EqualFold(r.PolicyRulesStatus, "Pass") || strings.EqualFold(r.PolicyRulesStatus, "Passed"), Detail: r.PolicyRulesStatus},
+		{Rule: "Grace periods", Passed: !r.GracePeriodExpired, Detail: "Within grace period"},
+		{Rule: "Scan frequency", Passed: !r.IsScanOverdue(), Detail: "Scans up to date"},
+	}
+	if r.GracePeriodExpired {
+		rules[1].Detail = "Grace period expired"
+	}
+	if r.IsScanOverdue() {
+		rules[2].Detail = "Scan overdue"
+	}
+	if r.SCA != nil {
+		rule := PolicyRuleResult{Rule: "Component rules", Passed: !r.SCA.ViolatePolicy, Detail: "No components violate the policy"}
+		if r.SCA.ViolatePolicy {
+			rule.Detail = fmt.Sprintf("%d components violate the policy", r.SCA.ComponentsViolatedPolicy)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,53 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	applicationsBasePath = "/appsec/v2/applications"
+)
+
+// Service provides methods to interact with the Veracode Summary Report API
+type Service struct {
+	client HTTPClient
+}
+
+// HTTPClient interface for making HTTP requests
+type HTTPClient interface {
+	DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error)
+}
+
+func NewService(client HTTPClient) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// GetSummaryReport retrieves the summary report of an application's latest policy scan, or of a
+// sandbox's latest scan when sandboxGUID is set
+func (s *Service) GetSummaryReport(appGUID, sandboxGUID string) (*SummaryReport, error) {
+	if appGUID == "" {
+		return nil, fmt.Errorf("appGUID is required")
+	}
+
+	params := url.Values{}
+	if sandboxGUID != "" {
+		params.Add("context", sandboxGUID)
+	}
+
+	urlPath := fmt.Sprintf("%s/%s/summary_report", applicationsBasePath, appGUID)
+	body, err := s.client.DoRequestWithQueryParams("GET", urlPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SummaryReport
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse summary report response: %w", err)
+	}
+
+	return &result, nil
+}
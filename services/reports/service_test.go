@@ -0,0 +1,106 @@
+package reports
+
+import (
+	"net/url"
+	"testing"
+)
+
+// MockHTTPClient is a mock implementation of HTTPClient for testing
+type MockHTTPClient struct {
+	DoRequestWithQueryParamsFunc func(method, urlPath string, params url.Values) ([]byte, error)
+}
+
+func (m *MockHTTPClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	if m.DoRequestWithQueryParamsFunc != nil {
+		return m.DoRequestWithQueryParamsFunc(method, urlPath, params)
+	}
+	return []byte("{}"), nil
+}
+
+const testReport = `{
+	"app_name": "Verademo",
+	"sandbox_name": "Release",
+	"policy_name": "Veracode Recommended High",
+	"policy_compliance_status": "Did Not Pass",
+	"policy_rules_status": "Did Not Pass",
+	"grace_period_expired": false,
+	"scan_overdue": "true",
+	"static-analysis": {
+		"rating": "C", "score": 72, "published_date": "2025-06-01 10:00:00 UTC",
+		"modules": {"module": [{"name": "app.war", "numflawssev5": 1, "numflawssev3": 4}]}
+	},
+	"dynamic-analysis": {"rating": "B", "score": 85},
+	"severity": [
+		{"level": 5, "category": [{"categoryname": "SQL Injection", "severity": "Very High", "count": 1}]},
+		{"level": 3, "category": [
+			{"categoryname": "CRLF Injection", "severity": "Medium", "count": 3},
+			{"categoryname": "Information Leakage", "severity": "Medium", "count": 1}
+		]},
+		{"level": 0, "category": []}
+	],
+	"flaw-status": {"new": 2, "reopen": 1, "open": 5, "fixed": 3, "total": 5, "not_mitigated": 5},
+	"software_composition_analysis": {"third_party_components": 40, "violate_policy": true, "components_violated_policy": 2}
+}`
+
+func TestGetSummaryReport(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if urlPath != applicationsBasePath+"/app-1/summary_report" || params.Get("context") != "sandbox-1" {
+				t.Errorf("Unexpected request %s %v", urlPath, params)
+			}
+			return []byte(testReport), nil
+		},
+	}
+
+	report, err := NewService(client).GetSummaryReport("app-1", "sandbox-1")
+	if err != nil {
+		t.Fatalf("GetSummaryReport failed: %v", err)
+	}
+	if report.StaticAnalysis == nil || report.StaticAnalysis.Score != 72 || len(report.StaticAnalysis.Modules.Module) != 1 {
+		t.Fatalf("Unexpected static analysis %+v", report.StaticAnalysis)
+	}
+	if analyses := report.Analyses(); len(analyses) != 2 || analyses[1].Name != "Dynamic" || analyses[1].Rating != "B" {
+		t.Errorf("Expected the static and dynamic analyses, got %+v", analyses)
+	}
+	if counts := report.FlawCounts(); counts != [6]int{0, 0, 0, 4, 0, 1} {
+		t.Errorf("Unexpected flaw counts %v", counts)
+	}
+	if report.FlawStatus == nil || report.FlawStatus.New != 2 || report.FlawStatus.Fixed != 3 {
+		t.Errorf("Unexpected flaw status %+v", report.FlawStatus)
+	}
+
+	rules := report.PolicyRules()
+	if len(rules) != 4 {
+		t.Fatalf("Expected four policy rule results, got %+v", rules)
+	}
+	for i, passed := range []bool{false, true, false, false} {
+		if rules[i].Passed != passed {
+			t.Errorf("%s: passed = %v, want %v", rules[i].Rule, rules[i].Passed, passed)
+		}
+	}
+}
+
+func TestGetSummaryReportPolicyContext(t *testing.T) {
+	client := &MockHTTPClient{
+		DoRequestWithQueryParamsFunc: func(method, urlPath string, params url.Values) ([]byte, error) {
+			if params.Has("context") {
+				t.Errorf("The policy report must not send a context, got %v", params)
+			}
+			return []byte(`{"policy_rules_status": "Pass", "scan_overdue": "false"}`), nil
+		},
+	}
+
+	report, err := NewService(client).GetSummaryReport("app-1", "")
+	if err != nil {
+		t.Fatalf("GetSummaryReport failed: %v", err)
+	}
+	for _, rule := range report.PolicyRules() {
+		if !rule.Passed {
+			t.Errorf("Expected %s to pass", rule.Rule)
+		}
+	}
+
+	if _, err := NewService(client).GetSummaryReport("", ""); err == nil {
+		t.Error("Expected an error without an application GUID")
+	}
+}
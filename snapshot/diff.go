@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Change is a finding whose state differs between two snapshots. Before is nil for new
+// findings and After is nil for findings no longer reported.
+type Change struct {
+	IssueID int64   `json:"issue_id"`
+	Before  *Record `json:"before,omitempty"`
+	After   *Record `json:"after,omitempty"`
+}
+
+// Record returns the latest known state of the finding
+func (c *Change) Record() *Record {
+	if c.After != nil {
+		return c.After
+	}
+	return c.Before
+}
+
+// Diff classifies the changes between two snapshots, keyed on IssueID. A finding can appear in
+// more than one category, for example when it is reopened with a different severity.
+type Diff struct {
+	From      *Snapshot `json:"-"`
+	To        *Snapshot `json:"-"`
+	FromID    string    `json:"from"`
+	ToID      string    `json:"to"`
+	ScanTypes []string  `json:"scan_types"` // Scan types present in both snapshots
+
+	New             []Change `json:"new"`              // Open now, not present before
+	Fixed           []Change `json:"fixed"`            // Open before, closed or no longer reported now
+	Reopened        []Change `json:"reopened"`         // Closed before, open now
+	NewlyMitigated  []Change `json:"newly_mitigated"`  // Mitigation approved since
+	SeverityChanged []Change `json:"severity_changed"` // Severity differs
+}
+
+// Empty reports whether nothing changed
+func (d *Diff) Empty() bool {
+	return len(d.New)+len(d.Fixed)+len(d.Reopened)+len(d.NewlyMitigated)+len(d.SeverityChanged) == 0
+}
+
+// Compare returns the changes from one snapshot to a later one. Only scan types recorded in both
+// snapshots are compared, so a scan type missing from one of them is not reported as fixed or new.
+func Compare(from, to *Snapshot) *Diff {
+	diff := &Diff{From: from, To: to, FromID: from.ID, ToID: to.ID}
+	for _, scanType := range to.ScanTypes {
+		if slices.Contains(from.ScanTypes, scanType) {
+			diff.ScanTypes = append(diff.ScanTypes, scanType)
+		}
+	}
+
+	before := indexRecords(from, diff.ScanTypes)
+	after := indexRecords(to, diff.ScanTypes)
+
+	// Walk the findings in IssueID order (snapshots are sorted) so the diff is stable
+	for i := range to.Findings {
+		now := &to.Findings[i]
+		if !slices.Contains(diff.ScanTypes, now.ScanType) {
+			continue
+		}
+		old, existed := before[now.IssueID]
+
+		switch {
+		case !existed:
+			if now.IsOpen() {
+				diff.New = append(diff.New, Change{IssueID: now.IssueID, After: now})
+			}
+			continue
+		case old.IsOpen() && !now.IsOpen():
+			diff.Fixed = append(diff.Fixed, Change{IssueID: now.IssueID, Before: old, After: now})
+		case !old.IsOpen() && now.IsOpen():
+			diff.Reopened = append(diff.Reopened, Change{IssueID: now.IssueID, Before: old, After: now})
+		}
+
+		if !old.IsMitigated() && now.IsMitigated() {
+			diff.NewlyMitigated = append(diff.NewlyMitigated, Change{IssueID: now.IssueID, Before: old, After: now})
+		}
+		if old.Severity != now.Severity {
+			diff.SeverityChanged = append(diff.SeverityChanged, Change{IssueID: now.IssueID, Before: old, After: now})
+		}
+	}
+
+	for i := range from.Findings {
+		old := &from.Findings[i]
+		if !slices.Contains(diff.ScanTypes, old.ScanType) {
+			continue
+		}
+		if _, stillReported := after[old.IssueID]; !stillReported && old.IsOpen() {
+			diff.Fixed = append(diff.Fixed, Change{IssueID: old.IssueID, Before: old})
+		}
+	}
+	slices.SortFunc(diff.Fixed, func(a, b Change) int {
+		return cmp.Compare(a.IssueID, b.IssueID)
+	})
+
+	return diff
+}
+
+func indexRecords(snap *Snapshot, scanTypes []string) map[int64]*Record {
+	index := make(map[int64]*Record, len(snap.Findings))
+	for i := range snap.Findings {
+		if slices.Contains(scanTypes, snap.Findings[i].ScanType) {
+			index[snap.Findings[i].IssueID] = &snap.Findings[i]
+		}
+	}
+	return index
+}
+
+// Category is a named group of changes
+type Category struct {
+	Name    string
+	Changes []Change
+}
+
+// Categories returns the change groups in display order
+func (d *Diff) Categories() []Category {
+	return []Category{
+		{Name: "New", Changes: d.New},
+		{Name: "Fixed", Changes: d.Fixed},
+		{Name: "Reopened", Changes: d.Reopened},
+		{Name: "Newly mitigated", Changes: d.NewlyMitigated},
+		{Name: "Severity changed", Changes: d.SeverityChanged},
+	}
+}
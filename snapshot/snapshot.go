@@ -0,0 +1,191 @@
+// Package snapshot records the findings of an application context over time and
+// compares two recordings to show what changed between scans.
+package snapshot
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+// idFormat is the layout of snapshot IDs, which are also their file names
+const idFormat = "20060102-150405.000"
+
+// Snapshot is the state of an application context's findings at a point in time
+type Snapshot struct {
+	ID          string    `json:"id"`
+	AppGUID     string    `json:"app_guid"`
+	AppName     string    `json:"app_name,omitempty"`
+	SandboxGUID string    `json:"sandbox_guid,omitempty"` // Empty for the policy scan
+	SandboxName string    `json:"sandbox_name,omitempty"`
+	ScanTypes   []string  `json:"scan_types"`
+	TakenAt     time.Time `json:"taken_at"`
+	Findings    []Record  `json:"findings"`
+}
+
+// Record is the part of a finding needed to compare snapshots
+type Record struct {
+	IssueID          int64     `json:"issue_id"`
+	ScanType         string    `json:"scan_type"`
+	Severity         int       `json:"severity"`
+	CWE              int       `json:"cwe,omitempty"`
+	Title            string    `json:"title,omitempty"`
+	Location         string    `json:"location,omitempty"`
+	Status           string    `json:"status,omitempty"`
+	ResolutionStatus string    `json:"resolution_status,omitempty"`
+	ViolatesPolicy   bool      `json:"violates_policy,omitempty"`
+	FirstFound       time.Time `json:"first_found,omitzero"` // UTC, so that records compare equal after a round trip
+	LastSeen         time.Time `json:"last_seen,omitzero"`
+}
+
+// IsOpen reports whether the finding was open (not closed) in the snapshot
+func (r *Record) IsOpen() bool {
+	return r.Status != string(findings.StatusClosed)
+}
+
+// IsMitigated reports whether the finding had an approved mitigation in the snapshot
+func (r *Record) IsMitigated() bool {
+	return r.ResolutionStatus == string(findings.ResolutionApproved)
+}
+
+// New builds a snapshot of findings taken at the given time. Findings are sorted by IssueID
+// so that identical states produce identical snapshots.
+func New(appGUID, appName, sandboxGUID, sandboxName string, scanTypes []string, list []findings.Finding, takenAt time.Time) *Snapshot {
+	records := make([]Record, 0, len(list))
+	for i := range list {
+		records = append(records, newRecord(&list[i]))
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].IssueID < records[j].IssueID
+	})
+
+	types := slices.Clone(scanTypes)
+	for i := range types {
+		types[i] = strings.ToUpper(types[i])
+	}
+	sort.Strings(types)
+
+	return &Snapshot{
+		ID:          takenAt.UTC().Format(idFormat),
+		AppGUID:     appGUID,
+		AppName:     appName,
+		SandboxGUID: sandboxGUID,
+		SandboxName: sandboxName,
+		ScanTypes:   types,
+		TakenAt:     takenAt,
+		Findings:    records,
+	}
+}
+
+// Update returns a snapshot of the base snapshot's context taken at takenAt, in which updated
+// findings replace the records with the same issue ID and new findings are added. It records
+// incremental changes, such as a report of the findings updated since the last snapshot, without
+// fetching every finding again. Findings of scan types the base does not cover are ignored.
+func Update(base *Snapshot, updated []findings.Finding, takenAt time.Time) *Snapshot {
+	records := make(map[int64]Record, len(base.Findings))
+	for _, record := range base.Findings {
+		records[record.IssueID] = record
+	}
+	for i := range updated {
+		f := &updated[i]
+		if slices.Contains(base.ScanTypes, string(f.ScanType)) {
+			records[f.IssueID] = newRecord(f)
+		}
+	}
+
+	snap := &Snapshot{
+		ID:          takenAt.UTC().Format(idFormat),
+		AppGUID:     base.AppGUID,
+		AppName:     base.AppName,
+		SandboxGUID: base.SandboxGUID,
+		SandboxName: base.SandboxName,
+		ScanTypes:   slices.Clone(base.ScanTypes),
+		TakenAt:     takenAt,
+		Findings:    make([]Record, 0, len(records)),
+	}
+	for _, record := range records {
+		snap.Findings = append(snap.Findings, record)
+	}
+	sort.Slice(snap.Findings, func(i, j int) bool {
+		return snap.Findings[i].IssueID < snap.Findings[j].IssueID
+	})
+	return snap
+}
+
+func newRecord(f *findings.Finding) Record {
+	record := Record{
+		IssueID:        f.IssueID,
+		ScanType:       string(f.ScanType),
+		Severity:       f.Severity(),
+		CWE:            f.CWEID(),
+		Title:          f.CWEName(),
+		Status:         string(f.Status()),
+		ViolatesPolicy: f.ViolatesPolicy,
+	}
+	if f.FindingStatus != nil {
+		record.ResolutionStatus = string(f.FindingStatus.ResolutionStatus)
+		if f.FindingStatus.FirstFoundDate != nil {
+			record.FirstFound = f.FindingStatus.FirstFoundDate.UTC()
+		}
+		if f.FindingStatus.LastSeenDate != nil {
+			record.LastSeen = f.FindingStatus.LastSeenDate.UTC()
+		}
+	}
+
+	switch f.ScanType {
+	case findings.ScanTypeSCA:
+		record.Title = f.CVE()
+		record.Location = strings.TrimSpace(f.Component() + " " + f.ComponentVersion())
+	case findings.ScanTypeDynamic:
+		record.Location = f.URL()
+	default:
+		record.Location = f.FilePath()
+		if line := f.FileLine(); line > 0 {
+			record.Location = fmt.Sprintf("%s:%d", record.Location, line)
+		}
+	}
+	return record
+}
+
+// ContextName returns the sandbox name of the snapshot, or "Policy Scan"
+func (s *Snapshot) ContextName() string {
+	if s.SandboxGUID == "" {
+		return "Policy Scan"
+	}
+	return s.SandboxName
+}
+
+// OpenCount returns the number of open findings in the snapshot
+func (s *Snapshot) OpenCount() int {
+	count := 0
+	for i := range s.Findings {
+		if s.Findings[i].IsOpen() {
+			count++
+		}
+	}
+	return count
+}
+
+// SameFindings reports whether two snapshots cover the same scan types and record identical findings
+func (s *Snapshot) SameFindings(other *Snapshot) bool {
+	return slices.Equal(s.ScanTypes, other.ScanTypes) && slices.Equal(s.Findings, other.Findings)
+}
+
+// Describe returns a one-line summary of the finding: CWE or CVE, title and location
+func (r *Record) Describe() string {
+	var parts []string
+	if r.CWE > 0 {
+		parts = append(parts, fmt.Sprintf("CWE-%d", r.CWE))
+	}
+	if r.Title != "" {
+		parts = append(parts, r.Title)
+	}
+	if r.Location != "" {
+		parts = append(parts, r.Location)
+	}
+	return strings.Join(parts, "  ")
+}
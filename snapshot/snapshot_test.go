@@ -0,0 +1,221 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+)
+
+func decodeFindings(t *testing.T, data string) []findings.Finding {
+	t.Helper()
+	var list []findings.Finding
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatalf("Failed to decode findings: %v", err)
+	}
+	return list
+}
+
+var (
+	firstRun = `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 4, "cwe": {"id": 89, "name": "SQL Injection"}, "file_path": "a.java", "file_line_number": 10}},
+		{"issue_id": 2, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 3}},
+		{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"}, "finding_details": {"severity": 3}},
+		{"issue_id": 4, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 2}},
+		{"issue_id": 5, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 2}}
+	]`
+	secondRun = `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"}, "finding_details": {"severity": 4}},
+		{"issue_id": 3, "scan_type": "STATIC", "finding_status": {"status": "REOPENED"}, "finding_details": {"severity": 4}},
+		{"issue_id": 4, "scan_type": "STATIC", "finding_status": {"status": "OPEN", "resolution_status": "APPROVED"}, "finding_details": {"severity": 2}},
+		{"issue_id": 5, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 2}},
+		{"issue_id": 6, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5}}
+	]`
+)
+
+func issueIDs(changes []Change) []int64 {
+	ids := make([]int64, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.IssueID)
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, category string, changes []Change, want ...int64) {
+	t.Helper()
+	got := issueIDs(changes)
+	if len(got) != len(want) {
+		t.Errorf("%s: got %v, want %v", category, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s: got %v, want %v", category, got, want)
+			return
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	from := New("app", "App", "", "", []string{"static"}, decodeFindings(t, firstRun), start)
+	to := New("app", "App", "", "", []string{"STATIC"}, decodeFindings(t, secondRun), start.Add(24*time.Hour))
+
+	diff := Compare(from, to)
+	assertIDs(t, "new", diff.New, 6)
+	assertIDs(t, "fixed", diff.Fixed, 1, 2)
+	assertIDs(t, "reopened", diff.Reopened, 3)
+	assertIDs(t, "newly mitigated", diff.NewlyMitigated, 4)
+	assertIDs(t, "severity changed", diff.SeverityChanged, 3)
+
+	if diff.Fixed[1].After != nil {
+		t.Error("Expected a finding no longer reported to have no After record")
+	}
+	if diff.Empty() {
+		t.Error("Expected a non-empty diff")
+	}
+}
+
+func TestCompareIgnoresScanTypesMissingFromOneSnapshot(t *testing.T) {
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	from := New("app", "App", "", "", []string{"STATIC"}, decodeFindings(t, firstRun), start)
+	to := New("app", "App", "", "", []string{"SCA"}, nil, start.Add(time.Hour))
+
+	if diff := Compare(from, to); !diff.Empty() {
+		t.Errorf("Expected no changes across different scan types, got %+v", diff)
+	}
+}
+
+func TestUpdateMergesChangedFindings(t *testing.T) {
+	base := New("app", "App", "", "", []string{"STATIC"}, decodeFindings(t, firstRun), time.Now())
+	changed := decodeFindings(t, `[
+		{"issue_id": 1, "scan_type": "STATIC", "finding_status": {"status": "CLOSED"}, "finding_details": {"severity": 4}},
+		{"issue_id": 6, "scan_type": "STATIC", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5}},
+		{"issue_id": 7, "scan_type": "SCA", "finding_status": {"status": "OPEN"}, "finding_details": {"severity": 5}}
+	]`)
+
+	updated := Update(base, changed, base.TakenAt.Add(time.Hour))
+	if len(updated.Findings) != 6 || updated.AppGUID != "app" || updated.ID == base.ID {
+		t.Fatalf("Expected six findings in a new snapshot of the same context, got %+v", updated)
+	}
+	if updated.Findings[0].IsOpen() || updated.Findings[5].IssueID != 6 {
+		t.Errorf("Expected issue 1 closed and issue 6 added, got %+v", updated.Findings)
+	}
+	if len(base.Findings) != 5 || !base.Findings[0].IsOpen() {
+		t.Error("Expected the base snapshot to be unchanged")
+	}
+
+	diff := Compare(base, updated)
+	assertIDs(t, "new", diff.New, 6)
+	assertIDs(t, "fixed", diff.Fixed, 1)
+}
+
+func TestStoreSaveSkipsUnchangedAndLists(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	first := New("app", "App", "sb-1", "Feature", []string{"STATIC"}, decodeFindings(t, firstRun), start)
+	if saved, err := store.Save(first); err != nil || !saved {
+		t.Fatalf("Expected first snapshot to be saved, got %v, %v", saved, err)
+	}
+
+	same := New("app", "App", "sb-1", "Feature", []string{"STATIC"}, decodeFindings(t, firstRun), start.Add(time.Minute))
+	if saved, err := store.Save(same); err != nil || saved {
+		t.Errorf("Expected unchanged snapshot to be skipped, got %v, %v", saved, err)
+	}
+
+	second := New("app", "App", "sb-1", "Feature", []string{"STATIC"}, decodeFindings(t, secondRun), start.Add(time.Hour))
+	if saved, err := store.Save(second); err != nil || !saved {
+		t.Fatalf("Expected changed snapshot to be saved, got %v, %v", saved, err)
+	}
+
+	snapshots, err := store.List("app", "sb-1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].ID != first.ID || snapshots[1].ID != second.ID {
+		t.Fatalf("Expected the two saved snapshots oldest first, got %d", len(snapshots))
+	}
+
+	found, err := store.Find("app", "sb-1", second.ID)
+	if err != nil || found.OpenCount() != 4 {
+		t.Errorf("Expected to find the second snapshot with 4 open findings, got %v", err)
+	}
+	if _, err := store.Find("app", "sb-1", "missing"); err == nil {
+		t.Error("Expected an error for a missing snapshot")
+	}
+
+	if policy, err := store.List("app", ""); err != nil || len(policy) != 0 {
+		t.Errorf("Expected no policy snapshots, got %d, %v", len(policy), err)
+	}
+}
+
+func TestLatestReadsNewestFirst(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	static := New("app", "App", "", "", []string{"STATIC"}, decodeFindings(t, firstRun), start)
+	sca := New("app", "App", "", "", []string{"SCA"}, decodeFindings(t, secondRun), start.Add(time.Hour))
+	for _, snap := range []*Snapshot{static, sca} {
+		if _, err := store.Save(snap); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	// An older unreadable file is never reached once a newer snapshot matches
+	if err := os.WriteFile(filepath.Join(dir, "app", "policy", "20000101-000000.000.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := store.Latest("app", "", []string{"STATIC"})
+	if err != nil || latest == nil || latest.ID != static.ID {
+		t.Fatalf("Expected the STATIC snapshot, got %v, %v", latest, err)
+	}
+	if latest, err := store.Latest("app", "", []string{"DYNAMIC"}); err == nil || latest != nil {
+		t.Errorf("Expected the unreadable file to be reached without a match, got %v, %v", latest, err)
+	}
+}
+
+func TestTrendReconstructsDaysWithoutSnapshots(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 12, 0, 0, 0, time.UTC) }
+	latest := &Snapshot{
+		ScanTypes: []string{"STATIC"},
+		TakenAt:   day(5),
+		Findings: []Record{
+			{IssueID: 1, ScanType: "STATIC", Severity: 5, Status: "OPEN", FirstFound: day(1), LastSeen: day(5)},
+			{IssueID: 2, ScanType: "STATIC", Severity: 3, Status: "CLOSED", FirstFound: day(1), LastSeen: day(2)},
+			{IssueID: 3, ScanType: "STATIC", Severity: 4, Status: "OPEN", ResolutionStatus: "APPROVED", FirstFound: day(3), LastSeen: day(5)},
+		},
+	}
+	earlier := &Snapshot{
+		ScanTypes: []string{"STATIC"},
+		TakenAt:   day(2),
+		Findings: []Record{
+			{IssueID: 1, ScanType: "STATIC", Severity: 5, Status: "OPEN"},
+		},
+	}
+
+	points := Trend([]*Snapshot{earlier, latest}, day(5), 5)
+	if len(points) != 5 || !points[0].Day.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected 5 daily points from May 1, got %d", len(points))
+	}
+
+	// May 1: reconstructed, issues 1 and 2 open
+	if got := points[0].Open.Total(); got != 2 || points[0].Recorded {
+		t.Errorf("May 1: expected 2 open (reconstructed), got %d (recorded %v)", got, points[0].Recorded)
+	}
+	// May 2: the earlier snapshot is used as recorded
+	if got := points[1].Open; got.Total() != 1 || got[5] != 1 || !points[1].Recorded {
+		t.Errorf("May 2: expected the recorded snapshot with one severity 5 finding, got %v", got)
+	}
+	// May 3: issue 2 closed after it was last seen, issue 3 found and mitigated
+	if points[2].Open.Total() != 1 || points[2].Closed[3] != 1 || points[2].Mitigated[4] != 1 {
+		t.Errorf("May 3: unexpected counts %+v", points[2])
+	}
+	if !points[4].Recorded {
+		t.Error("May 5: expected the latest snapshot to be used as recorded")
+	}
+}
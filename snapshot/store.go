@@ -0,0 +1,140 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// policyContext is the directory name used for policy scan snapshots
+const policyContext = "policy"
+
+// Store keeps snapshots on disk under <dir>/<app GUID>/<sandbox GUID or "policy">/<ID>.json
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store that keeps its files in dir. Directories are created on first save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) contextDir(appGUID, sandboxGUID string) string {
+	context := sandboxGUID
+	if context == "" {
+		context = policyContext
+	}
+	return filepath.Join(s.dir, filepath.Base(appGUID), filepath.Base(context))
+}
+
+// Save writes a snapshot unless it records the same findings as the latest snapshot of the same
+// scan types. It reports whether the snapshot was written.
+func (s *Store) Save(snap *Snapshot) (bool, error) {
+	latest, err := s.Latest(snap.AppGUID, snap.SandboxGUID, snap.ScanTypes)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && latest.SameFindings(snap) {
+		return false, nil
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	dir := s.contextDir(snap.AppGUID, snap.SandboxGUID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return false, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, snap.ID+".json"), data, 0o600); err != nil {
+		return false, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return true, nil
+}
+
+// List returns the snapshots of an application context, oldest first
+func (s *Store) List(appGUID, sandboxGUID string) ([]*Snapshot, error) {
+	dir := s.contextDir(appGUID, sandboxGUID)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var snapshots []*Snapshot
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		snap, err := readSnapshot(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].TakenAt.Before(snapshots[j].TakenAt)
+	})
+	return snapshots, nil
+}
+
+// Latest returns the most recent snapshot covering exactly the given scan types, or nil if there
+// is none. IDs are UTC timestamps, so the files are read newest first by name and only until one
+// matches, keeping Save cheap however long the history grows.
+func (s *Store) Latest(appGUID, sandboxGUID string, scanTypes []string) (*Snapshot, error) {
+	dir := s.contextDir(appGUID, sandboxGUID)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// ReadDir returns the files sorted by name
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		snap, err := readSnapshot(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if slices.Equal(snap.ScanTypes, scanTypes) {
+			return snap, nil
+		}
+	}
+	return nil, nil
+}
+
+// Find returns the snapshot of an application context with the given ID
+func (s *Store) Find(appGUID, sandboxGUID, id string) (*Snapshot, error) {
+	snap, err := readSnapshot(filepath.Join(s.contextDir(appGUID, sandboxGUID), filepath.Base(id)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot %s not found", id)
+	}
+	return snap, err
+}
+
+func readSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", filepath.Base(path), err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,141 @@
+package snapshot
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxSeverity is the highest finding severity; Counts are indexed by severity 0 to MaxSeverity
+const MaxSeverity = 5
+
+// Counts holds a number of findings per severity
+type Counts [MaxSeverity + 1]int
+
+// Total returns the number of findings of all severities
+func (c *Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+func (c *Counts) add(severity int) {
+	c[min(max(severity, 0), MaxSeverity)]++
+}
+
+// Point is the state of an application context's findings at the end of one day
+type Point struct {
+	Day       time.Time
+	Open      Counts // Open and not mitigated
+	Closed    Counts
+	Mitigated Counts // Open with an approved mitigation
+	Recorded  bool   // A snapshot was taken that day for every scan type; otherwise the point is reconstructed
+}
+
+// Trend returns one point per day for the given number of days up to and including the day of end.
+//
+// A day with a snapshot uses the counts recorded in the latest snapshot of that day. Other days are
+// reconstructed from the latest snapshot using each finding's first found and last seen dates: a finding
+// is counted from the day it was first found, and a closed finding is counted as closed after the day it
+// was last seen. Mitigation dates are not known, so reconstructed days use the latest mitigation status.
+func Trend(snapshots []*Snapshot, end time.Time, days int) []Point {
+	if days <= 0 {
+		return nil
+	}
+
+	// The latest snapshot covering each scan type is the basis for reconstructed days
+	latest := latestByScanType(snapshots)
+	scanTypes := make([]string, 0, len(latest))
+	for scanType := range latest {
+		scanTypes = append(scanTypes, scanType)
+	}
+	slices.Sort(scanTypes)
+
+	year, month, day := end.Date()
+	lastDay := time.Date(year, month, day, 0, 0, 0, 0, end.Location())
+
+	points := make([]Point, days)
+	for i := range points {
+		dayStart := lastDay.AddDate(0, 0, i-days+1)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		point := &points[i]
+		point.Day = dayStart
+		point.Recorded = len(scanTypes) > 0
+
+		for _, scanType := range scanTypes {
+			if recorded := takenOn(snapshots, scanType, dayStart, dayEnd); recorded != nil {
+				point.addRecorded(recorded, scanType)
+				continue
+			}
+			point.Recorded = false
+			point.addReconstructed(latest[scanType], scanType, dayStart, dayEnd)
+		}
+	}
+	return points
+}
+
+// latestByScanType returns the most recent snapshot covering each scan type
+func latestByScanType(snapshots []*Snapshot) map[string]*Snapshot {
+	latest := make(map[string]*Snapshot)
+	for _, snap := range snapshots {
+		for _, scanType := range snap.ScanTypes {
+			if current, ok := latest[scanType]; !ok || snap.TakenAt.After(current.TakenAt) {
+				latest[scanType] = snap
+			}
+		}
+	}
+	return latest
+}
+
+// takenOn returns the latest snapshot covering the scan type taken in [start, end), or nil
+func takenOn(snapshots []*Snapshot, scanType string, start, end time.Time) *Snapshot {
+	var found *Snapshot
+	for _, snap := range snapshots {
+		if snap.TakenAt.Before(start) || !snap.TakenAt.Before(end) || !slices.Contains(snap.ScanTypes, scanType) {
+			continue
+		}
+		if found == nil || snap.TakenAt.After(found.TakenAt) {
+			found = snap
+		}
+	}
+	return found
+}
+
+func (p *Point) addRecorded(snap *Snapshot, scanType string) {
+	for i := range snap.Findings {
+		record := &snap.Findings[i]
+		if record.ScanType != scanType {
+			continue
+		}
+		switch {
+		case !record.IsOpen():
+			p.Closed.add(record.Severity)
+		case record.IsMitigated():
+			p.Mitigated.add(record.Severity)
+		default:
+			p.Open.add(record.Severity)
+		}
+	}
+}
+
+func (p *Point) addReconstructed(snap *Snapshot, scanType string, dayStart, dayEnd time.Time) {
+	for i := range snap.Findings {
+		record := &snap.Findings[i]
+		if record.ScanType != scanType {
+			continue
+		}
+		// Without a first found date the finding is assumed to have existed all along
+		if !record.FirstFound.IsZero() && !record.FirstFound.Before(dayEnd) {
+			continue
+		}
+		switch {
+		case !record.IsOpen() && (record.LastSeen.IsZero() || record.LastSeen.Before(dayStart)):
+			p.Closed.add(record.Severity)
+		case record.IsMitigated():
+			p.Mitigated.add(record.Severity)
+		default:
+			p.Open.add(record.Severity)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/identity"
 	"github.com/dipsylala/veracode-tui/veracode"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -44,6 +45,7 @@ func (ui *UI) showApplicationDetail() {
 		SetDirection(tview.FlexColumn).
 		AddItem(ui.appInfoView, 0, 1, false).
 		AddItem(ui.complianceView, 0, 1, false).
+		AddItem(ui.trendView, 0, 1, false).
 		AddItem(ui.recentScansView, 0, 1, false)
 
 	ui.detailFlex = tview.NewFlex().
@@ -52,15 +54,7 @@ func (ui *UI) showApplicationDetail() {
 		AddItem(topRow, 0, 0, false).
 		AddItem(ui.contextsTable, 0, 1, true)
 
-	// Create keyboard shortcuts bar
-	shortcutsBar := tview.NewTextView().
-		SetDynamicColors(true).
-		SetTextAlign(tview.AlignCenter).
-		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Enter/Double-click[-] View Findings  [%s]ESC[-] Back  [%s]q[-] Quit",
-			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
-	shortcutsBar.SetBorder(false)
-
-	ui.detailFlex.AddItem(shortcutsBar, 1, 0, false)
+	ui.detailFlex.AddItem(ui.createApplicationDetailShortcutsBar(), 1, 0, false)
 
 	// Set up input handlers
 	ui.setupApplicationDetailInputHandlers()
@@ -108,6 +102,22 @@ func (ui *UI) showApplicationDetail() {
 	ui.app.SetFocus(ui.contextsTable)
 }
 
+// createApplicationDetailShortcutsBar creates the keyboard shortcuts bar of the application detail
+// view. The sandbox actions are only shown when the principal may manage sandboxes.
+func (ui *UI) createApplicationDetailShortcutsBar() *tview.TextView {
+	sandboxShortcuts := ""
+	if ui.capabilities.Can(identity.CapabilityManageSandboxes) {
+		sandboxShortcuts = fmt.Sprintf("[%s]n/r/D[-] New/Edit/Delete Sandbox  [%s]P[-] Promote  ", ui.theme.Info, ui.theme.Info)
+	}
+	shortcutsBar := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Enter/Double-click[-] View Findings  [%s]G[-] SLA  [%s]l[-] Licenses  [%s]p[-] Policy  [%s]c[-] Compare with Policy  %s[%s]R[-] Summary Report  [%s]H[-] HTML Report  [%s]ESC[-] Back  [%s]q[-] Quit",
+			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, sandboxShortcuts, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
+	shortcutsBar.SetBorder(false)
+	return shortcutsBar
+}
+
 // initializeApplicationDetailViews creates the detail view components
 func (ui *UI) initializeApplicationDetailViews() {
 	ui.appInfoView = tview.NewTextView().
@@ -119,6 +129,19 @@ func (ui *UI) initializeApplicationDetailViews() {
 		SetDynamicColors(true).
 		SetScrollable(true)
 	ui.complianceView.SetBorder(true).SetTitle(" Status & Compliance ").SetTitleAlign(tview.AlignLeft)
+	// Clicking the compliance box opens the policy rules
+	ui.complianceView.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseLeftClick {
+			ui.showApplicationPolicy()
+			return action, nil
+		}
+		return action, event
+	})
+
+	ui.trendView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	ui.trendView.SetBorder(true).SetTitle(" Findings Trend ").SetTitleAlign(tview.AlignLeft)
 
 	ui.recentScansView = tview.NewTextView().
 		SetDynamicColors(true).
@@ -147,9 +170,47 @@ func (ui *UI) setupApplicationDetailInputHandlers() {
 			ui.app.SetFocus(ui.applicationsTable)
 			return nil
 		case tcell.KeyRune:
-			if event.Rune() == 'q' {
+			// Sandbox actions are not offered unless the principal may manage sandboxes
+			switch event.Rune() {
+			case 'n', 'r', 'D', 'P':
+				if !ui.capabilities.Can(identity.CapabilityManageSandboxes) {
+					return nil
+				}
+			}
+			switch event.Rune() {
+			case 'q':
 				ui.app.Stop()
 				return nil
+			case 'G':
+				ui.showApplicationSLA()
+				return nil
+			case 'l':
+				ui.showApplicationLicenses()
+				return nil
+			case 'c':
+				ui.showContextComparison()
+				return nil
+			case 'p':
+				ui.showApplicationPolicy()
+				return nil
+			case 'n':
+				ui.showCreateSandbox()
+				return nil
+			case 'r':
+				ui.showEditSandbox()
+				return nil
+			case 'D':
+				ui.showDeleteSandbox()
+				return nil
+			case 'P':
+				ui.showPromoteSandbox()
+				return nil
+			case 'R':
+				ui.showSummaryReport()
+				return nil
+			case 'H':
+				ui.showHTMLReport()
+				return nil
 			}
 		}
 		return event
@@ -169,6 +230,11 @@ func (ui *UI) setupApplicationDetailInputHandlers() {
 		}
 	})
 
+	// Show the trend of the highlighted context
+	ui.contextsTable.SetSelectionChangedFunc(func(row, column int) {
+		ui.updateTrendView(row)
+	})
+
 	// Add double-click support
 	ui.contextsTable.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
 		if action == tview.MouseLeftDoubleClick {
@@ -219,6 +285,9 @@ func (ui *UI) updateApplicationDetailViews() {
 	if recentScansLines > topRowHeight {
 		topRowHeight = recentScansLines
 	}
+	if trendViewHeight > topRowHeight {
+		topRowHeight = trendViewHeight
+	}
 
 	// Update the flex layout with the calculated height
 	if ui.detailFlex != nil {
@@ -233,15 +302,10 @@ func (ui *UI) updateApplicationDetailViews() {
 			SetDirection(tview.FlexColumn).
 			AddItem(ui.appInfoView, 0, 1, false).
 			AddItem(ui.complianceView, 0, 1, false).
+			AddItem(ui.trendView, 0, 1, false).
 			AddItem(ui.recentScansView, 0, 1, false)
 
-		// Create keyboard shortcuts bar
-		shortcutsBar := tview.NewTextView().
-			SetDynamicColors(true).
-			SetTextAlign(tview.AlignCenter).
-			SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Enter/Double-click[-] View Findings  [%s]ESC[-] Back  [%s]q[-] Quit",
-				ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
-		shortcutsBar.SetBorder(false)
+		shortcutsBar := ui.createApplicationDetailShortcutsBar()
 
 		// Clear and rebuild the detail flex
 		ui.detailFlex.Clear()
@@ -360,19 +424,9 @@ func (ui *UI) buildComplianceContent() string {
 
 			// Policy compliance status with color coding
 			status := policy.PolicyComplianceStatus
-			var statusColor string
-			switch status {
-			case "PASSED", "PASS":
-				statusColor = ui.theme.PolicyPass
-			case "DID_NOT_PASS", "FAIL":
-				statusColor = ui.theme.PolicyFail
-			case "CONDITIONAL_PASS":
-				statusColor = ui.theme.Warning
-			default:
-				statusColor = ui.theme.SecondaryText
-			}
-			compliance.WriteString(fmt.Sprintf("[%s]Policy Compliance:[-] [%s]%s[-]\n", ui.theme.Label, statusColor, status))
+			compliance.WriteString(fmt.Sprintf("[%s]Policy Compliance:[-] [%s]%s[-]\n", ui.theme.Label, ui.complianceColor(status), status))
 		}
+		compliance.WriteString(fmt.Sprintf("[%s]p[-] Policy rules\n", ui.theme.Info))
 	} else {
 		compliance.WriteString(fmt.Sprintf("[%s]Policy Name:[-] %s\n", ui.theme.Label, TextNotAvailable))
 		compliance.WriteString(fmt.Sprintf("[%s]Policy Compliance:[-] No policy scans found\n", ui.theme.Label))
@@ -461,7 +515,11 @@ func (ui *UI) updateContextsTable() {
 			name := sandbox.Name
 			ui.contextsTable.SetCell(rowNum, 0, tview.NewTableCell(name).SetExpansion(1))
 			ui.contextsTable.SetCell(rowNum, 1, tview.NewTableCell(sandbox.OwnerUsername).SetExpansion(1))
-			ui.contextsTable.SetCell(rowNum, 2, tview.NewTableCell(sandbox.Created.Format("2006-01-02")).SetExpansion(1))
+			created := "-"
+			if sandbox.Created != nil {
+				created = sandbox.Created.Format("2006-01-02")
+			}
+			ui.contextsTable.SetCell(rowNum, 2, tview.NewTableCell(created).SetExpansion(1))
 
 			modified := "-"
 			if sandbox.Modified != nil {
@@ -0,0 +1,408 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// profileChoices are the business units, policies and teams that can be assigned in the
+// application form
+type profileChoices struct {
+	businessUnits []applications.BusinessUnit
+	policies      []applications.AppPolicy
+	teams         []applications.AppTeam
+}
+
+// loadProfileChoices loads the organization's business units and teams from the Identity API
+// and its application policies, each sorted by name
+func (ui *UI) loadProfileChoices() (profileChoices, error) {
+	ctx := context.Background()
+	businessUnits, err := ui.identityService.GetAllBusinessUnits(ctx)
+	if err != nil {
+		return profileChoices{}, fmt.Errorf("failed to load business units: %w", err)
+	}
+	teams, err := ui.identityService.GetAllTeams(ctx)
+	if err != nil {
+		return profileChoices{}, fmt.Errorf("failed to load teams: %w", err)
+	}
+	appPolicies, err := ui.policiesService.GetAllPolicies(policies.CategoryApplication)
+	if err != nil {
+		return profileChoices{}, fmt.Errorf("failed to load policies: %w", err)
+	}
+	return newProfileChoices(businessUnits, appPolicies, teams), nil
+}
+
+// newProfileChoices converts the Identity and Policy API entries to the references of an
+// application profile
+func newProfileChoices(businessUnits []identity.BusinessUnit, appPolicies []policies.Policy, teams []identity.Team) profileChoices {
+	var choices profileChoices
+	for _, bu := range businessUnits {
+		choices.businessUnits = append(choices.businessUnits, applications.BusinessUnit{GUID: bu.BUID, ID: bu.BULegacyID, Name: bu.BUName})
+	}
+	for _, policy := range appPolicies {
+		choices.policies = append(choices.policies, applications.AppPolicy{GUID: policy.GUID, Name: policy.Name})
+	}
+	for _, team := range teams {
+		choices.teams = append(choices.teams, applications.AppTeam{GUID: team.TeamID, TeamID: team.TeamLegacyID, TeamName: team.TeamName})
+	}
+	slices.SortFunc(choices.businessUnits, func(a, b applications.BusinessUnit) int { return compareFold(a.Name, b.Name) })
+	slices.SortFunc(choices.policies, func(a, b applications.AppPolicy) int { return compareFold(a.Name, b.Name) })
+	slices.SortFunc(choices.teams, func(a, b applications.AppTeam) int { return compareFold(a.TeamName, b.TeamName) })
+	return choices
+}
+
+// withProfile returns the choices with the business unit, policies and teams of profile added
+// when the lists do not include them, for instance because the user may not list them, so that
+// saving the form keeps them
+func (c profileChoices) withProfile(profile applications.ApplicationProfile) profileChoices {
+	out := profileChoices{
+		businessUnits: slices.Clone(c.businessUnits),
+		policies:      slices.Clone(c.policies),
+		teams:         slices.Clone(c.teams),
+	}
+	if bu := profile.BusinessUnit; bu != nil && bu.GUID != "" &&
+		!slices.ContainsFunc(out.businessUnits, func(b applications.BusinessUnit) bool { return b.GUID == bu.GUID }) {
+		out.businessUnits = append(out.businessUnits, *bu)
+		slices.SortFunc(out.businessUnits, func(a, b applications.BusinessUnit) int { return compareFold(a.Name, b.Name) })
+	}
+	for _, policy := range profile.Policies {
+		if policy.GUID != "" && !slices.ContainsFunc(out.policies, func(p applications.AppPolicy) bool { return p.GUID == policy.GUID }) {
+			out.policies = append(out.policies, policy)
+		}
+	}
+	slices.SortFunc(out.policies, func(a, b applications.AppPolicy) int { return compareFold(a.Name, b.Name) })
+	for _, team := range profile.Teams {
+		if team.GUID != "" && !slices.ContainsFunc(out.teams, func(t applications.AppTeam) bool { return t.GUID == team.GUID }) {
+			out.teams = append(out.teams, team)
+		}
+	}
+	slices.SortFunc(out.teams, func(a, b applications.AppTeam) int { return compareFold(a.TeamName, b.TeamName) })
+	return out
+}
+
+// indexes returns the dropdown indexes of the business unit and first policy of profile, where 0
+// is "None" and "Default"
+func (c profileChoices) indexes(profile applications.ApplicationProfile) (buIndex, policyIndex int) {
+	if profile.BusinessUnit != nil {
+		buIndex = slices.IndexFunc(c.businessUnits, func(b applications.BusinessUnit) bool { return b.GUID == profile.BusinessUnit.GUID }) + 1
+	}
+	if len(profile.Policies) > 0 {
+		policyIndex = slices.IndexFunc(c.policies, func(p applications.AppPolicy) bool { return p.GUID == profile.Policies[0].GUID }) + 1
+	}
+	return buIndex, policyIndex
+}
+
+// apply sets the business unit and first policy chosen in the dropdowns on profile. As an update
+// replaces the whole profile, a dropdown left at the profile's current value changes nothing, and
+// the policies after the first are always kept.
+func (c profileChoices) apply(profile *applications.ApplicationProfile, buIndex, policyIndex int) {
+	currentBU, currentPolicy := c.indexes(*profile)
+	if buIndex != currentBU {
+		profile.BusinessUnit = nil
+		if buIndex > 0 {
+			bu := c.businessUnits[buIndex-1]
+			profile.BusinessUnit = &bu
+		}
+	}
+	if policyIndex != currentPolicy {
+		var rest []applications.AppPolicy
+		if len(profile.Policies) > 1 {
+			rest = profile.Policies[1:]
+		}
+		// "Default" leaves the choice to the platform
+		profile.Policies = rest
+		if policyIndex > 0 {
+			profile.Policies = append([]applications.AppPolicy{c.policies[policyIndex-1]}, rest...)
+		}
+	}
+}
+
+// selectedListApplication returns the application highlighted in the applications list
+func (ui *UI) selectedListApplication() *applications.Application {
+	row, _ := ui.applicationsTable.GetSelection()
+	if row > 0 && row-1 < len(ui.applications) {
+		return &ui.applications[row-1]
+	}
+	return nil
+}
+
+// showCreateApplication opens the form to create an application
+func (ui *UI) showCreateApplication() {
+	ui.openApplicationForm(nil)
+}
+
+// showEditApplication opens the form to edit the profile of the highlighted application
+func (ui *UI) showEditApplication() {
+	if app := ui.selectedListApplication(); app != nil {
+		ui.openApplicationForm(app)
+	}
+}
+
+// openApplicationForm loads the profile choices in the background and then shows the form
+func (ui *UI) openApplicationForm(app *applications.Application) {
+	ui.statusBar.SetText(fmt.Sprintf(" [%s]Loading business units, policies and teams...[-]", ui.theme.Pending))
+	go func() {
+		choices, err := ui.loadProfileChoices()
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ui.statusBar.SetText(fmt.Sprintf(" [%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			ui.updateStatusBar()
+			ui.showApplicationForm(app, choices)
+		})
+	}()
+}
+
+// showApplicationForm shows the profile form for app, or for a new application when app is nil.
+// The business unit, policy and teams are chosen from those of the organization.
+//
+//nolint:gocyclo // Form construction with many optional profile fields
+func (ui *UI) showApplicationForm(app *applications.Application, choices profileChoices) {
+	profile := applications.ApplicationProfile{BusinessCriticality: string(applications.BusinessCriticalityHigh)}
+	title := " Create Application "
+	if app != nil {
+		if app.Profile != nil {
+			profile = *app.Profile
+		}
+		title = fmt.Sprintf(" Edit %s ", tview.Escape(appDisplayName(app)))
+	}
+
+	criticalities := make([]string, len(applications.BusinessCriticalities))
+	for i, c := range applications.BusinessCriticalities {
+		criticalities[i] = string(c)
+	}
+
+	choices = choices.withProfile(profile)
+	buIndex, policyIndex := choices.indexes(profile)
+	buOptions := []string{"None"}
+	for _, bu := range choices.businessUnits {
+		buOptions = append(buOptions, bu.Name)
+	}
+	policyOptions := []string{"Default"}
+	for _, policy := range choices.policies {
+		policyOptions = append(policyOptions, policy.Name)
+	}
+
+	teamNames := make([]string, len(profile.Teams))
+	for i, team := range profile.Teams {
+		teamNames[i] = team.TeamName
+	}
+	teamsText := strings.Join(teamNames, ", ")
+	customFieldsText := formatCustomFields(profile.CustomFields)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Tab[-] Navigate  [%s]Enter[-] Select  [%s]ESC[-] Cancel", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	form := ui.newStyledForm()
+	form.AddInputField("Name", profile.Name, 50, nil, func(text string) {
+		profile.Name = strings.TrimSpace(text)
+	}).
+		AddInputField("Description", profile.Description, 50, nil, func(text string) {
+			profile.Description = strings.TrimSpace(text)
+		}).
+		AddDropDown("Business Criticality", criticalities, dropDownIndex(criticalities, profile.BusinessCriticality), func(option string, _ int) {
+			profile.BusinessCriticality = option
+		}).
+		AddDropDown("Business Unit", buOptions, buIndex, func(_ string, index int) {
+			buIndex = index
+		}).
+		AddDropDown("Policy", policyOptions, policyIndex, func(_ string, index int) {
+			policyIndex = index
+		}).
+		AddInputField("Teams (comma separated)", teamsText, 50, nil, func(text string) {
+			teamsText = text
+		}).
+		AddInputField("Tags", profile.Tags, 50, nil, func(text string) {
+			profile.Tags = strings.TrimSpace(text)
+		}).
+		AddInputField("Custom Fields (name=value; ...)", customFieldsText, 50, nil, func(text string) {
+			customFieldsText = text
+		})
+
+	closeForm := func() {
+		ui.pages.RemovePage("app-edit")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+	showError := func(message string) {
+		statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(message)))
+	}
+
+	form.AddButton("Save", func() {
+		if profile.Name == "" {
+			showError("An application name is required")
+			return
+		}
+		teams, err := resolveTeams(teamsText, choices.teams)
+		if err != nil {
+			showError(err.Error())
+			return
+		}
+		customFields, err := parseCustomFields(customFieldsText)
+		if err != nil {
+			showError(err.Error())
+			return
+		}
+
+		profile.Teams = teams
+		profile.CustomFields = customFields
+		choices.apply(&profile, buIndex, policyIndex)
+
+		statusText.SetText(fmt.Sprintf("[%s]Saving...[-]", ui.theme.Pending))
+		go ui.saveApplication(app, profile, closeForm, showError)
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("app-edit", modal(content, 4, 6), true, true)
+	ui.app.SetFocus(form)
+}
+
+// saveApplication creates or updates an application and reloads the applications list
+func (ui *UI) saveApplication(app *applications.Application, profile applications.ApplicationProfile, closeForm func(), showError func(string)) {
+	var err error
+	action := "Created"
+	if app == nil {
+		_, err = ui.appService.CreateApplication(&profile)
+	} else {
+		action = "Updated"
+		_, err = ui.appService.UpdateApplication(app.GUID, &profile)
+	}
+	if err != nil {
+		ui.app.QueueUpdateDraw(func() {
+			showError(fmt.Sprintf("Failed to save application: %v", err))
+		})
+		return
+	}
+
+	ui.app.QueueUpdateDraw(closeForm)
+	ui.loadApplications()
+	ui.app.QueueUpdateDraw(func() {
+		ui.statusBar.SetText(fmt.Sprintf(" [%s]%s application %s[-]", ui.theme.Success, action, tview.Escape(profile.Name)))
+	})
+}
+
+// showDeleteApplication asks for the name of the highlighted application before deleting it
+func (ui *UI) showDeleteApplication() {
+	app := ui.selectedListApplication()
+	if app == nil {
+		return
+	}
+	name := appDisplayName(app)
+	typed := ""
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]This deletes the application, its sandboxes and all scan results[-]", ui.theme.Warning))
+
+	form := ui.newStyledForm()
+	form.AddInputField(fmt.Sprintf("Type %q to confirm", name), "", 40, nil, func(text string) {
+		typed = text
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("app-delete")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	form.AddButton("Delete", func() {
+		if typed != name {
+			statusText.SetText(fmt.Sprintf("[%s]The name does not match the application name[-]", ui.theme.Error))
+			return
+		}
+		statusText.SetText(fmt.Sprintf("[%s]Deleting...[-]", ui.theme.Pending))
+		guid := app.GUID
+		go func() {
+			if err := ui.appService.DeleteApplication(guid); err != nil {
+				ui.app.QueueUpdateDraw(func() {
+					statusText.SetText(fmt.Sprintf("[%s]Failed to delete application: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				})
+				return
+			}
+			ui.app.QueueUpdateDraw(closeForm)
+			ui.loadApplications()
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText(fmt.Sprintf(" [%s]Deleted application %s[-]", ui.theme.Success, tview.Escape(name)))
+			})
+		}()
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Delete %s ", tview.Escape(name))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.Error))
+
+	ui.pages.AddPage("app-delete", modal(content, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// resolveTeams converts comma-separated team names into the known teams with those names
+func resolveTeams(text string, known []applications.AppTeam) ([]applications.AppTeam, error) {
+	var teams []applications.AppTeam
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		index := slices.IndexFunc(known, func(team applications.AppTeam) bool {
+			return strings.EqualFold(team.TeamName, name)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("unknown team %q", name)
+		}
+		teams = append(teams, known[index])
+	}
+	return teams, nil
+}
+
+// formatCustomFields renders custom fields as "name=value; name=value"
+func formatCustomFields(fields []applications.CustomNameValue) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Name + "=" + field.Value
+	}
+	return strings.Join(parts, "; ")
+}
+
+// parseCustomFields parses custom fields written as "name=value; name=value"
+func parseCustomFields(text string) ([]applications.CustomNameValue, error) {
+	var fields []applications.CustomNameValue
+	for _, part := range strings.Split(text, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("custom field %q must be written as name=value", part)
+		}
+		fields = append(fields, applications.CustomNameValue{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+	return fields, nil
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+)
+
+// recordingClient records the body of each write to the Applications API
+type recordingClient struct {
+	method, urlPath string
+	body            []byte
+}
+
+func (c *recordingClient) DoRequestWithQueryParams(method, urlPath string, params url.Values) ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+func (c *recordingClient) DoRequestWithBody(method, urlPath string, body []byte, params url.Values) ([]byte, error) {
+	c.method, c.urlPath, c.body = method, urlPath, body
+	return []byte("{}"), nil
+}
+
+func TestApplicationFormKeepsUnlistedBusinessUnitAndPolicies(t *testing.T) {
+	profile := applications.ApplicationProfile{
+		Name:                "Payments",
+		BusinessCriticality: string(applications.BusinessCriticalityHigh),
+		BusinessUnit:        &applications.BusinessUnit{GUID: "bu-hidden", Name: "Hidden BU"},
+		Policies: []applications.AppPolicy{
+			{GUID: "policy-hidden", Name: "Hidden Policy"},
+			{GUID: "policy-second", Name: "Second Policy"},
+		},
+	}
+	// The lists the user may read include neither the business unit nor the policies
+	listed := profileChoices{
+		businessUnits: []applications.BusinessUnit{{GUID: "bu-1", Name: "Listed BU"}},
+		policies:      []applications.AppPolicy{{GUID: "policy-1", Name: "Listed Policy"}},
+	}
+
+	choices := listed.withProfile(profile)
+	buIndex, policyIndex := choices.indexes(profile)
+	if buIndex == 0 || policyIndex == 0 {
+		t.Fatalf("Expected the current business unit and policy to be selected, got %d and %d", buIndex, policyIndex)
+	}
+
+	// Save without touching the dropdowns
+	choices.apply(&profile, buIndex, policyIndex)
+	client := &recordingClient{}
+	if _, err := applications.NewService(client).UpdateApplication("app-1", &profile); err != nil {
+		t.Fatalf("UpdateApplication failed: %v", err)
+	}
+
+	var request struct {
+		Profile applications.ApplicationProfile `json:"profile"`
+	}
+	if err := json.Unmarshal(client.body, &request); err != nil {
+		t.Fatalf("Invalid request body %s: %v", client.body, err)
+	}
+	sent := request.Profile
+	if client.method != "PUT" || sent.BusinessUnit == nil || sent.BusinessUnit.GUID != "bu-hidden" {
+		t.Errorf("Expected the business unit to be kept in %s %s", client.method, client.body)
+	}
+	if len(sent.Policies) != 2 || sent.Policies[0].GUID != "policy-hidden" || sent.Policies[1].GUID != "policy-second" {
+		t.Errorf("Expected both policies to be kept, got %s", client.body)
+	}
+
+	// Choosing another policy replaces only the first one
+	choices.apply(&profile, buIndex, 1+indexOfPolicy(choices, "policy-1"))
+	if len(profile.Policies) != 2 || profile.Policies[0].GUID != "policy-1" || profile.Policies[1].GUID != "policy-second" {
+		t.Errorf("Expected the first policy to be replaced, got %+v", profile.Policies)
+	}
+}
+
+func indexOfPolicy(choices profileChoices, guid string) int {
+	for i, policy := range choices.policies {
+		if policy.GUID == guid {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/applications"
+)
+
+// applicationColumn describes a column of the applications table
+type applicationColumn struct {
+	info    columnInfo
+	value   func(app *applications.Application) string
+	compare func(a, b *applications.Application) int
+}
+
+// defaultApplicationsLayout sorts by last modified, most recent first
+var defaultApplicationsLayout = config.TableLayout{SortColumn: "modified", SortDescending: true}
+
+// applicationColumns returns all columns available in the applications table
+func (ui *UI) applicationColumns() []applicationColumn {
+	return []applicationColumn{
+		{
+			info: columnInfo{key: "name", title: "Application Name", sortable: true},
+			value: func(app *applications.Application) string {
+				name := applicationName(app)
+				if len(name) > 40 {
+					name = name[:40] + "..."
+				}
+				return name
+			},
+			compare: func(a, b *applications.Application) int {
+				return strings.Compare(strings.ToLower(applicationName(a)), strings.ToLower(applicationName(b)))
+			},
+		},
+		{
+			info:  columnInfo{key: "created", title: "Created", sortable: true},
+			value: func(app *applications.Application) string { return formatDate(app.Created) },
+			compare: func(a, b *applications.Application) int {
+				return compareTimes(a.Created, b.Created)
+			},
+		},
+		{
+			info:  columnInfo{key: "modified", title: "Last Modified", sortable: true},
+			value: func(app *applications.Application) string { return formatDate(app.Modified) },
+			compare: func(a, b *applications.Application) int {
+				return compareTimes(a.Modified, b.Modified)
+			},
+		},
+		{
+			info:  columnInfo{key: "last-scan", title: "Last Scan", sortable: true},
+			value: func(app *applications.Application) string { return formatDate(app.LastCompletedScanDate) },
+			compare: func(a, b *applications.Application) int {
+				return compareTimes(a.LastCompletedScanDate, b.LastCompletedScanDate)
+			},
+		},
+		{
+			info:  columnInfo{key: "policy-status", title: "Policy Status", sortable: true},
+			value: applicationPolicyStatus,
+			compare: func(a, b *applications.Application) int {
+				return policyComplianceRank(applicationPolicyStatus(a)) - policyComplianceRank(applicationPolicyStatus(b))
+			},
+		},
+		{
+			info:  columnInfo{key: "scan-status", title: "Scan Status", sortable: true},
+			value: applicationScanStatus,
+			compare: func(a, b *applications.Application) int {
+				return strings.Compare(applicationScanStatus(a), applicationScanStatus(b))
+			},
+		},
+	}
+}
+
+// applicationColumnInfos returns the column descriptions used for sorting and the column chooser
+func applicationColumnInfos(columns []applicationColumn) []columnInfo {
+	infos := make([]columnInfo, len(columns))
+	for i, column := range columns {
+		infos[i] = column.info
+	}
+	return infos
+}
+
+// visibleApplicationColumns returns the columns not hidden by the layout
+func (ui *UI) visibleApplicationColumns(layout config.TableLayout) []applicationColumn {
+	var visible []applicationColumn
+	for _, column := range ui.applicationColumns() {
+		if !layout.IsHidden(column.info.key) {
+			visible = append(visible, column)
+		}
+	}
+	return visible
+}
+
+// applicationsLayout returns the current applications table layout
+func (ui *UI) applicationsLayout() config.TableLayout {
+	return ui.tableLayout(applicationsTableName, defaultApplicationsLayout)
+}
+
+// setApplicationsLayout persists a new applications table layout and re-renders the table,
+// keeping the selected application selected
+func (ui *UI) setApplicationsLayout(layout config.TableLayout) {
+	ui.saveTableLayout(applicationsTableName, layout)
+
+	selectedGUID := ""
+	if row, _ := ui.applicationsTable.GetSelection(); row > 0 && row-1 < len(ui.applications) {
+		selectedGUID = ui.applications[row-1].GUID
+	}
+
+	ui.sortApplications()
+	ui.renderApplicationsTable()
+
+	for i := range ui.applications {
+		if ui.applications[i].GUID == selectedGUID {
+			ui.applicationsTable.Select(i+1, 0)
+			break
+		}
+	}
+}
+
+// sortApplications sorts the current page of applications by the layout's sort column. The
+// Applications API has no sort parameter, so other pages are not taken into account; the sort
+// column header says "(this page)" when there is more than one page.
+func (ui *UI) sortApplications() {
+	layout := ui.applicationsLayout()
+
+	var compare func(a, b *applications.Application) int
+	for _, column := range ui.applicationColumns() {
+		if column.info.key == layout.SortColumn {
+			compare = column.compare
+			break
+		}
+	}
+	if compare == nil {
+		return
+	}
+
+	sort.SliceStable(ui.applications, func(i, j int) bool {
+		return applySortDirection(compare(&ui.applications[i], &ui.applications[j]), layout.SortDescending) < 0
+	})
+}
+
+// applicationName returns the profile name of an application
+func applicationName(app *applications.Application) string {
+	if app.Profile == nil {
+		return "Unknown"
+	}
+	return app.Profile.Name
+}
+
+// applicationPolicyStatus returns the compliance status of the application's first policy
+func applicationPolicyStatus(app *applications.Application) string {
+	if app.Profile != nil && len(app.Profile.Policies) > 0 {
+		return app.Profile.Policies[0].PolicyComplianceStatus
+	}
+	return TextNotAvailable
+}
+
+// applicationScanStatus returns the status of the application's most recent scan
+func applicationScanStatus(app *applications.Application) string {
+	if len(app.Scans) > 0 {
+		return app.Scans[0].Status
+	}
+	return TextNotAvailable
+}
+
+// policyComplianceRank orders compliance statuses from passing to failing, unknown statuses last
+func policyComplianceRank(status string) int {
+	for i, known := range applications.PolicyComplianceStatuses {
+		if string(known) == status {
+			return i
+		}
+	}
+	return len(applications.PolicyComplianceStatuses)
+}
+
+// formatDate formats an optional timestamp as a date
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return TextNotAvailable
+	}
+	return t.Format("2006-01-02")
+}
+
+// compareTimes compares two optional timestamps; missing timestamps sort first
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
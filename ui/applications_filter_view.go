@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// filterOptionAny is the dropdown option that disables a filter
+const filterOptionAny = "Any"
+
+// applicationFilters holds the server-side filters applied to the applications list
+type applicationFilters struct {
+	BusinessUnit     string
+	Team             string
+	Tag              string
+	Policy           string
+	PolicyCompliance string
+	ScanType         string
+	ScanStatus       string
+	ModifiedAfter    string // Format: yyyy-MM-dd
+	CustomFieldName  string
+	CustomFieldValue string
+}
+
+// apply copies the active filters onto the API request options
+func (f *applicationFilters) apply(opts *applications.GetApplicationsOptions) {
+	opts.BusinessUnit = f.BusinessUnit
+	opts.Team = f.Team
+	opts.Tag = f.Tag
+	opts.Policy = f.Policy
+	opts.PolicyCompliance = f.PolicyCompliance
+	opts.ScanType = f.ScanType
+	opts.ModifiedAfter = f.ModifiedAfter
+	if f.ScanStatus != "" {
+		opts.ScanStatus = []string{f.ScanStatus}
+	}
+	if f.CustomFieldName != "" && f.CustomFieldValue != "" {
+		opts.CustomFieldNames = []string{f.CustomFieldName}
+		opts.CustomFieldValues = []string{f.CustomFieldValue}
+	}
+}
+
+// isEmpty reports whether no filters are active
+func (f *applicationFilters) isEmpty() bool {
+	return f.describe() == ""
+}
+
+// describe returns a short summary of the active filters for the status bar
+func (f *applicationFilters) describe() string {
+	var parts []string
+	add := func(label, value string) {
+		if value != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", label, value))
+		}
+	}
+
+	add("Business Unit", f.BusinessUnit)
+	add("Team", f.Team)
+	add("Tag", f.Tag)
+	add("Policy", f.Policy)
+	add("Compliance", f.PolicyCompliance)
+	add("Scan Type", f.ScanType)
+	add("Scan Status", f.ScanStatus)
+	add("Modified After", f.ModifiedAfter)
+	if f.CustomFieldName != "" && f.CustomFieldValue != "" {
+		add(f.CustomFieldName, f.CustomFieldValue)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// newStyledForm creates a form using the theme's colors
+func (ui *UI) newStyledForm() *tview.Form {
+	form := tview.NewForm().
+		SetLabelColor(tcell.GetColor(ui.theme.Label)).
+		SetFieldBackgroundColor(tcell.GetColor(ui.theme.DropDownBackground)).
+		SetFieldTextColor(tcell.GetColor(ui.theme.DropDownText)).
+		SetButtonBackgroundColor(tcell.GetColor(ui.theme.SelectionBackground)).
+		SetButtonTextColor(tcell.GetColor(ui.theme.SelectionForeground))
+	form.SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+	return form
+}
+
+// dropDownOptions prepends the "Any" option to a list of filter values
+func dropDownOptions[T ~string](values []T) []string {
+	options := []string{filterOptionAny}
+	for _, value := range values {
+		options = append(options, string(value))
+	}
+	return options
+}
+
+// dropDownIndex returns the index of value in options, or 0 ("Any") if not found
+func dropDownIndex(options []string, value string) int {
+	for i, option := range options {
+		if option == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// dropDownValue converts a selected dropdown option into a filter value
+func dropDownValue(option string) string {
+	if option == filterOptionAny {
+		return ""
+	}
+	return option
+}
+
+// showApplicationFilterPanel displays the server-side filter panel for the applications list
+func (ui *UI) showApplicationFilterPanel() {
+	filters := ui.appFilters
+
+	complianceOptions := dropDownOptions(applications.PolicyComplianceStatuses)
+	scanTypeOptions := dropDownOptions(applications.ScanTypes)
+	scanStatusOptions := dropDownOptions(applications.ScanStatuses)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Tab[-] Navigate  [%s]Enter[-] Select  [%s]ESC[-] Cancel", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	form := ui.newStyledForm()
+	form.AddDropDown("Policy Compliance", complianceOptions, dropDownIndex(complianceOptions, filters.PolicyCompliance), func(option string, _ int) {
+		filters.PolicyCompliance = dropDownValue(option)
+	}).
+		AddDropDown("Scan Type", scanTypeOptions, dropDownIndex(scanTypeOptions, filters.ScanType), func(option string, _ int) {
+			filters.ScanType = dropDownValue(option)
+		}).
+		AddDropDown("Scan Status", scanStatusOptions, dropDownIndex(scanStatusOptions, filters.ScanStatus), func(option string, _ int) {
+			filters.ScanStatus = dropDownValue(option)
+		}).
+		AddInputField("Business Unit", filters.BusinessUnit, 40, nil, func(text string) {
+			filters.BusinessUnit = strings.TrimSpace(text)
+		}).
+		AddInputField("Team", filters.Team, 40, nil, func(text string) {
+			filters.Team = strings.TrimSpace(text)
+		}).
+		AddInputField("Tag", filters.Tag, 40, nil, func(text string) {
+			filters.Tag = strings.TrimSpace(text)
+		}).
+		AddInputField("Policy", filters.Policy, 40, nil, func(text string) {
+			filters.Policy = strings.TrimSpace(text)
+		}).
+		AddInputField("Modified After (yyyy-MM-dd)", filters.ModifiedAfter, 12, nil, func(text string) {
+			filters.ModifiedAfter = strings.TrimSpace(text)
+		}).
+		AddInputField("Custom Field Name", filters.CustomFieldName, 30, nil, func(text string) {
+			filters.CustomFieldName = strings.TrimSpace(text)
+		}).
+		AddInputField("Custom Field Value", filters.CustomFieldValue, 30, nil, func(text string) {
+			filters.CustomFieldValue = strings.TrimSpace(text)
+		})
+
+	closePanel := func() {
+		ui.pages.RemovePage("app-filters")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	form.AddButton("Apply", func() {
+		if filters.ModifiedAfter != "" {
+			if _, err := time.Parse("2006-01-02", filters.ModifiedAfter); err != nil {
+				statusText.SetText(fmt.Sprintf("[%s]Modified After must be a date in yyyy-MM-dd format[-]", ui.theme.Error))
+				return
+			}
+		}
+		if (filters.CustomFieldName == "") != (filters.CustomFieldValue == "") {
+			statusText.SetText(fmt.Sprintf("[%s]Custom field filters need both a name and a value[-]", ui.theme.Error))
+			return
+		}
+		closePanel()
+		ui.setApplicationFilters(filters)
+	}).
+		AddButton("Clear", func() {
+			closePanel()
+			ui.setApplicationFilters(applicationFilters{})
+		}).
+		AddButton("Cancel", closePanel)
+	form.SetCancelFunc(closePanel)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Filter Applications ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("app-filters", modal(content, 4, 6), true, true)
+	ui.app.SetFocus(form)
+}
+
+// setApplicationFilters replaces the active filters and reloads the first page of applications
+func (ui *UI) setApplicationFilters(filters applicationFilters) {
+	ui.appFilters = filters
+	ui.currentPage = 0
+	go ui.loadApplications()
+}
+
+// clearApplicationFilters removes all server-side filters from the applications list
+func (ui *UI) clearApplicationFilters() {
+	if ui.appFilters.isEmpty() {
+		return
+	}
+	ui.setApplicationFilters(applicationFilters{})
+}
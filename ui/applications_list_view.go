@@ -2,7 +2,6 @@ package ui
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/dipsylala/veracode-tui/services/applications"
 	"github.com/gdamore/tcell/v2"
@@ -21,8 +20,8 @@ func (ui *UI) setupApplicationsView() {
 	shortcutsBar := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText(fmt.Sprintf("[%s]Enter/Double-click[-] Details  [%s]/[-] Search  [%s]n/p[-] Next/Prev Page  [%s]q/ESC[-] Quit",
-			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
+		SetText(fmt.Sprintf("[%s]Enter/Double-click[-] Details  [%s]/[-] Search  [%s]f[-] Filters  [%s]x[-] Clear Filters  [%s]s/S[-] Sort/Reverse  [%s]c[-] Columns  [%s]F[-] Favorites  [%s]G[-] SLA  [%s]L[-] Licenses  [%s]W[-] Where Used  [%s]U[-] Directory  [%s]I[-] Who Am I  [%s]a/e/D[-] Add/Edit/Delete  [%s]n/p[-] Next/Prev Page  [%s]q/ESC[-] Quit",
+			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
 	shortcutsBar.SetBorder(false)
 
 	// Layout: header, search, status bar, table, shortcuts
@@ -132,8 +131,18 @@ func (ui *UI) setupApplicationsInputHandlers(flex *tview.Flex) {
 		return event
 	})
 
-	// Add double-click support
+	// Add double-click support and header click sorting
 	ui.applicationsTable.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseLeftClick {
+			if col := headerColumnAt(ui.applicationsTable, event); col >= 0 {
+				layout := ui.applicationsLayout()
+				columns := ui.visibleApplicationColumns(layout)
+				if col < len(columns) {
+					ui.setApplicationsLayout(sortByColumn(columns[col].info, layout))
+				}
+				return action, nil
+			}
+		}
 		if action == tview.MouseLeftDoubleClick {
 			row, _ := ui.applicationsTable.GetSelection()
 			if row > 0 && row-1 < len(ui.applications) {
@@ -181,6 +190,50 @@ func (ui *UI) handleApplicationsTableRune(r rune) *tcell.EventKey {
 	case '/':
 		ui.app.SetFocus(ui.searchInput)
 		return nil
+	case 'f':
+		ui.showApplicationFilterPanel()
+		return nil
+	case 'x':
+		ui.clearApplicationFilters()
+		return nil
+	case 'F':
+		ui.showFavorites()
+		return nil
+	case 'G':
+		ui.showPortfolioSLA()
+		return nil
+	case 'L':
+		ui.showPortfolioLicenses()
+		return nil
+	case 'W':
+		ui.showWhereUsedSearch("")
+		return nil
+	case 'U':
+		ui.showDirectory()
+		return nil
+	case 'I':
+		ui.showWhoAmI()
+		return nil
+	case 'a':
+		ui.showCreateApplication()
+		return nil
+	case 'e':
+		ui.showEditApplication()
+		return nil
+	case 'D':
+		ui.showDeleteApplication()
+		return nil
+	case 's':
+		ui.setApplicationsLayout(cycleSortColumn(applicationColumnInfos(ui.applicationColumns()), ui.applicationsLayout()))
+		return nil
+	case 'S':
+		layout := ui.applicationsLayout()
+		layout.SortDescending = !layout.SortDescending
+		ui.setApplicationsLayout(layout)
+		return nil
+	case 'c':
+		ui.showColumnChooser("Applications", applicationColumnInfos(ui.applicationColumns()), ui.applicationsLayout(), ui.setApplicationsLayout, ui.applicationsTable)
+		return nil
 	case 'n':
 		if ui.currentPage < ui.totalPages-1 {
 			ui.currentPage++
@@ -201,21 +254,30 @@ func (ui *UI) handleApplicationsTableRune(r rune) *tcell.EventKey {
 	return nil
 }
 
+// applicationsQueryOptions returns the search query and server-side filters of the applications list
+func (ui *UI) applicationsQueryOptions() *applications.GetApplicationsOptions {
+	opts := &applications.GetApplicationsOptions{}
+
+	// Add search query if present
+	if ui.searchQuery != "" {
+		opts.Name = ui.searchQuery
+	}
+
+	// Add server-side filters if present
+	ui.appFilters.apply(opts)
+
+	return opts
+}
+
 // loadApplications fetches applications from the API
 func (ui *UI) loadApplications() {
 	ui.app.QueueUpdateDraw(func() {
 		ui.statusBar.SetText("[yellow]Loading applications...[-]")
 	})
 
-	opts := &applications.GetApplicationsOptions{
-		Page: ui.currentPage,
-		Size: ui.pageSize,
-	}
-
-	// Add search query if present
-	if ui.searchQuery != "" {
-		opts.Name = ui.searchQuery
-	}
+	opts := ui.applicationsQueryOptions()
+	opts.Page = ui.currentPage
+	opts.Size = ui.pageSize
 
 	result, err := ui.appService.GetApplications(opts)
 
@@ -233,16 +295,8 @@ func (ui *UI) loadApplications() {
 	} else {
 		ui.applications = result.Embedded.Applications
 
-		// Sort by Modified date descending (most recent first)
-		sort.Slice(ui.applications, func(i, j int) bool {
-			if ui.applications[i].Modified == nil {
-				return false
-			}
-			if ui.applications[j].Modified == nil {
-				return true
-			}
-			return ui.applications[i].Modified.After(*ui.applications[j].Modified)
-		})
+		// Sort by the chosen column (Modified descending by default)
+		ui.sortApplications()
 
 		if result.Page != nil {
 			ui.totalPages = int(result.Page.TotalPages)
@@ -259,10 +313,16 @@ func (ui *UI) loadApplications() {
 func (ui *UI) renderApplicationsTable() {
 	ui.applicationsTable.Clear()
 
-	// Add header row
-	headers := []string{"Application Name", "Created", "Last Modified", "Last Scan", "Policy Status", "Scan Status"}
-	for col, header := range headers {
-		cell := tview.NewTableCell(header).
+	layout := ui.applicationsLayout()
+	columns := ui.visibleApplicationColumns(layout)
+
+	// Add header row. Sorting only orders the loaded page, so say so when there are more
+	for col, column := range columns {
+		title := headerTitle(column.info, layout)
+		if column.info.key == layout.SortColumn && ui.totalPages > 1 {
+			title += " (this page)"
+		}
+		cell := tview.NewTableCell(title).
 			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
 			SetAttributes(tcell.AttrBold).
 			SetSelectable(false)
@@ -276,53 +336,10 @@ func (ui *UI) renderApplicationsTable() {
 	}
 
 	// Add application rows
-	for row, app := range appsToShow {
-		rowNum := row + 1
-
-		// Application name
-		appName := "Unknown"
-		if app.Profile != nil {
-			appName = app.Profile.Name
+	for row := range appsToShow {
+		for col, column := range columns {
+			ui.applicationsTable.SetCell(row+1, col, tview.NewTableCell(column.value(&appsToShow[row])))
 		}
-		if len(appName) > 40 {
-			appName = appName[:40] + "..."
-		}
-		ui.applicationsTable.SetCell(rowNum, 0, tview.NewTableCell(appName))
-
-		// Created date
-		created := TextNotAvailable
-		if app.Created != nil {
-			created = app.Created.Format("2006-01-02")
-		}
-		ui.applicationsTable.SetCell(rowNum, 1, tview.NewTableCell(created))
-
-		// Last modified
-		lastModified := TextNotAvailable
-		if app.Modified != nil {
-			lastModified = app.Modified.Format("2006-01-02")
-		}
-		ui.applicationsTable.SetCell(rowNum, 2, tview.NewTableCell(lastModified))
-
-		// Last completed scan date
-		lastScan := TextNotAvailable
-		if app.LastCompletedScanDate != nil {
-			lastScan = app.LastCompletedScanDate.Format("2006-01-02")
-		}
-		ui.applicationsTable.SetCell(rowNum, 3, tview.NewTableCell(lastScan))
-
-		// Policy status
-		policyStatus := TextNotAvailable
-		if app.Profile != nil && len(app.Profile.Policies) > 0 {
-			policyStatus = app.Profile.Policies[0].PolicyComplianceStatus
-		}
-		ui.applicationsTable.SetCell(rowNum, 4, tview.NewTableCell(policyStatus))
-
-		// Scan status
-		scanStatus := TextNotAvailable
-		if len(app.Scans) > 0 {
-			scanStatus = app.Scans[0].Status
-		}
-		ui.applicationsTable.SetCell(rowNum, 5, tview.NewTableCell(scanStatus))
 	}
 
 	// Select first data row if available
@@ -341,5 +358,10 @@ func (ui *UI) updateStatusBar() {
 	if ui.totalPages > 1 {
 		statusText += fmt.Sprintf(" • Page %d/%d (Total: %d)", ui.currentPage+1, ui.totalPages, ui.totalApps)
 	}
+	if !ui.appFilters.isEmpty() {
+		statusText += fmt.Sprintf(" • [%s]Filters:[-] %s", ui.theme.Info, tview.Escape(ui.appFilters.describe()))
+	}
+	statusText += ui.cacheIndicator()
+	statusText += ui.credentialIndicator()
 	ui.statusBar.SetText(statusText)
 }
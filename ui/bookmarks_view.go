@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// currentBookmark captures the open findings view (application, context and filters) as a bookmark
+func (ui *UI) currentBookmark() config.Bookmark {
+	bookmark := config.Bookmark{
+		AppGUID:     ui.selectedApp.GUID,
+		AppName:     DefaultApplicationName,
+		ScanType:    string(ui.findingsScanFilter),
+		MinSeverity: ui.findingsSeverityFilter,
+		Policy:      string(ui.findingsPolicyFilter),
+		Query:       ui.findingsQuickFilter,
+	}
+	if ui.selectedApp.Profile != nil {
+		bookmark.AppName = ui.selectedApp.Profile.Name
+	}
+	bookmark.SandboxGUID, bookmark.SandboxName = ui.currentSandbox()
+
+	bookmark.Name = fmt.Sprintf("%s - %s - %s", bookmark.AppName, bookmarkContextName(bookmark), bookmark.ScanType)
+	return bookmark
+}
+
+// bookmarkContextName returns the sandbox name of a bookmark, or "Policy Scan"
+func bookmarkContextName(bookmark config.Bookmark) string {
+	if bookmark.SandboxGUID == "" {
+		return "Policy Scan"
+	}
+	return bookmark.SandboxName
+}
+
+// describeBookmarkFilters summarises the filters stored in a bookmark
+func describeBookmarkFilters(bookmark config.Bookmark) string {
+	var parts []string
+	if bookmark.MinSeverity > 0 {
+		parts = append(parts, fmt.Sprintf("Sev>=%d", bookmark.MinSeverity))
+	}
+	if bookmark.Policy != "" && bookmark.Policy != string(findings.PolicyFilterAll) {
+		parts = append(parts, bookmark.Policy)
+	}
+	if bookmark.Query != "" {
+		parts = append(parts, bookmark.Query)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// showSaveBookmarkForm displays a modal to save the current findings view under a name
+func (ui *UI) showSaveBookmarkForm() {
+	if ui.selectedApp == nil {
+		return
+	}
+
+	bookmark := ui.currentBookmark()
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(tview.Escape(describeBookmarkFilters(bookmark)))
+
+	form := ui.newStyledForm()
+	form.AddInputField("Name", bookmark.Name, 50, nil, func(text string) {
+		bookmark.Name = strings.TrimSpace(text)
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("save-bookmark")
+		ui.app.SetFocus(ui.findingsTable)
+	}
+
+	form.AddButton("Save", func() {
+		if bookmark.Name == "" {
+			statusText.SetText(fmt.Sprintf("[%s]A name is required[-]", ui.theme.Error))
+			return
+		}
+		ui.bookmarks.Set(bookmark)
+		if err := ui.bookmarks.Save(); err != nil {
+			statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			return
+		}
+		closeForm()
+		ui.findingsTable.SetTitle(fmt.Sprintf(" %s • Saved \"%s\" ", ui.findingsScanFilter, tview.Escape(bookmark.Name)))
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Save View ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("save-bookmark", modal(content, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// showFavorites displays the saved views; Enter opens one, d deletes it
+func (ui *UI) showFavorites() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Enter[-] Open  [%s]d[-] Delete  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	render := func() {
+		table.Clear()
+		for col, header := range []string{"Name", "Application", "Context", "Scan Type", "Filters"} {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		if len(ui.bookmarks.Items) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("No saved views. Press b on a findings table to save one.").
+				SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+				SetSelectable(false))
+			return
+		}
+		for i, bookmark := range ui.bookmarks.Items {
+			table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(bookmark.Name)).SetExpansion(1))
+			table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(bookmark.AppName)))
+			table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(bookmarkContextName(bookmark))))
+			table.SetCell(i+1, 3, tview.NewTableCell(bookmark.ScanType))
+			table.SetCell(i+1, 4, tview.NewTableCell(tview.Escape(describeBookmarkFilters(bookmark))))
+		}
+		table.Select(1, 0)
+	}
+	render()
+
+	closeFavorites := func() {
+		ui.pages.RemovePage("favorites")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row > 0 && row-1 < len(ui.bookmarks.Items) {
+			bookmark := ui.bookmarks.Items[row-1]
+			closeFavorites()
+			ui.openBookmark(bookmark)
+		}
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeFavorites()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'd':
+			row, _ := table.GetSelection()
+			if row > 0 && row-1 < len(ui.bookmarks.Items) {
+				ui.bookmarks.Remove(ui.bookmarks.Items[row-1].Name)
+				if err := ui.bookmarks.Save(); err != nil {
+					statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				}
+				render()
+			}
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Favorites ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("favorites", modal(content, 4, 3), true, true)
+	ui.app.SetFocus(table)
+}
+
+// openBookmark loads the bookmarked application and sandbox and opens the findings view with its filters
+func (ui *UI) openBookmark(bookmark config.Bookmark) {
+	ui.statusBar.SetText(fmt.Sprintf("[%s]Opening %s...[-]", ui.theme.Pending, tview.Escape(bookmark.Name)))
+
+	go func() {
+		app, err := ui.appService.GetApplication(bookmark.AppGUID)
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText(fmt.Sprintf("[%s]Error opening %s: %s[-]", ui.theme.Error, tview.Escape(bookmark.Name), tview.Escape(err.Error())))
+			})
+			return
+		}
+
+		result, err := ui.appService.GetSandboxes(bookmark.AppGUID, &applications.GetSandboxesOptions{
+			Size: 100,
+		})
+		var sandboxes []applications.Sandbox
+		if err == nil && result.Embedded != nil {
+			sandboxes = result.Embedded.Sandboxes
+		}
+
+		selectionIndex := -1
+		if bookmark.SandboxGUID != "" {
+			for i := range sandboxes {
+				if sandboxes[i].GUID == bookmark.SandboxGUID {
+					selectionIndex = i
+					break
+				}
+			}
+			if selectionIndex < 0 {
+				ui.app.QueueUpdateDraw(func() {
+					ui.statusBar.SetText(fmt.Sprintf("[%s]Sandbox %s of %s no longer exists[-]", ui.theme.Error, tview.Escape(bookmark.SandboxName), tview.Escape(bookmark.AppName)))
+				})
+				return
+			}
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			// Open the detail page first so that Esc from the findings returns to the application
+			ui.selectedApp = app
+			ui.showApplicationDetail()
+			ui.sandboxes = sandboxes
+			ui.updateContextsTable()
+			ui.selectionIndex = selectionIndex
+			ui.updateStatusBar()
+
+			ui.openFindings(bookmarkScanFilter(bookmark), severityFromBookmark(bookmark), bookmarkPolicyFilter(bookmark), bookmark.Query)
+		})
+	}()
+}
+
+// bookmarkScanFilter returns the scan type filter stored in a bookmark, defaulting to STATIC
+func bookmarkScanFilter(bookmark config.Bookmark) findings.ScanFilterType {
+	switch findings.ScanFilterType(strings.ToUpper(bookmark.ScanType)) {
+	case findings.ScanFilterDynamic:
+		return findings.ScanFilterDynamic
+	case findings.ScanFilterSCA:
+		return findings.ScanFilterSCA
+	default:
+		return findings.ScanFilterStatic
+	}
+}
+
+// severityFromBookmark returns the minimum severity stored in a bookmark, ignoring invalid values
+func severityFromBookmark(bookmark config.Bookmark) int {
+	if bookmark.MinSeverity < 1 || bookmark.MinSeverity > 5 {
+		return 0
+	}
+	return bookmark.MinSeverity
+}
+
+// bookmarkPolicyFilter returns the policy filter stored in a bookmark, defaulting to All
+func bookmarkPolicyFilter(bookmark config.Bookmark) findings.PolicyFilterType {
+	switch findings.PolicyFilterType(bookmark.Policy) {
+	case findings.PolicyFilterViolations:
+		return findings.PolicyFilterViolations
+	case findings.PolicyFilterNonViolations:
+		return findings.PolicyFilterNonViolations
+	default:
+		return findings.PolicyFilterAll
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/cache"
+)
+
+// cacheStatus tracks whether the data on screen came from the local response cache
+type cacheStatus struct {
+	client       *cache.Client // nil when caching is disabled
+	offline      bool
+	refreshing   int       // Background refreshes of stale responses in progress
+	refreshError error     // Last failed refresh; cleared by the next successful one
+	syncedAt     time.Time // When the last response served offline was fetched
+}
+
+// SetCache connects the UI to the caching client so that stale and offline data are indicated
+// and views reload when a background refresh brings new data
+func (ui *UI) SetCache(client *cache.Client) {
+	ui.cache.client = client
+	ui.cache.offline = client.Offline()
+	client.SetListener(func(event cache.Event) {
+		ui.app.QueueUpdateDraw(func() {
+			ui.handleCacheEvent(event)
+		})
+	})
+}
+
+// invalidateCache discards the cached responses under each path prefix so that they are fetched
+// again after a change. Offline the cache is all there is, so it is kept.
+func (ui *UI) invalidateCache(prefixes ...string) {
+	if ui.cache.client == nil || ui.cache.offline {
+		return
+	}
+	for _, prefix := range prefixes {
+		_ = ui.cache.client.Invalidate(prefix)
+	}
+}
+
+// handleCacheEvent updates the cache indicator and reloads the current view when its data changed
+func (ui *UI) handleCacheEvent(event cache.Event) {
+	switch event.Kind {
+	case cache.EventStale:
+		ui.cache.refreshing++
+	case cache.EventRefreshed:
+		ui.cache.refreshing--
+		ui.cache.refreshError = nil
+		if event.Changed {
+			ui.reloadCurrentView(event.Path)
+		}
+	case cache.EventRefreshFailed:
+		ui.cache.refreshing--
+		ui.cache.refreshError = event.Err
+	case cache.EventCached:
+		ui.cache.syncedAt = event.FetchedAt
+	}
+
+	if ui.statusBar != nil {
+		ui.updateStatusBar()
+	}
+	if ui.findingsCountsLabel != nil {
+		ui.updateCountsLabel()
+	}
+}
+
+// reloadCurrentView reloads the visible list when a refreshed response belongs to it
+func (ui *UI) reloadCurrentView(urlPath string) {
+	page, _ := ui.pages.GetFrontPage()
+	switch {
+	case page == "applications" && !strings.Contains(urlPath, "/sandboxes"):
+		go ui.loadApplications()
+	case page == "findings" && strings.HasSuffix(urlPath, "/findings"):
+		go ui.loadFindingsWithFilter(ui.findingsScanFilter)
+	}
+}
+
+// cacheIndicator returns the status text describing cached data, or "" when the data is current
+func (ui *UI) cacheIndicator() string {
+	switch {
+	case ui.cache.offline && !ui.cache.syncedAt.IsZero():
+		return fmt.Sprintf(" • [%s]Offline - data from %s[-]", ui.theme.Warning, ui.cache.syncedAt.Local().Format("2006-01-02 15:04"))
+	case ui.cache.offline:
+		return fmt.Sprintf(" • [%s]Offline[-]", ui.theme.Warning)
+	case ui.cache.refreshing > 0:
+		return fmt.Sprintf(" • [%s]Stale, refreshing...[-]", ui.theme.Warning)
+	case ui.cache.refreshError != nil:
+		return fmt.Sprintf(" • [%s]Showing cached data, refresh failed[-]", ui.theme.Warning)
+	}
+	return ""
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// compareRow is a finding listed in the comparison table. policy is set for findings of the
+// policy scan, and also for the policy side of a finding whose status differs.
+type compareRow struct {
+	sandbox *findings.Finding
+	policy  *findings.Finding
+}
+
+// showContextComparison compares the sandbox highlighted in the contexts table with the policy
+// scan. On the policy row it asks which sandbox to compare.
+func (ui *UI) showContextComparison() {
+	if ui.selectedApp == nil || len(ui.sandboxes) == 0 {
+		return
+	}
+	if row, _ := ui.contextsTable.GetSelection(); row > 1 && row-2 < len(ui.sandboxes) {
+		ui.showComparison(row - 2)
+		return
+	}
+
+	names := make([]string, len(ui.sandboxes))
+	for i, sandbox := range ui.sandboxes {
+		names[i] = sandbox.Name
+	}
+	selected := 0
+
+	form := ui.newStyledForm()
+	form.AddDropDown("Sandbox", names, 0, func(_ string, index int) {
+		selected = index
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("compare-select")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+
+	form.AddButton("Compare", func() {
+		closeForm()
+		ui.showComparison(selected)
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetBorder(true).
+		SetTitle(" Compare with Policy ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("compare-select", modal(form, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// showComparison loads the findings of the policy scan and a sandbox, and lists the findings
+// only found in one of them and those whose status differs. Enter opens the details of a finding.
+func (ui *UI) showComparison(sandboxIndex int) {
+	app := *ui.selectedApp
+	sandbox := ui.sandboxes[sandboxIndex]
+
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[%s]Loading findings...[-]", ui.theme.Pending))
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Enter[-] Details  [%s]p[-] Policy Details  [%s]ESC[-] Close",
+			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	rows := make(map[int]compareRow)
+	closeComparison := func() {
+		ui.pages.RemovePage("compare")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+	openDetail := func(finding *findings.Finding, index int) {
+		ui.showComparedFinding(finding, index, table)
+	}
+
+	table.SetSelectedFunc(func(row, _ int) {
+		if r, ok := rows[row]; ok {
+			if r.sandbox != nil {
+				openDetail(r.sandbox, sandboxIndex)
+			} else {
+				openDetail(r.policy, -1)
+			}
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeComparison()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'p':
+			row, _ := table.GetSelection()
+			if r, ok := rows[row]; ok && r.policy != nil {
+				openDetail(r.policy, -1)
+			}
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s - Policy vs %s ", tview.Escape(appDisplayName(&app)), tview.Escape(sandbox.Name))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("compare", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(table)
+
+	go func() {
+		policy, err := portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{}, slaScanTypes)(&app)
+		var inSandbox []findings.Finding
+		if err == nil {
+			inSandbox, err = portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{Context: sandbox.GUID}, slaScanTypes)(&app)
+		}
+		for i := range inSandbox {
+			inSandbox[i].ContextType = findings.ContextTypeSandbox
+			inSandbox[i].ContextGUID = sandbox.GUID
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				summaryText.SetText(fmt.Sprintf("[%s]Error loading findings: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			comparison := findings.CompareContexts(policy, inSandbox)
+			ui.renderComparison(summaryText, table, rows, comparison, sandbox, len(policy), len(inSandbox))
+		})
+	}()
+}
+
+// renderComparison fills the comparison summary and table, recording the finding of each row
+func (ui *UI) renderComparison(summaryText *tview.TextView, table *tview.Table, rows map[int]compareRow,
+	comparison *findings.ContextComparison, sandbox applications.Sandbox, policyCount, sandboxCount int) {
+	summaryText.SetText(fmt.Sprintf("[%s]Policy:[-] %d findings   [%s]%s:[-] %d findings   [%s]Shared:[-] %d\n"+
+		"[%s]Only in sandbox:[-] %d   [%s]Only in policy:[-] %d   [%s]Status differs:[-] %d",
+		ui.theme.Label, policyCount, ui.theme.Label, tview.Escape(sandbox.Name), sandboxCount, ui.theme.Label, comparison.Shared,
+		ui.theme.Label, len(comparison.SandboxOnly), ui.theme.Label, len(comparison.PolicyOnly), ui.theme.Label, len(comparison.StatusDiffers)))
+
+	table.Clear()
+	for col, header := range []string{"ID", "Scan", "Sev", "Finding", "Policy", "Sandbox"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if comparison.Empty() {
+		table.SetCell(1, 0, tview.NewTableCell("The sandbox has the same findings as the policy scan").
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		return
+	}
+
+	row := 1
+	section := func(title string, count int) {
+		if count == 0 {
+			return
+		}
+		table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%s (%d)", title, count)).
+			SetTextColor(tcell.GetColor(ui.theme.Label)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+		row++
+	}
+	add := func(r compareRow) {
+		finding := r.sandbox
+		if finding == nil {
+			finding = r.policy
+		}
+		severity := extractSeverity(finding)
+		cells := []*tview.TableCell{
+			tview.NewTableCell(fmt.Sprintf("%d", finding.IssueID)),
+			tview.NewTableCell(string(finding.ScanType)),
+			tview.NewTableCell(severity).SetTextColor(ui.getSeverityColor(severity)),
+			tview.NewTableCell(tview.Escape(describeFinding(finding))).SetExpansion(1),
+			ui.comparedStatusCell(r.policy),
+			ui.comparedStatusCell(r.sandbox),
+		}
+		for col, cell := range cells {
+			table.SetCell(row, col, cell)
+		}
+		rows[row] = r
+		row++
+	}
+
+	section("Only in sandbox", len(comparison.SandboxOnly))
+	for _, f := range comparison.SandboxOnly {
+		add(compareRow{sandbox: f})
+	}
+	section("Only in policy", len(comparison.PolicyOnly))
+	for _, f := range comparison.PolicyOnly {
+		add(compareRow{policy: f})
+	}
+	section("Status differs", len(comparison.StatusDiffers))
+	for _, d := range comparison.StatusDiffers {
+		add(compareRow{policy: d.Policy, sandbox: d.Sandbox})
+	}
+
+	for r := 1; r < row; r++ {
+		if _, ok := rows[r]; ok {
+			table.Select(r, 0)
+			break
+		}
+	}
+}
+
+// comparedStatusCell shows the status and resolution of one side of a comparison, or "-" when
+// the finding is not reported in that context
+func (ui *UI) comparedStatusCell(finding *findings.Finding) *tview.TableCell {
+	if finding == nil || finding.FindingStatus == nil {
+		return tview.NewTableCell("-").SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+	}
+	status := string(finding.Status())
+	if resolution := finding.FindingStatus.ResolutionStatus; resolution != "" && resolution != findings.ResolutionNone {
+		status += " (" + strings.ToLower(string(resolution)) + ")"
+	}
+	return tview.NewTableCell(status).SetTextColor(ui.getStatusColor(finding))
+}
+
+// showComparedFinding opens the details of a finding from the comparison. The context of the
+// finding is selected while its details are shown, so that mitigations apply to it, and Esc
+// returns to the comparison.
+func (ui *UI) showComparedFinding(finding *findings.Finding, sandboxIndex int, table *tview.Table) {
+	previousIndex := ui.selectionIndex
+	ui.selectionIndex = sandboxIndex
+	ui.selectedFinding = finding
+	ui.findingDetailReturn = func() {
+		ui.selectionIndex = previousIndex
+		ui.pages.SwitchToPage("detail")
+		ui.pages.ShowPage("compare")
+		ui.app.SetFocus(table)
+	}
+
+	if finding.ScanType == findings.ScanTypeSCA {
+		ui.showSCAFindingDetail()
+	} else {
+		ui.showFindingDetail()
+	}
+}
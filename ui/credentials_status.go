@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// loadCredentialExpiry reads the expiry of the API credentials for the status bar. Errors leave
+// the indicator hidden, as the applications list reports any problem with the credentials.
+func (ui *UI) loadCredentialExpiry() {
+	if ui.identityService == nil {
+		return
+	}
+	creds, err := ui.identityService.GetAPICredentials(context.Background())
+	if err != nil {
+		return
+	}
+	ui.app.QueueUpdateDraw(func() {
+		ui.credentials = creds
+		if ui.statusBar != nil {
+			ui.updateStatusBar()
+		}
+	})
+}
+
+// credentialIndicator returns the status bar text showing when the API credentials expire,
+// highlighted once that is within the warning period of the settings
+func (ui *UI) credentialIndicator() string {
+	if ui.credentials == nil {
+		return ""
+	}
+	now := time.Now()
+	days, ok := ui.credentials.DaysUntilExpiry(now)
+	switch {
+	case !ok:
+		return ""
+	case days < 0:
+		return fmt.Sprintf(" • [%s]API key expired - run rotate-credentials[-]", ui.theme.Error)
+	case ui.credentials.ExpiresWithin(ui.settings.CredentialWarning(), now):
+		return fmt.Sprintf(" • [%s]API key expires in %d days - run rotate-credentials[-]", ui.theme.Warning, days)
+	}
+	return fmt.Sprintf(" • [%s]API key expires %s[-]", ui.theme.SecondaryText, ui.credentials.ExpirationTS.Local().Format("2006-01-02"))
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// upgradeCandidates is the number of direct dependencies suggested for upgrade
+const upgradeCandidates = 3
+
+// showDependencyTree displays the loaded SCA findings as a dependency tree, from the direct
+// dependencies down to the vulnerable components, with the CVE count and highest severity of
+// each branch. Enter expands or collapses a node.
+func (ui *UI) showDependencyTree() {
+	if ui.findingsScanFilter != findings.ScanFilterSCA {
+		ui.findingsCountsLabel.SetText(fmt.Sprintf("[%s]The dependency tree is available for SCA findings[-]", ui.theme.Warning))
+		return
+	}
+
+	tree := findings.BuildDependencyTree(ui.findings)
+
+	root := tview.NewTreeNode("Dependencies").
+		SetColor(tcell.GetColor(ui.theme.Label)).
+		SetSelectable(false)
+	for _, node := range tree.Roots {
+		root.AddChild(ui.dependencyTreeNode(node, 1))
+	}
+
+	view := tview.NewTreeView().
+		SetRoot(root).
+		SetTopLevel(1).
+		SetGraphicsColor(tcell.GetColor(ui.theme.Border))
+	if children := root.GetChildren(); len(children) > 0 {
+		view.SetCurrentNode(children[0])
+	}
+	view.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(ui.buildUpgradeSummary(tree))
+
+	closeTree := func() {
+		ui.pages.RemovePage("dependency-tree")
+		ui.app.SetFocus(ui.findingsTable)
+	}
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeTree()
+			return nil
+		}
+		return event
+	})
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Enter[-] Expand/Collapse  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 2, 0, false).
+		AddItem(view, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Dependency Tree ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("dependency-tree", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(view)
+}
+
+// dependencyTreeNode creates the tree node for a dependency and its children. The CVEs of the
+// component itself are listed as leaves. Nodes below the second level start collapsed.
+func (ui *UI) dependencyTreeNode(node *findings.DependencyNode, depth int) *tview.TreeNode {
+	severityColor := ui.getSeverityColorHex(node.MaxSeverity)
+	text := fmt.Sprintf("%s  [%s](%d CVEs, max sev %d)[-]", tview.Escape(node.Name), severityColor, len(node.CVEs), node.MaxSeverity)
+
+	treeNode := tview.NewTreeNode(text).
+		SetReference(node).
+		SetExpanded(depth < 2)
+
+	for _, child := range node.Children {
+		treeNode.AddChild(ui.dependencyTreeNode(child, depth+1))
+	}
+	for _, finding := range node.Findings {
+		severity := finding.Severity()
+		label := finding.CVE()
+		if label == "" {
+			label = fmt.Sprintf("Issue %d", finding.IssueID)
+		}
+		treeNode.AddChild(tview.NewTreeNode(fmt.Sprintf("[%s]%s[-] [%s]sev %d[-]",
+			ui.theme.SecondaryText, tview.Escape(label), ui.getSeverityColorHex(severity), severity)).
+			SetSelectable(false))
+	}
+	return treeNode
+}
+
+// buildUpgradeSummary names the direct dependencies whose upgrade would remove the most vulnerabilities
+func (ui *UI) buildUpgradeSummary(tree *findings.DependencyTree) string {
+	direct := tree.DirectDependencies()
+	if len(direct) == 0 {
+		return fmt.Sprintf("[%s]No SCA findings with component information[-]", ui.theme.SecondaryText)
+	}
+
+	var candidates []string
+	for _, node := range direct[:min(len(direct), upgradeCandidates)] {
+		candidates = append(candidates, fmt.Sprintf("%s [%s](%d CVEs, max sev %d)[-]",
+			tview.Escape(node.Name), ui.getSeverityColorHex(node.MaxSeverity), len(node.CVEs), node.MaxSeverity))
+	}
+	return fmt.Sprintf("[%s]Upgrade first:[-] %s\n[%s]%d direct dependencies with vulnerabilities[-]",
+		ui.theme.Label, strings.Join(candidates, ", "), ui.theme.SecondaryText, len(direct))
+}
@@ -0,0 +1,411 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// directoryTab is a list of the directory screen
+type directoryTab int
+
+const (
+	directoryUsers directoryTab = iota
+	directoryTeams
+	directoryBusinessUnits
+)
+
+var directoryTabNames = []string{"Users", "Teams", "Business Units"}
+
+// directory holds the lists of the directory screen, loaded when their tab is first shown
+type directory struct {
+	tab           directoryTab
+	users         []identity.User
+	teams         []identity.Team
+	businessUnits []identity.BusinessUnit
+	loaded        [3]bool
+	loading       [3]bool
+}
+
+// showDirectory browses the organization's users, teams and business units. Tab switches list,
+// Enter shows the details of a user or the members of a team, a lists the applications of a team
+// or business unit.
+func (ui *UI) showDirectory() {
+	dir := &directory{}
+
+	tabsText := tview.NewTextView().
+		SetDynamicColors(true)
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	detailText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	detailText.SetBorder(true).
+		SetTitle(" Details ").
+		SetTitleAlign(tview.AlignLeft)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	render := func() {
+		ui.renderDirectory(dir, tabsText, table, detailText, statusText)
+	}
+
+	// load fetches the list of the current tab the first time it is shown
+	load := func() {
+		tab := dir.tab
+		if dir.loaded[tab] || dir.loading[tab] {
+			return
+		}
+		dir.loading[tab] = true
+		go func() {
+			var err error
+			var users []identity.User
+			var teams []identity.Team
+			var businessUnits []identity.BusinessUnit
+			ctx := context.Background()
+			switch tab {
+			case directoryUsers:
+				users, err = ui.identityService.GetAllUsers(ctx)
+			case directoryTeams:
+				teams, err = ui.identityService.GetAllTeams(ctx)
+			case directoryBusinessUnits:
+				businessUnits, err = ui.identityService.GetAllBusinessUnits(ctx)
+			}
+
+			ui.app.QueueUpdateDraw(func() {
+				dir.loading[tab] = false
+				if err != nil {
+					if dir.tab == tab {
+						detailText.SetText(fmt.Sprintf("[%s]Failed to load %s: %s[-]", ui.theme.Error, strings.ToLower(directoryTabNames[tab]), tview.Escape(err.Error())))
+					}
+					return
+				}
+				switch tab {
+				case directoryUsers:
+					slices.SortFunc(users, func(a, b identity.User) int { return compareFold(a.UserName, b.UserName) })
+					dir.users = users
+				case directoryTeams:
+					slices.SortFunc(teams, func(a, b identity.Team) int { return compareFold(a.TeamName, b.TeamName) })
+					dir.teams = teams
+				case directoryBusinessUnits:
+					slices.SortFunc(businessUnits, func(a, b identity.BusinessUnit) int { return compareFold(a.BUName, b.BUName) })
+					dir.businessUnits = businessUnits
+				}
+				dir.loaded[tab] = true
+				if dir.tab == tab {
+					render()
+				}
+			})
+		}()
+	}
+
+	closeDirectory := func() {
+		ui.pages.RemovePage("directory")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	// showApplications closes the directory and filters the applications list
+	showApplications := func(filters applicationFilters) {
+		closeDirectory()
+		ui.setApplicationFilters(filters)
+	}
+
+	table.SetSelectionChangedFunc(func(row, column int) {
+		ui.renderDirectoryDetail(dir, row-1, detailText)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		index, _ := table.GetSelection()
+		index--
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeDirectory()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			step := 1
+			if event.Key() == tcell.KeyBacktab {
+				step = len(directoryTabNames) - 1
+			}
+			dir.tab = directoryTab((int(dir.tab) + step) % len(directoryTabNames))
+			render()
+			load()
+			return nil
+		case tcell.KeyEnter:
+			switch {
+			case dir.tab == directoryUsers && index >= 0 && index < len(dir.users):
+				ui.loadDirectoryUser(dir, index, detailText)
+			case dir.tab == directoryTeams && index >= 0 && index < len(dir.teams):
+				ui.loadDirectoryTeam(dir, index, detailText)
+			}
+			return nil
+		}
+		if event.Rune() == 'a' {
+			switch {
+			case dir.tab == directoryTeams && index >= 0 && index < len(dir.teams):
+				showApplications(applicationFilters{Team: dir.teams[index].TeamName})
+			case dir.tab == directoryBusinessUnits && index >= 0 && index < len(dir.businessUnits):
+				showApplications(applicationFilters{BusinessUnit: dir.businessUnits[index].BUName})
+			}
+			return nil
+		}
+		return event
+	})
+
+	lists := tview.NewFlex().
+		AddItem(table, 0, 3, true).
+		AddItem(detailText, 0, 2, false)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tabsText, 1, 0, false).
+		AddItem(lists, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Directory ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("directory", modal(content, 6, 4), true, true)
+	ui.app.SetFocus(table)
+
+	render()
+	load()
+}
+
+// compareFold compares two names ignoring case
+func compareFold(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// renderDirectory fills the tabs, list and shortcuts of the current directory tab
+func (ui *UI) renderDirectory(dir *directory, tabsText *tview.TextView, table *tview.Table, detailText *tview.TextView, statusText *tview.TextView) {
+	var tabs []string
+	for i, name := range directoryTabNames {
+		if directoryTab(i) == dir.tab {
+			tabs = append(tabs, fmt.Sprintf("[%s::b]%s[-::-]", ui.theme.Info, name))
+		} else {
+			tabs = append(tabs, fmt.Sprintf("[%s]%s[-]", ui.theme.SecondaryText, name))
+		}
+	}
+	tabsText.SetText(strings.Join(tabs, "  |  "))
+
+	shortcuts := map[directoryTab]string{
+		directoryUsers:         fmt.Sprintf("[%s]Tab[-] Switch List  [%s]Enter[-] Roles & Teams  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info),
+		directoryTeams:         fmt.Sprintf("[%s]Tab[-] Switch List  [%s]Enter[-] Members  [%s]a[-] Applications  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info),
+		directoryBusinessUnits: fmt.Sprintf("[%s]Tab[-] Switch List  [%s]a[-] Applications  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info),
+	}
+	statusText.SetText(shortcuts[dir.tab])
+
+	table.Clear()
+	var headers []string
+	var rows [][]string
+	switch dir.tab {
+	case directoryUsers:
+		headers = []string{"User Name", "Name", "Email"}
+		for _, user := range dir.users {
+			rows = append(rows, []string{user.UserName, user.FullName(), user.EmailAddress})
+		}
+	case directoryTeams:
+		headers = []string{"Team", "Business Unit", "Members"}
+		for _, team := range dir.teams {
+			bu := "-"
+			if team.BusinessUnit != nil {
+				bu = team.BusinessUnit.BUName
+			}
+			members := "-"
+			if team.UserCount > 0 {
+				members = fmt.Sprintf("%d", team.UserCount)
+			}
+			rows = append(rows, []string{team.TeamName, bu, members})
+		}
+	case directoryBusinessUnits:
+		headers = []string{"Business Unit", "Teams", "Default"}
+		for _, bu := range dir.businessUnits {
+			isDefault := ""
+			if bu.IsDefault {
+				isDefault = "Yes"
+			}
+			rows = append(rows, []string{bu.BUName, fmt.Sprintf("%d", len(bu.Teams)), isDefault})
+		}
+	}
+
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if !dir.loaded[dir.tab] {
+		table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Loading %s...", strings.ToLower(directoryTabNames[dir.tab]))).
+			SetTextColor(tcell.GetColor(ui.theme.Pending)).
+			SetSelectable(false))
+		detailText.Clear()
+		return
+	}
+	if len(rows) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("No %s", strings.ToLower(directoryTabNames[dir.tab]))).
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		detailText.Clear()
+		return
+	}
+	for i, row := range rows {
+		for col, value := range row {
+			cell := tview.NewTableCell(tview.Escape(value))
+			if col == 0 {
+				cell.SetExpansion(1)
+			}
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+	table.ScrollToBeginning()
+	ui.renderDirectoryDetail(dir, 0, detailText)
+}
+
+// renderDirectoryDetail shows what the list knows about the selected entry
+func (ui *UI) renderDirectoryDetail(dir *directory, index int, detailText *tview.TextView) {
+	var sb strings.Builder
+	field := func(label, value string) {
+		if value != "" {
+			sb.WriteString(fmt.Sprintf("[%s]%s:[-] %s\n", ui.theme.Label, label, tview.Escape(value)))
+		}
+	}
+
+	switch {
+	case dir.tab == directoryUsers && index >= 0 && index < len(dir.users):
+		user := &dir.users[index]
+		field("User Name", user.UserName)
+		field("Name", user.FullName())
+		field("Email", user.EmailAddress)
+		field("Title", user.Title)
+		field("Account Type", user.AccountType)
+		if user.SAMLUser {
+			field("SAML", "Yes")
+		}
+		if len(user.Roles) > 0 {
+			sb.WriteString(fmt.Sprintf("\n[%s]Roles:[-]\n", ui.theme.Label))
+			for _, role := range user.Roles {
+				name := role.RoleDescription
+				if name == "" {
+					name = role.RoleName
+				}
+				sb.WriteString("  " + tview.Escape(name) + "\n")
+			}
+		}
+		if len(user.Teams) > 0 {
+			sb.WriteString(fmt.Sprintf("\n[%s]Teams:[-]\n", ui.theme.Label))
+			for _, team := range user.Teams {
+				sb.WriteString("  " + tview.Escape(team.TeamName) + "\n")
+			}
+		}
+		if user.Roles == nil && user.Teams == nil {
+			sb.WriteString(fmt.Sprintf("\n[%s]Press Enter for roles and teams[-]", ui.theme.SecondaryText))
+		}
+	case dir.tab == directoryTeams && index >= 0 && index < len(dir.teams):
+		team := &dir.teams[index]
+		field("Team", team.TeamName)
+		if team.BusinessUnit != nil {
+			field("Business Unit", team.BusinessUnit.BUName)
+		}
+		if team.Users == nil {
+			sb.WriteString(fmt.Sprintf("\n[%s]Press Enter for members, a for applications[-]", ui.theme.SecondaryText))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("\n[%s]Members (%d):[-]\n", ui.theme.Label, len(team.Users)))
+		for _, member := range team.Users {
+			name := strings.TrimSpace(member.FirstName + " " + member.LastName)
+			line := member.UserName
+			if name != "" {
+				line += " (" + name + ")"
+			}
+			if member.Relationship != nil && strings.EqualFold(member.Relationship.Name, "ADMIN") {
+				line += " - admin"
+			}
+			sb.WriteString("  " + tview.Escape(line) + "\n")
+		}
+	case dir.tab == directoryBusinessUnits && index >= 0 && index < len(dir.businessUnits):
+		bu := &dir.businessUnits[index]
+		field("Business Unit", bu.BUName)
+		if bu.IsDefault {
+			field("Default", "Yes")
+		}
+		sb.WriteString(fmt.Sprintf("\n[%s]Teams (%d):[-]\n", ui.theme.Label, len(bu.Teams)))
+		for _, team := range bu.Teams {
+			sb.WriteString("  " + tview.Escape(team.TeamName) + "\n")
+		}
+	}
+
+	detailText.SetText(sb.String())
+	detailText.ScrollToBeginning()
+}
+
+// loadDirectoryUser fetches the roles and teams of a user into the list and shows them
+func (ui *UI) loadDirectoryUser(dir *directory, index int, detailText *tview.TextView) {
+	userID := dir.users[index].UserID
+	detailText.SetText(fmt.Sprintf("[%s]Loading user...[-]", ui.theme.Pending))
+	go func() {
+		user, err := ui.identityService.GetUser(context.Background(), userID)
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				detailText.SetText(fmt.Sprintf("[%s]Failed to load user: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			if user.Roles == nil {
+				user.Roles = []identity.Role{}
+			}
+			if user.Teams == nil {
+				user.Teams = []identity.TeamRef{}
+			}
+			if index < len(dir.users) && dir.users[index].UserID == userID {
+				dir.users[index] = *user
+			}
+			if dir.tab == directoryUsers {
+				ui.renderDirectoryDetail(dir, index, detailText)
+			}
+		})
+	}()
+}
+
+// loadDirectoryTeam fetches the members of a team into the list and shows them
+func (ui *UI) loadDirectoryTeam(dir *directory, index int, detailText *tview.TextView) {
+	teamID := dir.teams[index].TeamID
+	detailText.SetText(fmt.Sprintf("[%s]Loading members...[-]", ui.theme.Pending))
+	go func() {
+		team, err := ui.identityService.GetTeam(context.Background(), teamID)
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				detailText.SetText(fmt.Sprintf("[%s]Failed to load team: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			if team.Users == nil {
+				team.Users = []identity.TeamMember{}
+			}
+			if index < len(dir.teams) && dir.teams[index].TeamID == teamID {
+				if team.BusinessUnit == nil {
+					team.BusinessUnit = dir.teams[index].BusinessUnit
+				}
+				team.UserCount = len(team.Users)
+				dir.teams[index] = *team
+			}
+			if dir.tab == directoryTeams {
+				ui.renderDirectoryDetail(dir, index, detailText)
+			}
+		})
+	}()
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showExportForm asks for a file name and writes to it with write. what describes the content
+// in the confirmation, e.g. "12 component licenses". The outcome is shown in statusText, which
+// is restored to shortcuts when the form is cancelled.
+func (ui *UI) showExportForm(title, defaultPath, what string, write func(w io.Writer) error, returnFocus tview.Primitive, statusText *tview.TextView, shortcuts string) {
+	path := defaultPath
+
+	form := ui.newStyledForm()
+	form.AddInputField("File", path, 50, nil, func(text string) {
+		path = strings.TrimSpace(text)
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("export-file")
+		ui.app.SetFocus(returnFocus)
+	}
+	cancel := func() {
+		statusText.SetText(shortcuts)
+		closeForm()
+	}
+
+	form.AddButton("Export", func() {
+		if path == "" {
+			return
+		}
+		closeForm()
+		if err := writeFile(path, write); err != nil {
+			statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			return
+		}
+		statusText.SetText(fmt.Sprintf("[%s]Exported %s to %s[-]", ui.theme.Success, what, tview.Escape(path)))
+	}).
+		AddButton("Cancel", cancel)
+	form.SetCancelFunc(cancel)
+	form.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("export-file", modal(form, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// writeFile creates a file and writes to it with write
+func writeFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/dipsylala/veracode-tui/services/annotations"
 	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/identity"
 	"github.com/dipsylala/veracode-tui/veracode"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -293,8 +293,7 @@ func (ui *UI) createFindingDetailInputHandler(finding *findings.Finding, focusab
 	return func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyEscape:
-			ui.pages.SwitchToPage("findings")
-			ui.app.SetFocus(ui.findingsTable)
+			ui.closeFindingDetail()
 			return nil
 		case tcell.KeyRune:
 			if event.Rune() == 'm' {
@@ -324,6 +323,17 @@ func (ui *UI) createFindingDetailInputHandler(finding *findings.Finding, focusab
 	}
 }
 
+// closeFindingDetail returns from a finding detail view to the view it was opened from
+func (ui *UI) closeFindingDetail() {
+	if back := ui.findingDetailReturn; back != nil {
+		ui.findingDetailReturn = nil
+		back()
+		return
+	}
+	ui.pages.SwitchToPage("findings")
+	ui.app.SetFocus(ui.findingsTable)
+}
+
 // handleDataPathNavigation handles navigation between data paths for STATIC scans
 func (ui *UI) handleDataPathNavigation(direction int) {
 	if ui.currentStaticFlawInfo == nil || len(ui.currentStaticFlawInfo.DataPaths) <= 1 || ui.currentDataPathsView == nil {
@@ -572,6 +582,7 @@ func (ui *UI) buildTechnicalDetailsContent(finding *findings.Finding) string {
 
 	if finding.ScanType == findings.ScanTypeDynamic {
 		sb.WriteString(ui.buildDynamicScanDetails(details))
+		sb.WriteString(ui.formatCVSSScores(finding))
 	} else {
 		sb.WriteString(ui.buildStaticScanDetails(details))
 	}
@@ -923,25 +934,11 @@ func modal(p tview.Primitive, widthProportion, heightProportion int) tview.Primi
 		AddItem(nil, 0, 1, false)
 }
 
-// getAvailableAnnotationActions determines which annotation actions are available
+// getAvailableAnnotationActions determines which annotation actions the principal's capabilities allow
 func (ui *UI) getAvailableAnnotationActions(finding *findings.Finding) []string {
 	baseActions := []string{"COMMENT", "FP", "APPDESIGN", "OSENV", "NETENV"}
 
-	// Check if user has approveMitigations permission
-	principal, err := ui.identityService.GetPrincipal(context.Background())
-	if err != nil || principal == nil {
-		return baseActions
-	}
-
-	hasApproveMitigations := false
-	for _, perm := range principal.Permissions {
-		if perm == "approveMitigations" {
-			hasApproveMitigations = true
-			break
-		}
-	}
-
-	if !hasApproveMitigations {
+	if !ui.capabilities.Can(identity.CapabilityApproveMitigations) {
 		return baseActions
 	}
 
@@ -1172,11 +1169,8 @@ func (ui *UI) submitAnnotationCommentInModal(finding *findings.Finding, comment,
 
 			// Get current user name if available
 			userName := "Current User"
-			if ui.identityService != nil {
-				principal, err := ui.identityService.GetPrincipal(context.Background())
-				if err == nil && principal != nil {
-					userName = principal.Username
-				}
+			if ui.principal != nil {
+				userName = ui.principal.Username
 			}
 
 			// Create new annotation object
@@ -0,0 +1,618 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// findingColumn describes a column of the findings table.
+// For SCA the findings table is grouped by component: groupCell and groupCompare render and sort
+// the component summary rows, while cell renders the CVE rows beneath them.
+type findingColumn struct {
+	info         columnInfo
+	cell         func(finding *findings.Finding) *tview.TableCell
+	compare      func(a, b *findings.Finding) int
+	groupCell    func(comp *SCAComponent) *tview.TableCell
+	groupCompare func(a, b *SCAComponent) int
+}
+
+// defaultFindingsLayout sorts findings by severity, highest first
+var defaultFindingsLayout = config.TableLayout{SortColumn: "severity", SortDescending: true}
+
+// defaultSCALayout sorts SCA components by their severity counts, worst first
+var defaultSCALayout = config.TableLayout{SortColumn: "sev-5", SortDescending: true}
+
+// findingColumns returns all columns available in the findings table for a scan type
+func (ui *UI) findingColumns(scanFilter findings.ScanFilterType) []findingColumn {
+	switch scanFilter {
+	case findings.ScanFilterDynamic:
+		return []findingColumn{
+			ui.issueIDColumn(),
+			ui.policyColumn(),
+			ui.cweColumn(),
+			ui.severityColumn(),
+			ui.cvssColumn(),
+			ui.textColumn("url", "URL", extractURL, true),
+			ui.textColumn("parameter", "Parameter", extractParameter, false),
+			ui.firstFoundColumn(),
+			ui.graceColumn(),
+			ui.statusColumn(),
+		}
+	case findings.ScanFilterSCA:
+		return ui.scaColumns()
+	default:
+		return []findingColumn{
+			ui.issueIDColumn(),
+			ui.policyColumn(),
+			ui.cweColumn(),
+			ui.severityColumn(),
+			ui.textColumn("module", "Module", extractModule, true),
+			ui.fileColumn(),
+			ui.textColumn("attack-vector", "Attack Vector", extractAttackVector, false),
+			ui.firstFoundColumn(),
+			ui.graceColumn(),
+			ui.statusColumn(),
+		}
+	}
+}
+
+// findingColumnInfos returns the column descriptions used for sorting and the column chooser
+func findingColumnInfos(columns []findingColumn) []columnInfo {
+	infos := make([]columnInfo, len(columns))
+	for i, column := range columns {
+		infos[i] = column.info
+	}
+	return infos
+}
+
+// visibleFindingColumns returns the columns of the current scan type not hidden by the layout
+func (ui *UI) visibleFindingColumns(layout config.TableLayout) []findingColumn {
+	var visible []findingColumn
+	for _, column := range ui.findingColumns(ui.findingsScanFilter) {
+		if !layout.IsHidden(column.info.key) {
+			visible = append(visible, column)
+		}
+	}
+	return visible
+}
+
+// findingsTableName returns the settings key for the findings table of the current scan type
+func (ui *UI) findingsTableName() string {
+	return findingsTablePrefix + strings.ToLower(string(ui.findingsScanFilter))
+}
+
+// findingsLayout returns the layout for the findings table of the current scan type
+func (ui *UI) findingsLayout() config.TableLayout {
+	if ui.findingsScanFilter == findings.ScanFilterSCA {
+		return ui.tableLayout(ui.findingsTableName(), defaultSCALayout)
+	}
+	return ui.tableLayout(ui.findingsTableName(), defaultFindingsLayout)
+}
+
+// setFindingsLayout persists a new findings table layout and re-renders the table,
+// keeping the selected finding selected
+func (ui *UI) setFindingsLayout(layout config.TableLayout) {
+	ui.saveTableLayout(ui.findingsTableName(), layout)
+
+	var selectedIssueID int64 = -1
+	row, _ := ui.findingsTable.GetSelection()
+	if ui.findingsScanFilter != findings.ScanFilterSCA && row > 0 && row-1 < len(ui.visibleFindings) {
+		selectedIssueID = ui.visibleFindings[row-1].IssueID
+	}
+
+	ui.sortFindings()
+	ui.renderFindingsTable()
+
+	for i := range ui.visibleFindings {
+		if ui.visibleFindings[i].IssueID == selectedIssueID {
+			ui.findingsTable.Select(i+1, 0)
+			break
+		}
+	}
+}
+
+// sortFindings sorts the loaded findings by the layout's sort column.
+// SCA findings are kept in severity order; their components are sorted when grouped.
+func (ui *UI) sortFindings() {
+	layout := ui.findingsLayout()
+	descending := layout.SortDescending
+
+	var compare func(a, b *findings.Finding) int
+	if ui.findingsScanFilter == findings.ScanFilterSCA {
+		compare = ui.severityColumn().compare
+		descending = true
+	} else {
+		for _, column := range ui.findingColumns(ui.findingsScanFilter) {
+			if column.info.key == layout.SortColumn {
+				compare = column.compare
+				break
+			}
+		}
+	}
+	if compare == nil {
+		return
+	}
+
+	// Sort by ID first so that ties keep a stable, predictable order
+	sort.SliceStable(ui.findings, func(i, j int) bool {
+		return ui.findings[i].IssueID < ui.findings[j].IssueID
+	})
+	sort.SliceStable(ui.findings, func(i, j int) bool {
+		return applySortDirection(compare(&ui.findings[i], &ui.findings[j]), descending) < 0
+	})
+}
+
+// sortSCAComponents sorts grouped SCA components by the layout's sort column
+func (ui *UI) sortSCAComponents(components []*SCAComponent) {
+	layout := ui.findingsLayout()
+
+	// Sort by name first so that ties keep a stable, predictable order
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	for _, column := range ui.scaColumns() {
+		if column.info.key == layout.SortColumn && column.groupCompare != nil {
+			sort.SliceStable(components, func(i, j int) bool {
+				return applySortDirection(column.groupCompare(components[i], components[j]), layout.SortDescending) < 0
+			})
+			return
+		}
+	}
+}
+
+func (ui *UI) issueIDColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "id", title: "ID", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(fmt.Sprintf("%d", finding.IssueID))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(a.IssueID, b.IssueID)
+		},
+	}
+}
+
+func (ui *UI) policyColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "policy", title: "Policy", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(getPolicyIndicator(finding)).
+				SetTextColor(ui.getPolicyIndicatorColor(finding))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return compareBools(a.ViolatesPolicy, b.ViolatesPolicy)
+		},
+	}
+}
+
+func (ui *UI) cweColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "cwe", title: "CWE", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(ui.highlightQuickFilter(extractCWE(finding)))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(numericValue(extractCWE(a)), numericValue(extractCWE(b)))
+		},
+	}
+}
+
+func (ui *UI) severityColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "severity", title: "Sev", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			severity := extractSeverity(finding)
+			return tview.NewTableCell(severity).SetTextColor(ui.getSeverityColor(severity))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(ui.getFindingSeverity(a), ui.getFindingSeverity(b))
+		},
+	}
+}
+
+func (ui *UI) fileColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "file", title: "File:Line", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(ui.highlightQuickFilter(extractFileLine(finding)))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return compareFileLines(extractFileLine(a), extractFileLine(b))
+		},
+	}
+}
+
+func (ui *UI) firstFoundColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "first-found", title: "First Found", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(extractFirstFoundDate(finding))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return compareTimes(firstFoundDate(a), firstFoundDate(b))
+		},
+	}
+}
+
+// graceColumn shows the days left until the grace period of an open policy violation expires.
+// Sorting ascending puts the most overdue first and findings without an SLA last.
+func (ui *UI) graceColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "grace", title: "Grace", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return ui.graceCell(graceDays(finding))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(graceDays(a), graceDays(b))
+		},
+		groupCell: func(comp *SCAComponent) *tview.TableCell {
+			return ui.graceCell(earliestGraceDays(comp.CVEs))
+		},
+		groupCompare: func(a, b *SCAComponent) int {
+			return cmp.Compare(earliestGraceDays(a.CVEs), earliestGraceDays(b.CVEs))
+		},
+	}
+}
+
+// cvssColumn shows the CVSS base score of a finding, v3 where reported, otherwise v2.
+// SCA component rows show the highest score of their CVEs.
+func (ui *UI) cvssColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "cvss", title: "CVSS", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return ui.cvssCell(finding.CVSSBase())
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(a.CVSSBase(), b.CVSSBase())
+		},
+		groupCell: func(comp *SCAComponent) *tview.TableCell {
+			return ui.cvssCell(highestCVSS(comp.CVEs))
+		},
+		groupCompare: func(a, b *SCAComponent) int {
+			return cmp.Compare(highestCVSS(a.CVEs), highestCVSS(b.CVEs))
+		},
+	}
+}
+
+// highestCVSS returns the highest CVSS base score across findings, or -1 if none has one
+func highestCVSS(list []*findings.Finding) float64 {
+	highest := -1.0
+	for _, finding := range list {
+		highest = max(highest, finding.CVSSBase())
+	}
+	return highest
+}
+
+// cvssCell renders a CVSS base score in the color of its rating, or "-" when there is none
+func (ui *UI) cvssCell(score float64) *tview.TableCell {
+	if score < 0 {
+		return tview.NewTableCell("-").SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+	}
+	return tview.NewTableCell(fmt.Sprintf("%.1f", score)).SetTextColor(tcell.GetColor(ui.cvssColorHex(score)))
+}
+
+// noGraceDays is the grace value of findings that are not tracked by the SLA
+const noGraceDays = math.MaxInt
+
+// graceDays returns the days until the grace period expires, or noGraceDays if the finding is not SLA-tracked
+func graceDays(finding *findings.Finding) int {
+	if !finding.IsSLATracked() {
+		return noGraceDays
+	}
+	days, _ := finding.GraceDaysRemaining(time.Now())
+	return days
+}
+
+// earliestGraceDays returns the fewest days remaining across findings
+func earliestGraceDays(list []*findings.Finding) int {
+	earliest := noGraceDays
+	for _, finding := range list {
+		earliest = min(earliest, graceDays(finding))
+	}
+	return earliest
+}
+
+// graceCell renders days remaining, highlighting overdue and due-soon findings
+func (ui *UI) graceCell(days int) *tview.TableCell {
+	switch {
+	case days == noGraceDays:
+		return tview.NewTableCell("-").SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+	case days < 0:
+		return tview.NewTableCell(fmt.Sprintf("%dd over", -days)).SetTextColor(tcell.GetColor(ui.theme.Error)).SetAttributes(tcell.AttrBold)
+	case days <= findings.DueSoonDays:
+		return tview.NewTableCell(fmt.Sprintf("%dd", days)).SetTextColor(tcell.GetColor(ui.theme.Warning))
+	default:
+		return tview.NewTableCell(fmt.Sprintf("%dd", days))
+	}
+}
+
+func (ui *UI) statusColumn() findingColumn {
+	return findingColumn{
+		info: columnInfo{key: "status", title: "Status", sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			return tview.NewTableCell(extractStatus(finding)).SetTextColor(ui.getStatusColor(finding))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return cmp.Compare(statusRank(a), statusRank(b))
+		},
+	}
+}
+
+// textColumn creates a column showing a text value, sorted alphabetically.
+// Searchable columns highlight the characters matched by the quick filter.
+func (ui *UI) textColumn(key, title string, extract func(*findings.Finding) string, searchable bool) findingColumn {
+	return findingColumn{
+		info: columnInfo{key: key, title: title, sortable: true},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			if searchable {
+				return tview.NewTableCell(ui.highlightQuickFilter(extract(finding)))
+			}
+			return tview.NewTableCell(extract(finding))
+		},
+		compare: func(a, b *findings.Finding) int {
+			return strings.Compare(strings.ToLower(extract(a)), strings.ToLower(extract(b)))
+		},
+	}
+}
+
+// scaColumns returns the columns of the grouped SCA findings table
+func (ui *UI) scaColumns() []findingColumn {
+	columns := []findingColumn{
+		{
+			info: columnInfo{key: "component", title: "Component", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				expandChar := "▶"
+				if ui.scaExpandedComponents[comp.Name+"|"+comp.Version] {
+					expandChar = "▼"
+				}
+				return tview.NewTableCell(fmt.Sprintf("%s %s", expandChar, ui.highlightQuickFilter(comp.Name))).
+					SetTextColor(tcell.GetColor(ui.theme.DefaultText)).
+					SetAttributes(tcell.AttrBold)
+			},
+			cell: func(finding *findings.Finding) *tview.TableCell {
+				// Indented CVE name with hyperlink using tview's format: [:::URL]text[:::-]
+				cve := ui.highlightQuickFilter(extractCVE(finding))
+				cveText := fmt.Sprintf("  └─ %s", cve)
+				if cveHref := extractCVEHref(finding); cveHref != "" {
+					cveText = fmt.Sprintf("  └─ [:::%s]%s[:::-]", cveHref, cve)
+				}
+				return tview.NewTableCell(cveText).SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+			},
+		},
+		{
+			info: columnInfo{key: "version", title: "Version", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				return tview.NewTableCell(comp.Version)
+			},
+			cell: func(_ *findings.Finding) *tview.TableCell {
+				return tview.NewTableCell("")
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return strings.Compare(a.Version, b.Version)
+			},
+		},
+		{
+			info: columnInfo{key: "policy", title: "Policy", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				if comp.HasViolation {
+					return tview.NewTableCell(EmojiViolatesPolicy).
+						SetTextColor(tcell.GetColor(ui.theme.PolicyFail)).
+						SetAlign(tview.AlignCenter)
+				}
+				return tview.NewTableCell(" ").
+					SetTextColor(tcell.GetColor(ui.theme.PolicyNeutral)).
+					SetAlign(tview.AlignCenter)
+			},
+			cell: func(finding *findings.Finding) *tview.TableCell {
+				return tview.NewTableCell(getPolicyIndicator(finding)).
+					SetTextColor(ui.getPolicyIndicatorColor(finding)).
+					SetAlign(tview.AlignCenter)
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return compareBools(a.HasViolation, b.HasViolation)
+			},
+		},
+	}
+
+	for sev := 5; sev >= 1; sev-- {
+		columns = append(columns, ui.scaSeverityColumn(sev))
+	}
+
+	return append(columns,
+		ui.cvssColumn(),
+		findingColumn{
+			info: columnInfo{key: "cves", title: "CVEs", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				return tview.NewTableCell(fmt.Sprintf("%d", len(comp.CVEs)))
+			},
+			cell: func(_ *findings.Finding) *tview.TableCell {
+				return tview.NewTableCell("")
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return cmp.Compare(len(a.CVEs), len(b.CVEs))
+			},
+		},
+		findingColumn{
+			info: columnInfo{key: "first-found", title: "First Found", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				return tview.NewTableCell(ui.getEarliestCVEDate(comp.CVEs))
+			},
+			cell: func(finding *findings.Finding) *tview.TableCell {
+				return tview.NewTableCell(extractFirstFoundDate(finding)).
+					SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return compareTimes(earliestFirstFoundDate(a.CVEs), earliestFirstFoundDate(b.CVEs))
+			},
+		},
+		ui.graceColumn(),
+		findingColumn{
+			info: columnInfo{key: "status", title: "Status", sortable: true},
+			groupCell: func(comp *SCAComponent) *tview.TableCell {
+				worstStatus := ui.getWorstCVEStatus(comp.CVEs)
+				if worstStatus == nil {
+					return tview.NewTableCell("-").SetTextColor(tcell.GetColor(ui.theme.DefaultText))
+				}
+				return tview.NewTableCell(extractStatus(worstStatus)).SetTextColor(ui.getStatusColor(worstStatus))
+			},
+			cell: func(finding *findings.Finding) *tview.TableCell {
+				return tview.NewTableCell(extractStatus(finding)).SetTextColor(ui.getStatusColor(finding))
+			},
+			groupCompare: func(a, b *SCAComponent) int {
+				return cmp.Compare(statusRank(ui.getWorstCVEStatus(a.CVEs)), statusRank(ui.getWorstCVEStatus(b.CVEs)))
+			},
+		},
+	)
+}
+
+// scaSeverityColumn creates the count column for one severity level of the SCA table.
+// Sorting compares counts from this severity downwards, so the default Sev:5 sort ranks
+// components by their worst findings first.
+func (ui *UI) scaSeverityColumn(sev int) findingColumn {
+	sevText := fmt.Sprintf("%d", sev)
+	return findingColumn{
+		info: columnInfo{key: fmt.Sprintf("sev-%d", sev), title: fmt.Sprintf("Sev:%d", sev), sortable: true},
+		groupCell: func(comp *SCAComponent) *tview.TableCell {
+			countText := "-"
+			if count := comp.SevCounts[sev]; count > 0 {
+				countText = fmt.Sprintf("%d", count)
+			}
+			return tview.NewTableCell(countText).SetTextColor(ui.getSeverityColor(sevText))
+		},
+		cell: func(finding *findings.Finding) *tview.TableCell {
+			// Show "*" in the column matching the CVE's severity
+			if ui.getFindingSeverity(finding) == sev {
+				return tview.NewTableCell("*").SetTextColor(ui.getSeverityColor(sevText))
+			}
+			return tview.NewTableCell("")
+		},
+		groupCompare: func(a, b *SCAComponent) int {
+			for level := sev; level >= 1; level-- {
+				if result := cmp.Compare(a.SevCounts[level], b.SevCounts[level]); result != 0 {
+					return result
+				}
+			}
+			return 0
+		},
+	}
+}
+
+// getPolicyIndicatorColor returns the color for a finding's policy indicator
+func (ui *UI) getPolicyIndicatorColor(finding *findings.Finding) tcell.Color {
+	switch getPolicyIndicator(finding) {
+	case EmojiPassesPolicy:
+		return tcell.GetColor(ui.theme.PolicyPass)
+	case EmojiViolatesPolicy:
+		return tcell.GetColor(ui.theme.PolicyFail)
+	default:
+		return tcell.GetColor(ui.theme.PolicyNeutral)
+	}
+}
+
+// statusRank orders findings by how much attention they need: closed, open, reopened, new.
+// Within a flaw status, findings with a mitigation sort after those without.
+func statusRank(finding *findings.Finding) int {
+	if finding == nil || finding.FindingStatus == nil {
+		return 0
+	}
+
+	rank := 0
+	switch {
+	case finding.FindingStatus.New:
+		rank = 40
+	case finding.FindingStatus.Status == findings.StatusReopened:
+		rank = 30
+	case finding.FindingStatus.Status == findings.StatusOpen:
+		rank = 20
+	case finding.FindingStatus.Status == findings.StatusClosed:
+		rank = 10
+	}
+
+	resolution := finding.FindingStatus.ResolutionStatus
+	if resolution == "" || resolution == findings.ResolutionNone {
+		resolution = finding.FindingStatus.MitigationReviewStatus
+	}
+	switch resolution {
+	case findings.ResolutionProposed:
+		rank++
+	case findings.ResolutionRejected:
+		rank += 2
+	case findings.ResolutionApproved:
+		rank += 3
+	}
+
+	return rank
+}
+
+// firstFoundDate returns the first found date of a finding, if known
+func firstFoundDate(finding *findings.Finding) *time.Time {
+	if finding.FindingStatus == nil {
+		return nil
+	}
+	return finding.FindingStatus.FirstFoundDate
+}
+
+// earliestFirstFoundDate returns the earliest first found date across findings
+func earliestFirstFoundDate(list []*findings.Finding) *time.Time {
+	var earliest *time.Time
+	for _, finding := range list {
+		if date := firstFoundDate(finding); date != nil && (earliest == nil || date.Before(*earliest)) {
+			earliest = date
+		}
+	}
+	return earliest
+}
+
+// compareFileLines compares "file:line" values by file name, then numerically by line
+func compareFileLines(a, b string) int {
+	fileA, lineA := splitFileLine(a)
+	fileB, lineB := splitFileLine(b)
+	if result := strings.Compare(strings.ToLower(fileA), strings.ToLower(fileB)); result != 0 {
+		return result
+	}
+	return cmp.Compare(lineA, lineB)
+}
+
+// splitFileLine splits a "file:line" value into its file and numeric line parts
+func splitFileLine(value string) (string, int) {
+	if idx := strings.LastIndex(value, ":"); idx >= 0 {
+		if line, err := strconv.Atoi(value[idx+1:]); err == nil {
+			return value[:idx], line
+		}
+	}
+	return value, 0
+}
+
+// numericValue parses a numeric display value, returning -1 for placeholders like "-"
+func numericValue(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
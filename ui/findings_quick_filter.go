@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/findings/query"
+	"github.com/rivo/tview"
+)
+
+// quickFilterTerms splits the quick filter query into lower-case terms
+func quickFilterTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// matchesQuickFilter reports whether every term matches at least one searchable field of the finding.
+// Short fields (CWE, file, module, procedure, component, CVE, URL) are fuzzy matched; the
+// description only matches whole substrings, since fuzzy matching free text matches almost anything.
+func matchesQuickFilter(finding *findings.Finding, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+
+	fields := finding.SearchFields()
+	description := strings.ToLower(finding.Description)
+
+	for _, term := range terms {
+		matched := strings.Contains(description, term)
+		for _, field := range fields {
+			if matched {
+				break
+			}
+			matched = fuzzyMatch(field, term) != nil
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyMatch returns the rune positions in text matched by the (lower-case) term, or nil if it
+// does not match. A contiguous substring match is preferred; otherwise the term's characters
+// must appear in order.
+func fuzzyMatch(text, term string) []int {
+	textRunes := []rune(strings.ToLower(text))
+	termRunes := []rune(term)
+	if len(termRunes) == 0 || len(termRunes) > len(textRunes) {
+		return nil
+	}
+
+	// Contiguous match
+	for start := 0; start+len(termRunes) <= len(textRunes); start++ {
+		if string(textRunes[start:start+len(termRunes)]) == term {
+			positions := make([]int, len(termRunes))
+			for i := range positions {
+				positions[i] = start + i
+			}
+			return positions
+		}
+	}
+
+	// Subsequence match
+	positions := make([]int, 0, len(termRunes))
+	next := 0
+	for i, r := range textRunes {
+		if next < len(termRunes) && r == termRunes[next] {
+			positions = append(positions, i)
+			next++
+		}
+	}
+	if next < len(termRunes) {
+		return nil
+	}
+	return positions
+}
+
+// looksLikeExpression reports whether the filter text is meant as a filter expression rather than
+// fuzzy search terms, so that a mistyped expression reports an error instead of matching nothing
+func looksLikeExpression(text string) bool {
+	return strings.ContainsAny(text, "=<>~(")
+}
+
+// parseFindingsFilter interprets the filter text. Text that parses as a filter expression (for
+// example "severity>=4 and not mitigated") is used as one; anything else falls back to fuzzy
+// matching, unless it looks like an expression, in which case the parse error is returned.
+func parseFindingsFilter(text string) (*query.Query, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	q, err := query.Parse(text)
+	if err != nil {
+		if looksLikeExpression(text) {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return q, nil
+}
+
+// filterVisibleFindings rebuilds the list of findings shown in the table from the quick filter.
+// While the filter expression has an error, all findings are shown.
+func (ui *UI) filterVisibleFindings() {
+	ui.findingsFilterQuery, ui.findingsFilterError = parseFindingsFilter(ui.findingsQuickFilter)
+
+	var terms []string
+	if ui.findingsFilterQuery == nil && ui.findingsFilterError == nil {
+		terms = quickFilterTerms(ui.findingsQuickFilter)
+	}
+
+	ui.visibleFindings = make([]*findings.Finding, 0, len(ui.findings))
+	for i := range ui.findings {
+		finding := &ui.findings[i]
+		if ui.findingsFilterQuery != nil {
+			if !ui.findingsFilterQuery.Match(finding) {
+				continue
+			}
+		} else if !matchesQuickFilter(finding, terms) {
+			continue
+		}
+		ui.visibleFindings = append(ui.visibleFindings, finding)
+	}
+}
+
+// highlightQuickFilter marks the characters of text matched by the quick filter.
+// The text is returned unchanged when no fuzzy filter is active.
+func (ui *UI) highlightQuickFilter(text string) string {
+	if ui.findingsFilterQuery != nil || ui.findingsFilterError != nil {
+		return text
+	}
+	terms := quickFilterTerms(ui.findingsQuickFilter)
+	if len(terms) == 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	highlighted := make([]bool, len(runes))
+	for _, term := range terms {
+		for _, pos := range fuzzyMatch(text, term) {
+			// Lower-casing can change the rune count of some scripts; ignore positions past the end
+			if pos < len(highlighted) {
+				highlighted[pos] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && highlighted[end] == highlighted[start] {
+			end++
+		}
+		segment := tview.Escape(string(runes[start:end]))
+		if highlighted[start] {
+			sb.WriteString("[::r]" + segment + "[::-]")
+		} else {
+			sb.WriteString(segment)
+		}
+		start = end
+	}
+	return sb.String()
+}
+
+// setFindingsQuickFilter applies a new quick filter query and re-renders the findings table
+func (ui *UI) setFindingsQuickFilter(query string) {
+	ui.findingsQuickFilter = query
+	ui.renderFindingsTable()
+	ui.updateCountsLabel()
+}
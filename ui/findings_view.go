@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dipsylala/veracode-tui/config"
 	"github.com/dipsylala/veracode-tui/services/annotations"
 	"github.com/dipsylala/veracode-tui/services/findings"
 	"github.com/gdamore/tcell/v2"
@@ -13,6 +14,11 @@ import (
 
 // showFindings displays findings for the selected context (policy or sandbox)
 func (ui *UI) showFindings() {
+	ui.openFindings(findings.ScanFilterStatic, 0, findings.PolicyFilterAll, "")
+}
+
+// openFindings displays findings for the selected context with the given filters applied
+func (ui *UI) openFindings(scanFilter findings.ScanFilterType, minSeverity int, policyFilter findings.PolicyFilterType, quickFilter string) {
 	if ui.selectedApp == nil {
 		return
 	}
@@ -33,16 +39,19 @@ func (ui *UI) showFindings() {
 	ui.findingsTitleView.SetText(fmt.Sprintf("[white::b]Latest Findings - %s - %s", appName, contextName))
 	ui.findingsTable.SetTitle("") // Clear the table title
 
-	// Clear existing data and reset filters
+	// Clear existing data and apply the requested filters
 	ui.findings = []findings.Finding{}
 	ui.selectedFinding = nil
-	ui.findingsScanFilter = findings.ScanFilterStatic
-	ui.findingsSeverityFilter = 0
-	ui.findingsPolicyFilter = findings.PolicyFilterAll
+	ui.findingsScanFilter = scanFilter
+	ui.findingsSeverityFilter = minSeverity
+	ui.findingsPolicyFilter = policyFilter
+	ui.findingsQuickFilter = quickFilter
+	ui.visibleFindings = nil
 	ui.scaExpandedComponents = make(map[string]bool)
-	ui.findingsFilter.SetCurrentOption(0)                 // Reset to STATIC
-	ui.findingsSeverityFilterDropdown.SetCurrentOption(0) // Reset to All
-	ui.findingsPolicyFilterDropdown.SetCurrentOption(0)   // Reset to All
+	ui.findingsQuickFilterInput.SetText(quickFilter)
+	ui.findingsFilter.SetCurrentOption(scanFilterIndex(scanFilter))
+	ui.findingsSeverityFilterDropdown.SetCurrentOption(severityFilterIndex(minSeverity))
+	ui.findingsPolicyFilterDropdown.SetCurrentOption(policyFilterIndex(policyFilter))
 
 	// Set up the filter callbacks (do this after SetCurrentOption to avoid triggering during init)
 	ui.setupFindingsFilterCallbacks()
@@ -66,10 +75,42 @@ func (ui *UI) showFindings() {
 	// Load findings with initial filter after UI is ready
 	// The count for the loaded scan type will come from the response
 	go func() {
-		ui.loadFindingsWithFilter("STATIC")
+		ui.loadFindingsWithFilter(scanFilter)
 	}()
 }
 
+// scanFilterIndex returns the scan type dropdown option for a scan filter
+func scanFilterIndex(scanFilter findings.ScanFilterType) int {
+	switch scanFilter {
+	case findings.ScanFilterDynamic:
+		return 1
+	case findings.ScanFilterSCA:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// severityFilterIndex returns the minimum severity dropdown option for a severity (0 means All)
+func severityFilterIndex(minSeverity int) int {
+	if minSeverity < 1 || minSeverity > 5 {
+		return 0
+	}
+	return 6 - minSeverity
+}
+
+// policyFilterIndex returns the policy dropdown option for a policy filter
+func policyFilterIndex(policyFilter findings.PolicyFilterType) int {
+	switch policyFilter {
+	case findings.PolicyFilterViolations:
+		return 1
+	case findings.PolicyFilterNonViolations:
+		return 2
+	default:
+		return 0
+	}
+}
+
 // initializeFindingsView creates all the findings view components
 func (ui *UI) initializeFindingsView() {
 	ui.findingsTable = tview.NewTable().
@@ -153,6 +194,25 @@ func (ui *UI) initializeFindingsView() {
 		ui.findingsPolicyFilterDropdown.SetBorderColor(tcell.GetColor(ui.theme.Border))
 	})
 
+	// Create quick filter input
+	ui.findingsQuickFilterInput = tview.NewInputField().
+		SetLabel("Filter: ").
+		SetLabelColor(tcell.GetColor(ui.theme.Label)).
+		SetFieldTextColor(tcell.GetColor(ui.theme.DropDownText)).
+		SetFieldBackgroundColor(tcell.GetColor(ui.theme.DropDownBackground)).
+		SetPlaceholder("text, or an expression like severity>=4 and not mitigated")
+	ui.findingsQuickFilterInput.SetBorder(true).
+		SetBorderColor(tcell.GetColor(ui.theme.Border))
+	ui.findingsQuickFilterInput.SetFocusFunc(func() {
+		ui.findingsQuickFilterInput.SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+	})
+	ui.findingsQuickFilterInput.SetBlurFunc(func() {
+		ui.findingsQuickFilterInput.SetBorderColor(tcell.GetColor(ui.theme.Border))
+	})
+	ui.findingsQuickFilterInput.SetChangedFunc(func(text string) {
+		ui.setFindingsQuickFilter(text)
+	})
+
 	// Create counts label
 	ui.findingsCountsLabel = tview.NewTextView().
 		SetDynamicColors(true).
@@ -164,14 +224,15 @@ func (ui *UI) initializeFindingsView() {
 		SetDirection(tview.FlexColumn).
 		AddItem(ui.findingsFilter, 22, 0, false).
 		AddItem(ui.findingsSeverityFilterDropdown, 30, 0, false).
-		AddItem(ui.findingsPolicyFilterDropdown, 28, 0, false)
+		AddItem(ui.findingsPolicyFilterDropdown, 28, 0, false).
+		AddItem(ui.findingsQuickFilterInput, 0, 1, false)
 
 	// Create keyboard shortcuts bar
 	shortcutsBar := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText(fmt.Sprintf("[%s]Enter/Double-click[-] Details  [%s]Tab[-] Filter  [%s]ESC[-] Back  [%s]q[-] Quit",
-			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
+		SetText(fmt.Sprintf("[%s]Enter/Double-click[-] Details  [%s]Tab[-] Filter  [%s]/[-] Quick Filter  [%s]s/S[-] Sort/Reverse  [%s]c[-] Columns  [%s]b[-] Save View  [%s]h[-] History  [%s]t[-] Dependencies (SCA)  [%s]B[-] SBOM (SCA)  [%s]ESC[-] Back  [%s]q[-] Quit",
+			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
 	shortcutsBar.SetBorder(false)
 
 	ui.findingsFlex = tview.NewFlex().
@@ -185,6 +246,7 @@ func (ui *UI) initializeFindingsView() {
 
 	// Set up input handlers
 	ui.setupFindingsInputHandlers()
+	ui.setupFindingsQuickFilterInputHandlers()
 
 	// Handle finding selection to show detail view
 	ui.findingsTable.SetSelectedFunc(func(row, column int) {
@@ -193,8 +255,8 @@ func (ui *UI) initializeFindingsView() {
 			ui.handleSCARowSelection(row)
 			return
 		}
-		if row > 0 && row-1 < len(ui.findings) {
-			ui.selectedFinding = &ui.findings[row-1]
+		if row > 0 && row-1 < len(ui.visibleFindings) {
+			ui.selectedFinding = ui.visibleFindings[row-1]
 			if ui.selectedFinding.ScanType == findings.ScanTypeSCA {
 				ui.showSCAFindingDetail()
 			} else {
@@ -203,14 +265,24 @@ func (ui *UI) initializeFindingsView() {
 		}
 	})
 
-	// Add double-click support for non-SCA views
+	// Add header click sorting and double-click support for non-SCA views
 	ui.findingsTable.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseLeftClick && len(ui.findings) > 0 {
+			if col := headerColumnAt(ui.findingsTable, event); col >= 0 {
+				layout := ui.findingsLayout()
+				columns := ui.visibleFindingColumns(layout)
+				if col < len(columns) {
+					ui.setFindingsLayout(sortByColumn(columns[col].info, layout))
+				}
+				return action, nil
+			}
+		}
 		if action == tview.MouseLeftDoubleClick {
 			row, _ := ui.findingsTable.GetSelection()
 			// Only handle double-click for non-SCA views
 			if row > 0 && ui.findingsScanFilter != findings.ScanFilterSCA {
-				if row-1 < len(ui.findings) {
-					ui.selectedFinding = &ui.findings[row-1]
+				if row-1 < len(ui.visibleFindings) {
+					ui.selectedFinding = ui.visibleFindings[row-1]
 					ui.showFindingDetail()
 				}
 			}
@@ -228,20 +300,50 @@ func (ui *UI) setupFindingsInputHandlers() {
 		case tcell.KeyEscape:
 			ui.pages.SwitchToPage("detail")
 			ui.app.SetFocus(ui.contextsTable)
+			// Loading the findings may have recorded a snapshot
+			row, _ := ui.contextsTable.GetSelection()
+			ui.updateTrendView(row)
 			return nil
 		case tcell.KeyTab:
 			ui.app.SetFocus(ui.findingsFilter)
 			return nil
 		case tcell.KeyBacktab:
-			ui.app.SetFocus(ui.findingsPolicyFilterDropdown)
+			ui.app.SetFocus(ui.findingsQuickFilterInput)
 			return nil
 		case tcell.KeyRune:
-			if event.Rune() == 'q' {
+			switch event.Rune() {
+			case 'q':
 				ui.app.Stop()
 				return nil
-			} else if event.Rune() == 'f' {
+			case 'f':
 				ui.app.SetFocus(ui.findingsFilter)
 				return nil
+			case '/':
+				ui.app.SetFocus(ui.findingsQuickFilterInput)
+				return nil
+			case 's':
+				ui.setFindingsLayout(cycleSortColumn(findingColumnInfos(ui.findingColumns(ui.findingsScanFilter)), ui.findingsLayout()))
+				return nil
+			case 'S':
+				layout := ui.findingsLayout()
+				layout.SortDescending = !layout.SortDescending
+				ui.setFindingsLayout(layout)
+				return nil
+			case 'b':
+				ui.showSaveBookmarkForm()
+				return nil
+			case 'h':
+				ui.showSnapshotHistory()
+				return nil
+			case 't':
+				ui.showDependencyTree()
+				return nil
+			case 'B':
+				ui.showSBOMExport()
+				return nil
+			case 'c':
+				ui.showColumnChooser(string(ui.findingsScanFilter), findingColumnInfos(ui.findingColumns(ui.findingsScanFilter)), ui.findingsLayout(), ui.setFindingsLayout, ui.findingsTable)
+				return nil
 			}
 		}
 		return event
@@ -283,7 +385,7 @@ func (ui *UI) setupFindingsInputHandlers() {
 			ui.app.SetFocus(ui.findingsTable)
 			return nil
 		case tcell.KeyTab:
-			ui.app.SetFocus(ui.findingsTable)
+			ui.app.SetFocus(ui.findingsQuickFilterInput)
 			return nil
 		case tcell.KeyBacktab:
 			ui.app.SetFocus(ui.findingsSeverityFilterDropdown)
@@ -293,6 +395,21 @@ func (ui *UI) setupFindingsInputHandlers() {
 	})
 }
 
+// setupFindingsQuickFilterInputHandlers configures keyboard input for the quick filter
+func (ui *UI) setupFindingsQuickFilterInputHandlers() {
+	ui.findingsQuickFilterInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape, tcell.KeyEnter, tcell.KeyTab:
+			ui.app.SetFocus(ui.findingsTable)
+			return nil
+		case tcell.KeyBacktab:
+			ui.app.SetFocus(ui.findingsPolicyFilterDropdown)
+			return nil
+		}
+		return event
+	})
+}
+
 // setupFindingsFilterCallbacks configures the filter change callbacks
 func (ui *UI) setupFindingsFilterCallbacks() {
 	ui.findingsFilter.SetSelectedFunc(func(text string, index int) {
@@ -349,14 +466,14 @@ func (ui *UI) loadFindingsWithFilter(scanType findings.ScanFilterType) {
 	ui.findingsScanFilter = scanType
 
 	// Determine context value
-	contextValue := ""
-	if ui.selectionIndex >= 0 && ui.selectionIndex < len(ui.sandboxes) {
-		sandbox := ui.sandboxes[ui.selectionIndex]
-		contextValue = sandbox.GUID
-	}
+	contextValue, contextName := ui.currentSandbox()
 
 	// Capture variables for the goroutine
 	appGUID := ui.selectedApp.GUID
+	appName := DefaultApplicationName
+	if ui.selectedApp.Profile != nil {
+		appName = ui.selectedApp.Profile.Name
+	}
 	capturedContextValue := contextValue
 	capturedScanType := string(scanType)
 	capturedSeverity := ui.findingsSeverityFilter
@@ -418,8 +535,14 @@ func (ui *UI) loadFindingsWithFilter(scanType findings.ScanFilterType) {
 		if result != nil && result.Embedded != nil {
 			ui.findings = result.Embedded.Findings
 
-			// Sort findings by severity (highest first)
-			ui.sortFindingsBySeverity()
+			// Record a snapshot for the findings history before sorting
+			if result.Page != nil {
+				ui.recordSnapshot(appGUID, appName, capturedContextValue, contextName, capturedScanType, result.Embedded.Findings,
+					result.Page.TotalElements, capturedSeverity > 0 || capturedPolicyFilter != findings.PolicyFilterAll)
+			}
+
+			// Sort findings by the chosen column (severity by default)
+			ui.sortFindings()
 
 			// Update the count for this scan type from the response
 			if result.Page != nil {
@@ -466,21 +589,34 @@ func (ui *UI) renderFindingsTable() {
 		return
 	}
 
+	layout := ui.findingsLayout()
+	columns := ui.visibleFindingColumns(layout)
+
 	// Render headers
-	headers := ui.getFindingsTableHeaders(ui.findingsScanFilter)
-	ui.renderTableHeaders(headers)
+	ui.renderTableHeaders(columns, layout)
+
+	// Apply the quick filter
+	ui.filterVisibleFindings()
+	if len(ui.visibleFindings) == 0 {
+		cell := tview.NewTableCell("No findings match the filter").
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetAlign(tview.AlignCenter).
+			SetExpansion(1)
+		ui.findingsTable.SetCell(1, 0, cell)
+		return
+	}
 
 	// Render rows
 	if ui.findingsScanFilter == findings.ScanFilterSCA {
-		ui.renderSCAGroupedFindings()
+		ui.renderSCAGroupedFindings(columns)
 	} else {
-		for i, finding := range ui.findings {
-			ui.renderFindingRow(i+1, &finding)
+		for i, finding := range ui.visibleFindings {
+			ui.renderFindingRow(i+1, finding, columns)
 		}
 	}
 
 	// Auto-select first row if nothing selected and scroll to top
-	if len(ui.findings) > 0 {
+	if len(ui.visibleFindings) > 0 {
 		row, _ := ui.findingsTable.GetSelection()
 		if row == 0 {
 			ui.findingsTable.Select(1, 0)
@@ -507,24 +643,10 @@ func (ui *UI) renderEmptyFindingsTable() {
 	ui.findingsTable.SetCell(1, 0, cell)
 }
 
-// getFindingsTableHeaders returns the appropriate headers based on scan type
-func (ui *UI) getFindingsTableHeaders(scanFilter findings.ScanFilterType) []string {
-	switch scanFilter {
-	case findings.ScanFilterStatic:
-		return []string{"ID", "Policy", "CWE", "Sev", "Module", "File:Line", "Attack Vector", "First Found", "Status"}
-	case findings.ScanFilterDynamic:
-		return []string{"ID", "Policy", "CWE", "Sev", "URL", "Parameter", "First Found", "Status"}
-	case findings.ScanFilterSCA:
-		return []string{"Component", "Version", "Policy", "Sev:5", "Sev:4", "Sev:3", "Sev:2", "Sev:1", "CVEs", "First Found", "Status"}
-	default:
-		return []string{"ID", "Type", "Policy", "CWE", "Sev", "Location", "First Found", "Status"}
-	}
-}
-
-// renderTableHeaders renders the header row
-func (ui *UI) renderTableHeaders(headers []string) {
-	for col, header := range headers {
-		cell := tview.NewTableCell(header).
+// renderTableHeaders renders the header row, marking the sort column
+func (ui *UI) renderTableHeaders(columns []findingColumn, layout config.TableLayout) {
+	for col, column := range columns {
+		cell := tview.NewTableCell(headerTitle(column.info, layout)).
 			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
 			SetAttributes(tcell.AttrBold).
 			SetSelectable(false).
@@ -539,10 +661,18 @@ func (ui *UI) updateFindingRowInTable(finding *findings.Finding) {
 		return
 	}
 
-	// Find the row index in the current findings list
+	// SCA rows are grouped by component, so re-render the table and keep the selection
+	if ui.findingsScanFilter == findings.ScanFilterSCA {
+		row, _ := ui.findingsTable.GetSelection()
+		ui.renderFindingsTable()
+		ui.findingsTable.Select(row, 0)
+		return
+	}
+
+	// Find the row index in the displayed findings list
 	rowIndex := -1
-	for i := range ui.findings {
-		if ui.findings[i].IssueID == finding.IssueID {
+	for i := range ui.visibleFindings {
+		if ui.visibleFindings[i].IssueID == finding.IssueID {
 			rowIndex = i
 			break
 		}
@@ -553,150 +683,16 @@ func (ui *UI) updateFindingRowInTable(finding *findings.Finding) {
 	}
 
 	// Re-render the row (rowIndex + 1 because row 0 is headers)
-	ui.renderFindingRow(rowIndex+1, finding)
+	ui.renderFindingRow(rowIndex+1, finding, ui.visibleFindingColumns(ui.findingsLayout()))
 }
 
-// renderFindingRow renders a single finding row based on scan type
-func (ui *UI) renderFindingRow(rowNum int, finding *findings.Finding) {
-	switch ui.findingsScanFilter {
-	case findings.ScanFilterStatic:
-		ui.renderStaticRow(rowNum, finding)
-	case findings.ScanFilterDynamic:
-		ui.renderDynamicRow(rowNum, finding)
-	case findings.ScanFilterSCA:
-		ui.renderSCARow(rowNum, finding)
+// renderFindingRow renders a single finding row using the visible columns
+func (ui *UI) renderFindingRow(rowNum int, finding *findings.Finding, columns []findingColumn) {
+	for col, column := range columns {
+		ui.findingsTable.SetCell(rowNum, col, column.cell(finding).SetExpansion(1))
 	}
 }
 
-// renderStaticRow renders a row for static scan findings
-//
-//nolint:dupl // Intentionally separate from renderDynamicRow for scan-type-specific clarity
-func (ui *UI) renderStaticRow(rowNum int, finding *findings.Finding) {
-	col := 0
-
-	// Issue ID
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(fmt.Sprintf("%d", finding.IssueID)).SetExpansion(1))
-	col++
-
-	// Policy indicator
-	col = ui.renderPolicyIndicator(rowNum, col, finding)
-
-	// CWE
-	cwe := extractCWE(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(cwe).SetExpansion(1))
-	col++
-
-	// Severity
-	severity := extractSeverity(finding)
-	sevColor := ui.getSeverityColor(severity)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(severity).SetTextColor(sevColor).SetExpansion(1))
-	col++
-
-	// Module
-	module := extractModule(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(module).SetExpansion(1))
-	col++
-
-	// File:Line
-	fileLine := extractFileLine(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(fileLine).SetExpansion(1))
-	col++
-
-	// Attack Vector
-	attackVector := extractAttackVector(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(attackVector).SetExpansion(1))
-	col++
-
-	// First Found Date
-	firstFound := extractFirstFoundDate(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(firstFound).SetExpansion(1))
-	col++
-
-	// Status
-	status := extractStatus(finding)
-	statusColor := ui.getStatusColor(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(status).SetTextColor(statusColor).SetExpansion(1))
-}
-
-// renderDynamicRow renders a row for dynamic scan findings
-//
-//nolint:dupl // Intentionally separate from renderStaticRow for scan-type-specific clarity
-func (ui *UI) renderDynamicRow(rowNum int, finding *findings.Finding) {
-	col := 0
-
-	// Issue ID
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(fmt.Sprintf("%d", finding.IssueID)).SetExpansion(1))
-	col++
-
-	// Policy indicator
-	col = ui.renderPolicyIndicator(rowNum, col, finding)
-
-	// CWE
-	cwe := extractCWE(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(cwe).SetExpansion(1))
-	col++
-
-	// Severity
-	severity := extractSeverity(finding)
-	sevColor := ui.getSeverityColor(severity)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(severity).SetTextColor(sevColor).SetExpansion(1))
-	col++
-
-	// URL
-	url := extractURL(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(url).SetExpansion(1))
-	col++
-
-	// Parameter
-	param := extractParameter(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(param).SetExpansion(1))
-	col++
-
-	// First Found Date
-	firstFound := extractFirstFoundDate(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(firstFound).SetExpansion(1))
-	col++
-
-	// Status
-	status := extractStatus(finding)
-	statusColor := ui.getStatusColor(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(status).SetTextColor(statusColor).SetExpansion(1))
-}
-
-// renderSCARow renders a row for SCA scan findings
-func (ui *UI) renderSCARow(rowNum int, finding *findings.Finding) {
-	col := 0
-
-	// Policy indicator (no Issue ID for SCA)
-	col = ui.renderPolicyIndicator(rowNum, col, finding)
-
-	// Component
-	component := extractComponent(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(component).SetExpansion(1))
-	col++
-
-	// Version
-	version := extractVersion(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(version).SetExpansion(1))
-	col++
-
-	// Policy
-	status := extractStatus(finding)
-	statusColor := ui.getStatusColor(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(status).SetTextColor(statusColor).SetExpansion(1))
-	col++
-
-	// CVE
-	cve := extractCVE(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(cve).SetExpansion(1))
-	col++
-
-	// Severity
-	severity := extractSeverity(finding)
-	sevColor := ui.getSeverityColor(severity)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(severity).SetTextColor(sevColor).SetExpansion(1))
-}
-
 // SCAComponent represents a grouped component with its CVEs
 type SCAComponent struct {
 	Name         string
@@ -708,21 +704,23 @@ type SCAComponent struct {
 }
 
 // renderSCAGroupedFindings renders SCA findings grouped by component
-func (ui *UI) renderSCAGroupedFindings() {
+func (ui *UI) renderSCAGroupedFindings(columns []findingColumn) {
 	// Group findings by component+version
 	components := ui.groupSCAByComponent()
 
 	rowNum := 1
 	for _, comp := range components {
 		// Render component summary row
-		ui.renderSCAComponentRow(rowNum, comp)
+		for col, column := range columns {
+			ui.findingsTable.SetCell(rowNum, col, column.groupCell(comp).SetExpansion(1))
+		}
 		rowNum++
 
 		// If expanded, render CVE detail rows
 		componentKey := comp.Name + "|" + comp.Version
 		if ui.scaExpandedComponents[componentKey] {
 			for _, cve := range comp.CVEs {
-				ui.renderSCACVERow(rowNum, cve)
+				ui.renderFindingRow(rowNum, cve, columns)
 				rowNum++
 			}
 		}
@@ -733,8 +731,7 @@ func (ui *UI) renderSCAGroupedFindings() {
 func (ui *UI) groupSCAByComponent() []*SCAComponent {
 	componentMap := make(map[string]*SCAComponent)
 
-	for i := range ui.findings {
-		finding := &ui.findings[i]
+	for _, finding := range ui.visibleFindings {
 		component := extractComponent(finding)
 		version := extractVersion(finding)
 		key := component + "|" + version
@@ -766,39 +763,16 @@ func (ui *UI) groupSCAByComponent() []*SCAComponent {
 		}
 	}
 
-	// Convert map to sorted slice (by severity counts, then name)
+	// Convert map to a slice sorted by the chosen column
 	components := make([]*SCAComponent, 0, len(componentMap))
 	for _, comp := range componentMap {
 		components = append(components, comp)
 	}
-
-	// Sort by severity counts (5, 4, 3, 2, 1 in descending order), then by name
-	for i := 0; i < len(components); i++ {
-		for j := i + 1; j < len(components); j++ {
-			if ui.shouldSwapComponents(components[i], components[j]) {
-				components[i], components[j] = components[j], components[i]
-			}
-		}
-	}
+	ui.sortSCAComponents(components)
 
 	return components
 }
 
-// shouldSwapComponents determines if two components should be swapped during sorting
-func (ui *UI) shouldSwapComponents(a, b *SCAComponent) bool {
-	// Compare severity counts from 5 down to 1
-	for sev := 5; sev >= 1; sev-- {
-		if a.SevCounts[sev] < b.SevCounts[sev] {
-			return true
-		} else if a.SevCounts[sev] > b.SevCounts[sev] {
-			return false
-		}
-	}
-
-	// If all severity counts are equal, sort by name
-	return a.Name > b.Name
-}
-
 // getEarliestCVEDate finds the earliest first found date across all CVEs
 func (ui *UI) getEarliestCVEDate(cves []*findings.Finding) string {
 	var earliestDate *time.Time
@@ -831,161 +805,6 @@ func (ui *UI) getWorstCVEStatus(cves []*findings.Finding) *findings.Finding {
 	return worstStatus
 }
 
-// renderSeverityCounts renders the severity count columns for SCA component row
-func (ui *UI) renderSeverityCounts(rowNum int, col int, comp *SCAComponent) int {
-	for sev := 5; sev >= 1; sev-- {
-		count := comp.SevCounts[sev]
-		countText := "-"
-		if count > 0 {
-			countText = fmt.Sprintf("%d", count)
-		}
-		sevColor := ui.getSeverityColor(fmt.Sprintf("%d", sev))
-		ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(countText).
-			SetTextColor(sevColor).
-			SetExpansion(1))
-		col++
-	}
-	return col
-}
-
-// renderSCAComponentRow renders a component summary row
-func (ui *UI) renderSCAComponentRow(rowNum int, comp *SCAComponent) {
-	col := 0
-	componentKey := comp.Name + "|" + comp.Version
-	expanded := ui.scaExpandedComponents[componentKey]
-
-	// Expand/collapse indicator + Component name
-	expandChar := "▶"
-	if expanded {
-		expandChar = "▼"
-	}
-	componentText := fmt.Sprintf("%s %s", expandChar, comp.Name)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(componentText).
-		SetTextColor(tcell.GetColor(ui.theme.DefaultText)).
-		SetAttributes(tcell.AttrBold).
-		SetExpansion(1))
-	col++
-
-	// Version
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(comp.Version).SetExpansion(1))
-	col++
-
-	// Policy indicator (whether component affects policy)
-	statusText := " "
-	statusColor := tcell.GetColor(ui.theme.PolicyNeutral)
-	if comp.HasViolation {
-		statusText = EmojiViolatesPolicy
-		statusColor = tcell.GetColor(ui.theme.PolicyFail)
-	}
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(statusText).
-		SetTextColor(statusColor).
-		SetAlign(tview.AlignCenter).
-		SetExpansion(1))
-	col++
-
-	// Severity counts (5, 4, 3, 2, 1)
-	col = ui.renderSeverityCounts(rowNum, col, comp)
-
-	// Total CVEs count
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(fmt.Sprintf("%d", len(comp.CVEs))).
-		SetExpansion(1))
-	col++
-
-	// First Found Date (earliest from all CVEs)
-	firstFound := ui.getEarliestCVEDate(comp.CVEs)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(firstFound).SetExpansion(1))
-	col++
-
-	// Status (aggregate status across CVEs - show worst status)
-	worstStatus := ui.getWorstCVEStatus(comp.CVEs)
-	status := "-"
-	statusTextColor := tcell.GetColor(ui.theme.DefaultText)
-	if worstStatus != nil {
-		status = extractStatus(worstStatus)
-		statusTextColor = ui.getStatusColor(worstStatus)
-	}
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(status).SetTextColor(statusTextColor).SetExpansion(1))
-}
-
-// renderSCACVERow renders a CVE detail row (indented under component)
-func (ui *UI) renderSCACVERow(rowNum int, finding *findings.Finding) {
-	col := 0
-
-	// Indented CVE name with hyperlink
-	cve := extractCVE(finding)
-	cveHref := extractCVEHref(finding)
-
-	var cveText string
-	if cveHref != "" {
-		// Create clickable hyperlink using tview's format: [:::URL]text[:::-]
-		cveText = fmt.Sprintf("  └─ [:::%s]%s[:::-]", cveHref, cve)
-	} else {
-		cveText = fmt.Sprintf("  └─ %s", cve)
-	}
-
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(cveText).
-		SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
-		SetExpansion(1))
-	col++
-
-	// Skip version column (empty for CVE rows)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell("").SetExpansion(1))
-	col++
-
-	// Policy indicator for this CVE
-	policyIndicator := getPolicyIndicator(finding)
-	policyColor := tcell.GetColor(ui.theme.PolicyNeutral)
-	switch policyIndicator {
-	case "✓":
-		policyColor = tcell.GetColor(ui.theme.PolicyPass)
-	case "❌":
-		policyColor = tcell.GetColor(ui.theme.PolicyFail)
-	}
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(policyIndicator).
-		SetTextColor(policyColor).
-		SetAlign(tview.AlignCenter).
-		SetExpansion(1))
-	col++
-
-	// Severity columns - show "*" in the appropriate column
-	severity := extractSeverity(finding)
-	sevValue := 0
-	if severity != "-" {
-		_, _ = fmt.Sscanf(severity, "%d", &sevValue)
-	}
-	sevColor := ui.getSeverityColor(severity)
-
-	for sev := 5; sev >= 1; sev-- {
-		if sev == sevValue {
-			ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell("*").
-				SetTextColor(sevColor).
-				SetExpansion(1))
-		} else {
-			ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell("").SetExpansion(1))
-		}
-		col++
-	}
-
-	// CVEs column - empty for detail rows
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell("").
-		SetExpansion(1))
-	col++
-
-	// First Found Date
-	firstFound := extractFirstFoundDate(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(firstFound).
-		SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
-		SetExpansion(1))
-	col++
-
-	// Status
-	status := extractStatus(finding)
-	statusColor := ui.getStatusColor(finding)
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(status).
-		SetTextColor(statusColor).
-		SetExpansion(1))
-}
-
 // handleSCARowSelection handles row selection for SCA grouped view
 func (ui *UI) handleSCARowSelection(row int) {
 	if row == 0 {
@@ -1024,20 +843,6 @@ func (ui *UI) handleSCARowSelection(row int) {
 	}
 }
 
-// renderPolicyIndicator renders the policy indicator column
-func (ui *UI) renderPolicyIndicator(rowNum, col int, finding *findings.Finding) int {
-	policyIndicator := getPolicyIndicator(finding)
-	policyColor := tcell.GetColor(ui.theme.PolicyNeutral)
-	switch policyIndicator {
-	case "✓":
-		policyColor = tcell.GetColor(ui.theme.PolicyPass)
-	case "❌":
-		policyColor = tcell.GetColor(ui.theme.PolicyFail)
-	}
-	ui.findingsTable.SetCell(rowNum, col, tview.NewTableCell(policyIndicator).SetTextColor(policyColor).SetExpansion(1))
-	return col + 1
-}
-
 // Helper functions for extracting finding data
 
 func getPolicyIndicator(finding *findings.Finding) string {
@@ -1527,20 +1332,14 @@ func (ui *UI) loadDynamicCount() {
 }
 
 func (ui *UI) updateCountsLabel() {
-	ui.findingsCountsLabel.SetText(fmt.Sprintf("  [white]Static: [%s]%d[white]  |  Dynamic: [%s]%d[white]  |  SCA: [%s]%d", ui.theme.Label, ui.staticCount, ui.theme.Label, ui.dynamicCount, ui.theme.Label, ui.scaCount))
-}
-
-func (ui *UI) sortFindingsBySeverity() {
-	// Sort by severity in descending order (5 = highest, 0 = lowest)
-	for i := 0; i < len(ui.findings); i++ {
-		for j := i + 1; j < len(ui.findings); j++ {
-			sevI := ui.getFindingSeverity(&ui.findings[i])
-			sevJ := ui.getFindingSeverity(&ui.findings[j])
-			if sevI < sevJ {
-				ui.findings[i], ui.findings[j] = ui.findings[j], ui.findings[i]
-			}
-		}
-	}
+	text := fmt.Sprintf("  [white]Static: [%s]%d[white]  |  Dynamic: [%s]%d[white]  |  SCA: [%s]%d", ui.theme.Label, ui.staticCount, ui.theme.Label, ui.dynamicCount, ui.theme.Label, ui.scaCount)
+	if ui.findingsFilterError != nil {
+		text += fmt.Sprintf("[white]  |  [%s]Filter error: %s[-]", ui.theme.Error, tview.Escape(ui.findingsFilterError.Error()))
+	} else if ui.findingsQuickFilter != "" {
+		text += fmt.Sprintf("[white]  |  Filtered: [%s]%d[white] of [%s]%d", ui.theme.Label, len(ui.visibleFindings), ui.theme.Label, len(ui.findings))
+	}
+	text += ui.cacheIndicator()
+	ui.findingsCountsLabel.SetText(text)
 }
 
 func (ui *UI) getFindingSeverity(finding *findings.Finding) int {
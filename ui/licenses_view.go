@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// defaultLicensesFile is the file name suggested when exporting the license report
+const defaultLicensesFile = "licenses.csv"
+
+// showApplicationLicenses shows the component licenses of the selected application's policy scan
+func (ui *UI) showApplicationLicenses() {
+	if ui.selectedApp == nil {
+		return
+	}
+	ui.showLicenses(fmt.Sprintf(" Licenses - %s ", tview.Escape(appDisplayName(ui.selectedApp))), ui.contextsTable, false, ui.selectedApplicationLoader())
+}
+
+// showPortfolioLicenses shows the component licenses across all applications matching the
+// current search and filters
+func (ui *UI) showPortfolioLicenses() {
+	ui.showLicenses(" Licenses - Portfolio ", ui.applicationsTable, true, ui.matchingApplicationsLoader())
+}
+
+// showLicenses lists the licenses of the SCA components of the applications returned by loadApps,
+// highest risk first. h and c restrict the list to high risk and copyleft licenses, e exports it.
+func (ui *UI) showLicenses(title string, returnFocus tview.Primitive, showApps bool, loadApps func() ([]applications.Application, error)) {
+	var results []portfolio.AppFindings
+	var rows []portfolio.ComponentLicense
+	var filter portfolio.LicenseFilter
+	loaded := false
+
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[%s]Loading applications...[-]", ui.theme.Pending))
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	shortcuts := fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]h[-] High Risk  [%s]c[-] Copyleft  [%s]e[-] Export CSV  [%s]ESC[-] Close",
+		ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info)
+	statusText.SetText(shortcuts)
+
+	render := func() {
+		rows = portfolio.Licenses(results, filter)
+		ui.renderLicenses(summaryText, table, results, rows, filter, showApps)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			ui.pages.RemovePage("licenses")
+			ui.app.SetFocus(returnFocus)
+			return nil
+		}
+		if !loaded {
+			return event
+		}
+		switch event.Rune() {
+		case 'h':
+			filter.HighRisk = !filter.HighRisk
+			render()
+			return nil
+		case 'c':
+			filter.Copyleft = !filter.Copyleft
+			render()
+			return nil
+		case 'e':
+			listed := rows
+			ui.showExportForm(" Export Licenses ", defaultLicensesFile, fmt.Sprintf("%d component licenses", len(listed)), func(w io.Writer) error {
+				return portfolio.WriteLicensesCSV(w, listed)
+			}, table, statusText, shortcuts)
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("licenses", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(table)
+
+	fetch := portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{}, []string{string(findings.ScanTypeSCA)})
+	ui.collectFindings(loadApps, fetch, summaryText, func(collected []portfolio.AppFindings) {
+		results = collected
+		loaded = true
+		render()
+	})
+}
+
+// renderLicenses fills the license summary and table
+func (ui *UI) renderLicenses(summaryText *tview.TextView, table *tview.Table, results []portfolio.AppFindings, rows []portfolio.ComponentLicense, filter portfolio.LicenseFilter, showApps bool) {
+	counts := make(map[findings.LicenseRisk]int)
+	copyleft := 0
+	for _, row := range rows {
+		counts[row.License.Risk()]++
+		if row.License.IsCopyleft() {
+			copyleft++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]Component licenses:[-] %d   [%s]High:[-] [%s]%d[-]   [%s]Medium:[-] [%s]%d[-]   [%s]Low:[-] %d   [%s]Copyleft:[-] %d",
+		ui.theme.Label, len(rows),
+		ui.theme.Label, ui.theme.Error, counts[findings.LicenseRiskHigh],
+		ui.theme.Label, ui.theme.Warning, counts[findings.LicenseRiskMedium],
+		ui.theme.Label, counts[findings.LicenseRiskLow],
+		ui.theme.Label, copyleft))
+	if showApps {
+		sb.WriteString(fmt.Sprintf("   [%s]Applications:[-] %d", ui.theme.Label, len(results)))
+	}
+	var line []string
+	if filter.HighRisk {
+		line = append(line, "high risk")
+	}
+	if filter.Copyleft {
+		line = append(line, "copyleft")
+	}
+	if len(line) > 0 {
+		sb.WriteString(fmt.Sprintf("\n[%s]Showing %s licenses only[-]", ui.theme.Info, strings.Join(line, " or ")))
+	}
+	failed := 0
+	for i := range results {
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		sb.WriteString(fmt.Sprintf("   [%s]%d applications could not be loaded[-]", ui.theme.Error, failed))
+	}
+	summaryText.SetText(sb.String())
+
+	headers := []string{"Risk", "License", "Copyleft", "Component", "Version"}
+	if showApps {
+		headers = append(headers, "Apps", "Applications")
+	}
+	table.Clear()
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if len(rows) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No SCA component licenses").
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		return
+	}
+
+	for i, row := range rows {
+		license := row.License.ID
+		if license == "" {
+			license = "(none reported)"
+		}
+		copyleftText := ""
+		if row.License.IsCopyleft() {
+			copyleftText = "Yes"
+		}
+		cells := []*tview.TableCell{
+			ui.licenseRiskCell(row.License.Risk()),
+			tview.NewTableCell(tview.Escape(license)),
+			tview.NewTableCell(copyleftText).SetTextColor(tcell.GetColor(ui.theme.Warning)),
+			tview.NewTableCell(tview.Escape(row.Component)),
+			tview.NewTableCell(tview.Escape(row.Version)),
+		}
+		if showApps {
+			cells = append(cells,
+				tview.NewTableCell(fmt.Sprintf("%d", len(row.Applications))).SetAlign(tview.AlignRight),
+				tview.NewTableCell(tview.Escape(strings.Join(row.Applications, ", "))).SetExpansion(1))
+		} else {
+			cells[len(cells)-1].SetExpansion(1)
+		}
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// licenseRiskCell returns a table cell for a license risk level, coloured by severity
+func (ui *UI) licenseRiskCell(risk findings.LicenseRisk) *tview.TableCell {
+	cell := tview.NewTableCell(risk.String())
+	switch risk {
+	case findings.LicenseRiskHigh:
+		cell.SetTextColor(tcell.GetColor(ui.theme.Error))
+	case findings.LicenseRiskMedium:
+		cell.SetTextColor(tcell.GetColor(ui.theme.Warning))
+	case findings.LicenseRiskUnknown:
+		cell.SetTextColor(tcell.GetColor(ui.theme.SecondaryText))
+	}
+	return cell
+}
@@ -0,0 +1,309 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/compliance"
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// policyEvaluation is the state of the policy view: the findings evaluated against the policy
+// and the findings assumed to be mitigated in a what-if simulation
+type policyEvaluation struct {
+	input     compliance.Input
+	status    string // Compliance status reported by the platform
+	actual    *compliance.Result
+	simulated *compliance.Result
+	whatIf    map[int64]bool
+}
+
+// evaluate evaluates the policy with the findings assumed to be mitigated
+func (e *policyEvaluation) evaluate() {
+	var ids []int64
+	for id := range e.whatIf {
+		ids = append(ids, id)
+	}
+	in := e.input
+	in.Findings = compliance.AssumeMitigated(in.Findings, ids)
+	e.simulated = compliance.Evaluate(in)
+}
+
+// showApplicationPolicy evaluates the selected application's policy locally and lists each rule
+// with whether it passes and the findings breaking it. Findings can be marked as mitigated to see
+// whether the application would then pass.
+func (ui *UI) showApplicationPolicy() {
+	if ui.selectedApp == nil || ui.selectedApp.Profile == nil || len(ui.selectedApp.Profile.Policies) == 0 {
+		return
+	}
+	app := *ui.selectedApp
+	appPolicy := app.Profile.Policies[0]
+
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetText(fmt.Sprintf("[%s]Loading policy and findings...[-]", ui.theme.Pending))
+
+	rulesTable := ui.newPolicyTable()
+	findingsTable := ui.newPolicyTable()
+	findingsTable.SetBorder(true).
+		SetTitle(" Findings Breaking the Rule ").
+		SetTitleAlign(tview.AlignLeft)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]Tab[-] Rules/Findings  [%s]Space[-] What If Mitigated  [%s]x[-] Clear What If  [%s]ESC[-] Close",
+			ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	var evaluation *policyEvaluation
+	render := func() {
+		ui.renderPolicyEvaluation(summaryText, rulesTable, findingsTable, evaluation)
+	}
+
+	closePolicy := func() {
+		ui.pages.RemovePage("policy")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+	handleKeys := func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closePolicy()
+			return nil
+		case event.Key() == tcell.KeyTab || event.Key() == tcell.KeyBacktab:
+			if ui.app.GetFocus() == rulesTable {
+				ui.app.SetFocus(findingsTable)
+			} else {
+				ui.app.SetFocus(rulesTable)
+			}
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'x' && evaluation != nil:
+			clear(evaluation.whatIf)
+			evaluation.evaluate()
+			render()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ' && evaluation != nil && ui.app.GetFocus() == findingsTable:
+			row, _ := findingsTable.GetSelection()
+			if f, ok := findingsTable.GetCell(row, 0).GetReference().(*findings.Finding); ok {
+				if evaluation.whatIf[f.IssueID] {
+					delete(evaluation.whatIf, f.IssueID)
+				} else {
+					evaluation.whatIf[f.IssueID] = true
+				}
+				evaluation.evaluate()
+				render()
+			}
+			return nil
+		}
+		return event
+	}
+	rulesTable.SetInputCapture(handleKeys)
+	findingsTable.SetInputCapture(handleKeys)
+	rulesTable.SetSelectionChangedFunc(func(_, _ int) {
+		if evaluation != nil {
+			ui.renderRuleFindings(findingsTable, rulesTable, evaluation)
+		}
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 7, 0, false).
+		AddItem(rulesTable, 0, 1, true).
+		AddItem(findingsTable, 0, 1, false).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s - Policy %s ", tview.Escape(appDisplayName(&app)), tview.Escape(appPolicy.Name))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("policy", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(rulesTable)
+
+	go func() {
+		policy, err := ui.policiesService.GetPolicy(appPolicy.GUID)
+		var list []findings.Finding
+		if err == nil {
+			list, err = portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{}, slaScanTypes)(&app)
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				summaryText.SetText(fmt.Sprintf("[%s]Error loading policy: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			in := compliance.Input{Policy: policy, Findings: list, Scans: app.Scans, Now: time.Now()}
+			evaluation = &policyEvaluation{input: in, status: appPolicy.PolicyComplianceStatus, actual: compliance.Evaluate(in), whatIf: make(map[int64]bool)}
+			evaluation.evaluate()
+			render()
+			rulesTable.Select(1, 0)
+		})
+	}()
+}
+
+// newPolicyTable creates a selectable table for the policy view
+func (ui *UI) newPolicyTable() *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+	return table
+}
+
+// renderPolicyEvaluation fills the policy summary and the rules table. The rules show the outcome
+// of the simulation, which is the actual outcome until findings are marked as mitigated.
+func (ui *UI) renderPolicyEvaluation(summaryText *tview.TextView, rulesTable, findingsTable *tview.Table, e *policyEvaluation) {
+	policy := e.input.Policy
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("[%s]Policy:[-] %s (version %d)   [%s]Compliance:[-] [%s]%s[-]   [%s]Local evaluation:[-] [%s]%s[-]",
+		ui.theme.Label, tview.Escape(policy.Name), policy.Version, ui.theme.Label, ui.complianceColor(e.status), e.status,
+		ui.theme.Label, ui.complianceColor(string(e.actual.Status)), e.actual.Status))
+	if len(e.whatIf) > 0 {
+		summary.WriteString(fmt.Sprintf("   [%s]What if %d mitigated:[-] [%s]%s[-]", ui.theme.Label, len(e.whatIf),
+			ui.complianceColor(string(e.simulated.Status)), e.simulated.Status))
+	}
+	summary.WriteString("\n")
+	if policy.Description != "" {
+		summary.WriteString(tview.Escape(policy.Description) + "\n")
+	}
+	summary.WriteString(fmt.Sprintf("[%s]Scan frequency:[-] %s\n", ui.theme.Label, describeScanFrequency(policy.ScanFrequencyRules)))
+	summary.WriteString(fmt.Sprintf("[%s]Grace periods (days):[-] %s\n", ui.theme.Label, describeGracePeriods(policy, findings.ScanTypeStatic)))
+	if policy.SCAGracePeriods != nil {
+		summary.WriteString(fmt.Sprintf("[%s]SCA grace periods (days):[-] %s\n", ui.theme.Label, describeGracePeriods(policy, findings.ScanTypeSCA)))
+	}
+	if toFix := e.simulated.ToFix(); len(toFix) > 0 {
+		summary.WriteString(fmt.Sprintf("[%s]To pass the finding rules, fix or mitigate %d findings[-]\n", ui.theme.Warning, len(toFix)))
+	}
+	summaryText.SetText(summary.String())
+
+	row, _ := rulesTable.GetSelection()
+	rulesTable.Clear()
+	for col, header := range []string{"Requirement", "Rule", "Status", "Explanation"} {
+		rulesTable.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	for i, rule := range e.simulated.Rules {
+		rulesTable.SetCell(i+1, 0, tview.NewTableCell(string(rule.Kind)))
+		rulesTable.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(rule.Rule)).SetMaxWidth(60))
+		rulesTable.SetCell(i+1, 2, tview.NewTableCell(ruleStatusLabel(rule.Status)).SetTextColor(tcell.GetColor(ui.ruleStatusColor(rule.Status))))
+		rulesTable.SetCell(i+1, 3, tview.NewTableCell(tview.Escape(rule.Explanation)).SetExpansion(1))
+	}
+	if row > 0 && row <= len(e.simulated.Rules) {
+		rulesTable.Select(row, 0)
+	}
+	ui.renderRuleFindings(findingsTable, rulesTable, e)
+}
+
+// renderRuleFindings lists the findings breaking the highlighted rule before any simulation, so
+// that findings marked as mitigated stay listed and can be unmarked
+func (ui *UI) renderRuleFindings(findingsTable, rulesTable *tview.Table, e *policyEvaluation) {
+	selected, _ := findingsTable.GetSelection()
+	findingsTable.Clear()
+	for col, header := range []string{"What If", "ID", "Scan", "Severity", "Finding"} {
+		findingsTable.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+
+	row, _ := rulesTable.GetSelection()
+	if row < 1 || row > len(e.actual.Rules) {
+		return
+	}
+	rule := e.actual.Rules[row-1]
+	if len(rule.Findings) == 0 {
+		findingsTable.SetCell(1, 0, tview.NewTableCell(rule.Explanation).
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		return
+	}
+	for i, f := range rule.Findings {
+		mark := ""
+		if e.whatIf[f.IssueID] {
+			mark = "mitigated"
+		}
+		severity := e.input.Policy.Severity(f)
+		findingsTable.SetCell(i+1, 0, tview.NewTableCell(mark).SetTextColor(tcell.GetColor(ui.theme.Success)).SetReference(f))
+		findingsTable.SetCell(i+1, 1, tview.NewTableCell(fmt.Sprintf("%d", f.IssueID)))
+		findingsTable.SetCell(i+1, 2, tview.NewTableCell(string(f.ScanType)))
+		findingsTable.SetCell(i+1, 3, tview.NewTableCell(policies.SeverityName(severity)).SetTextColor(tcell.GetColor(ui.getSeverityColorHex(severity))))
+		findingsTable.SetCell(i+1, 4, tview.NewTableCell(tview.Escape(describeFinding(f))).SetExpansion(1))
+	}
+	if selected > 0 && selected <= len(rule.Findings) {
+		findingsTable.Select(selected, 0)
+	} else {
+		findingsTable.Select(1, 0)
+	}
+}
+
+// ruleStatusLabel returns the label shown for the outcome of a rule
+func ruleStatusLabel(status compliance.Status) string {
+	switch status {
+	case compliance.Passed:
+		return "Passes"
+	case compliance.ConditionalPass:
+		return "Within grace"
+	case compliance.DidNotPass:
+		return "Fails"
+	default:
+		return "Checked by Veracode"
+	}
+}
+
+// ruleStatusColor returns the theme color of the outcome of a rule
+func (ui *UI) ruleStatusColor(status compliance.Status) string {
+	if status == compliance.NotEvaluated {
+		return ui.theme.SecondaryText
+	}
+	return ui.complianceColor(string(status))
+}
+
+// complianceColor returns the theme color of a policy compliance status, given either as an enum
+// ("DID_NOT_PASS") or as the summary report's text ("Did Not Pass")
+func (ui *UI) complianceColor(status string) string {
+	switch strings.ToUpper(strings.ReplaceAll(status, " ", "_")) {
+	case "PASSED", "PASS":
+		return ui.theme.PolicyPass
+	case "DID_NOT_PASS", "FAIL":
+		return ui.theme.PolicyFail
+	case "CONDITIONAL_PASS":
+		return ui.theme.Warning
+	default:
+		return ui.theme.SecondaryText
+	}
+}
+
+// describeScanFrequency lists the required scan frequencies, e.g. "STATIC quarterly, SCA monthly"
+func describeScanFrequency(rules []policies.ScanFrequencyRule) string {
+	var parts []string
+	for _, rule := range rules {
+		if rule.Frequency == policies.FrequencyNotRequired {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", rule.ScanType, strings.ToLower(strings.ReplaceAll(string(rule.Frequency), "_", " "))))
+	}
+	if len(parts) == 0 {
+		return "Not required"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeGracePeriods lists the grace periods of a policy from very high to very low severity
+func describeGracePeriods(policy *policies.Policy, scanType findings.ScanType) string {
+	var parts []string
+	for severity := 5; severity >= 1; severity-- {
+		parts = append(parts, fmt.Sprintf("%s %d", policies.SeverityName(severity), policy.GracePeriod(severity, scanType)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// loadPrincipal fetches the current principal and derives the capabilities that decide which
+// actions the UI offers. The cached principal is discarded first, as a stale one would keep the
+// capabilities of changed roles. On error the previous principal is kept, and until one is
+// loaded mitigations cannot be approved.
+func (ui *UI) loadPrincipal() error {
+	if ui.identityService == nil {
+		return nil
+	}
+	ui.invalidateCache(identity.PrincipalPath)
+
+	principal, err := ui.identityService.GetPrincipal(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to load the current user: %w", err)
+	}
+
+	ui.app.QueueUpdateDraw(func() {
+		ui.principal = principal
+		ui.capabilities = identity.NewCapabilities(principal)
+	})
+	return nil
+}
+
+// showWhoAmI shows the current user, organization, roles and permissions, and the actions they
+// allow in the UI. 'r' refreshes them from the API.
+func (ui *UI) showWhoAmI() {
+	detailText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetScrollable(true)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	shortcuts := fmt.Sprintf("[%s]r[-] Refresh  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info)
+	render := func() {
+		detailText.SetText(ui.whoAmIContent())
+		statusText.SetText(shortcuts)
+	}
+
+	refreshing := false
+	refresh := func() {
+		if refreshing {
+			return
+		}
+		refreshing = true
+		statusText.SetText(fmt.Sprintf("[%s]Refreshing...[-]", ui.theme.Pending))
+		go func() {
+			ui.invalidateCache(identity.APICredentialsPath)
+			ui.loadCredentialExpiry()
+			err := ui.loadPrincipal()
+			ui.app.QueueUpdateDraw(func() {
+				refreshing = false
+				render()
+				if err != nil {
+					statusText.SetText(fmt.Sprintf("[%s]%s[-]  %s", ui.theme.Error, tview.Escape(err.Error()), shortcuts))
+				}
+			})
+		}()
+	}
+
+	closePanel := func() {
+		ui.pages.RemovePage("whoami")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	detailText.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closePanel()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			refresh()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'q':
+			closePanel()
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(detailText, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Who Am I ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("whoami", modal(content, 3, 4), true, true)
+	ui.app.SetFocus(detailText)
+
+	render()
+	if ui.principal == nil {
+		refresh()
+	}
+}
+
+// whoAmIContent describes the current principal and the capabilities derived from it
+func (ui *UI) whoAmIContent() string {
+	p := ui.principal
+	if p == nil {
+		return fmt.Sprintf("[%s]The current user has not been loaded[-]", ui.theme.SecondaryText)
+	}
+
+	var b strings.Builder
+	field := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "[%s]%-14s[-] %s\n", ui.theme.Label, label+":", tview.Escape(value))
+	}
+
+	name := strings.TrimSpace(p.UserFirstName + " " + p.UserLastName)
+	if name == "" {
+		name = p.Username
+	} else if p.Username != "" {
+		name += " (" + p.Username + ")"
+	}
+	field("User", name)
+	field("Email", p.Email)
+	field("Organization", fmt.Sprintf("%s (ID %d)", p.OrganizationName, p.OrganizationID))
+	field("SAML user", yesNo(p.SAMLUser))
+	field("Sandboxes", enabledDisabled(p.SandboxEnabled))
+	if ui.credentials != nil {
+		field("API key", fmt.Sprintf("%s, %s", ui.credentials.APIID, ui.credentials.DescribeExpiry(time.Now())))
+	}
+
+	fmt.Fprintf(&b, "\n[%s]Roles[-]\n", ui.theme.ColumnHeader)
+	writeList(&b, p.Roles)
+	fmt.Fprintf(&b, "\n[%s]Permissions[-]\n", ui.theme.ColumnHeader)
+	writeList(&b, p.Permissions)
+
+	fmt.Fprintf(&b, "\n[%s]Actions in this UI[-]\n", ui.theme.ColumnHeader)
+	for _, c := range identity.AllCapabilities {
+		if ui.capabilities.Can(c) {
+			fmt.Fprintf(&b, "  [%s]%s[-] %s\n", ui.theme.Success, EmojiCheckMark, c.Description())
+		} else {
+			fmt.Fprintf(&b, "  [%s]%s[-] %s\n", ui.theme.Error, EmojiBallotX, c.Description())
+		}
+	}
+	fmt.Fprintf(&b, "\n[%s]Approving needs the approveMitigations permission or the Mitigation Approver role, and sandboxes need to be enabled. Other actions are offered and the API decides.[-]\n", ui.theme.SecondaryText)
+	return b.String()
+}
+
+// writeList writes one indented line per item, or "-" when there are none
+func writeList(b *strings.Builder, items []string) {
+	if len(items) == 0 {
+		b.WriteString("  -\n")
+		return
+	}
+	for _, item := range items {
+		fmt.Fprintf(b, "  %s\n", tview.Escape(item))
+	}
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+func enabledDisabled(value bool) string {
+	if value {
+		return "Enabled"
+	}
+	return "Disabled"
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/report"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/snapshot"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showHTMLReport asks for a file name, then writes an HTML security report of the highlighted
+// context of the selected application. A report.html.tmpl in the state directory replaces the
+// built-in template.
+func (ui *UI) showHTMLReport() {
+	if ui.selectedApp == nil {
+		return
+	}
+	app := *ui.selectedApp
+	var sandboxGUID, sandboxName string
+	if index := ui.highlightedSandbox(); index >= 0 {
+		sandboxGUID, sandboxName = ui.sandboxes[index].GUID, ui.sandboxes[index].Name
+	}
+	baseName := strings.Trim(unsafeFileNameChars.ReplaceAllString(strings.TrimSpace(appDisplayName(&app)+" "+sandboxName), "-"), "-")
+	path := baseName + "-report.html"
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Tab[-] Navigate  [%s]Enter[-] Select  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	form := ui.newStyledForm()
+	form.AddInputField("File", path, 50, nil, func(text string) {
+		path = strings.TrimSpace(text)
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("html-report")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+
+	form.AddButton("Generate", func() {
+		if path == "" {
+			return
+		}
+		statusText.SetText(fmt.Sprintf("[%s]Generating report...[-]", ui.theme.Pending))
+		output := path
+		go func() {
+			count, err := ui.writeHTMLReport(&app, sandboxGUID, sandboxName, output)
+			ui.app.QueueUpdateDraw(func() {
+				if err != nil {
+					statusText.SetText(fmt.Sprintf("[%s]Report failed: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+					return
+				}
+				statusText.SetText(fmt.Sprintf("[%s]Wrote a report of %d findings to %s[-]", ui.theme.Success, count, tview.Escape(output)))
+			})
+		}()
+	}).
+		AddButton("Close", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" HTML Report - %s ", tview.Escape(strings.TrimSpace(appDisplayName(&app)+" "+sandboxName)))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("html-report", modal(content, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// writeHTMLReport fetches the findings of an application context with their annotations and its
+// snapshot history, and writes the report to path. It returns the number of findings reported.
+func (ui *UI) writeHTMLReport(app *applications.Application, sandboxGUID, sandboxName, path string) (int, error) {
+	stateDir, _ := config.StateDir()
+	tmpl, err := report.LoadTemplate("", stateDir)
+	if err != nil {
+		return 0, err
+	}
+
+	fetch := portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{
+		Context:            sandboxGUID,
+		IncludeAnnotations: true,
+	}, slaScanTypes)
+	list, err := fetch(app)
+	if err != nil {
+		return 0, err
+	}
+
+	var history []*snapshot.Snapshot
+	if ui.snapshots != nil {
+		// The trend is optional; a report without history is still useful
+		history, _ = ui.snapshots.List(app.GUID, sandboxGUID)
+	}
+
+	r := report.Build(report.Input{
+		App:         app,
+		SandboxName: sandboxName,
+		Findings:    list,
+		Snapshots:   history,
+		Now:         time.Now(),
+		ToolVersion: ui.version,
+	})
+	return len(list), writeFile(path, func(w io.Writer) error {
+		return report.Write(w, tmpl, r)
+	})
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// highlightedSandbox returns the index of the sandbox highlighted in the contexts table, or -1
+// when the policy row is highlighted
+func (ui *UI) highlightedSandbox() int {
+	if row, _ := ui.contextsTable.GetSelection(); row > 1 && row-2 < len(ui.sandboxes) {
+		return row - 2
+	}
+	return -1
+}
+
+// showCreateSandbox opens the form to create a sandbox for the selected application
+func (ui *UI) showCreateSandbox() {
+	if ui.selectedApp != nil {
+		ui.showSandboxForm(-1)
+	}
+}
+
+// showEditSandbox opens the form to rename the highlighted sandbox and change its settings
+func (ui *UI) showEditSandbox() {
+	if index := ui.highlightedSandbox(); index >= 0 {
+		ui.showSandboxForm(index)
+	}
+}
+
+// showSandboxForm shows the sandbox form for ui.sandboxes[index], or for a new sandbox when index
+// is -1
+func (ui *UI) showSandboxForm(index int) {
+	request := applications.SandboxRequest{}
+	title := " Create Sandbox "
+	var sandboxGUID string
+	if index >= 0 {
+		sandbox := ui.sandboxes[index]
+		sandboxGUID = sandbox.GUID
+		request = applications.SandboxRequest{Name: sandbox.Name, AutoRecreate: sandbox.AutoRecreate, CustomFields: sandbox.CustomFields}
+		title = fmt.Sprintf(" Edit %s ", tview.Escape(sandbox.Name))
+	}
+	appGUID := ui.selectedApp.GUID
+	customFieldsText := formatCustomFields(request.CustomFields)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Tab[-] Navigate  [%s]Enter[-] Select  [%s]ESC[-] Cancel", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	form := ui.newStyledForm()
+	form.AddInputField("Name", request.Name, 40, nil, func(text string) {
+		request.Name = strings.TrimSpace(text)
+	}).
+		AddCheckbox("Auto Recreate", request.AutoRecreate, func(checked bool) {
+			request.AutoRecreate = checked
+		}).
+		AddInputField("Custom Fields (name=value; ...)", customFieldsText, 40, nil, func(text string) {
+			customFieldsText = text
+		})
+
+	closeForm := func() {
+		ui.pages.RemovePage("sandbox-edit")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+	showError := func(message string) {
+		statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(message)))
+	}
+
+	form.AddButton("Save", func() {
+		if request.Name == "" {
+			showError("A sandbox name is required")
+			return
+		}
+		customFields, err := parseCustomFields(customFieldsText)
+		if err != nil {
+			showError(err.Error())
+			return
+		}
+		request.CustomFields = customFields
+
+		statusText.SetText(fmt.Sprintf("[%s]Saving...[-]", ui.theme.Pending))
+		go func() {
+			var saved *applications.Sandbox
+			var err error
+			if index < 0 {
+				saved, err = ui.appService.CreateSandbox(appGUID, &request)
+			} else {
+				saved, err = ui.appService.UpdateSandbox(appGUID, sandboxGUID, &request)
+			}
+			ui.app.QueueUpdateDraw(func() {
+				if err != nil {
+					showError(fmt.Sprintf("Failed to save sandbox: %v", err))
+					return
+				}
+				closeForm()
+				ui.storeSandbox(sandboxGUID, request, saved)
+			})
+		}()
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("sandbox-edit", modal(content, 3, 2), true, true)
+	ui.app.SetFocus(form)
+}
+
+// storeSandbox updates ui.sandboxes after a sandbox was saved, replacing the sandbox with
+// sandboxGUID or adding a new one, and highlights it in the contexts table. The request fills
+// in the sandbox when the API response is empty.
+func (ui *UI) storeSandbox(sandboxGUID string, request applications.SandboxRequest, saved *applications.Sandbox) {
+	index := slices.IndexFunc(ui.sandboxes, func(s applications.Sandbox) bool {
+		return sandboxGUID != "" && s.GUID == sandboxGUID
+	})
+
+	sandbox := applications.Sandbox{}
+	if index >= 0 {
+		sandbox = ui.sandboxes[index]
+	}
+	if saved != nil && saved.GUID != "" {
+		sandbox = *saved
+	} else {
+		sandbox.Name = request.Name
+		sandbox.AutoRecreate = request.AutoRecreate
+		sandbox.CustomFields = request.CustomFields
+	}
+
+	if index >= 0 {
+		ui.sandboxes[index] = sandbox
+	} else {
+		ui.sandboxes = append(ui.sandboxes, sandbox)
+		index = len(ui.sandboxes) - 1
+	}
+	ui.updateContextsTable()
+	ui.contextsTable.Select(index+2, 0)
+}
+
+// removeSandbox removes a deleted sandbox from ui.sandboxes and the contexts table. The selected
+// context stays selected, unless it is the deleted sandbox, when the policy scan is selected.
+func (ui *UI) removeSandbox(sandboxGUID string) {
+	selectedGUID := ""
+	if ui.selectionIndex >= 0 && ui.selectionIndex < len(ui.sandboxes) {
+		selectedGUID = ui.sandboxes[ui.selectionIndex].GUID
+	}
+	ui.sandboxes = slices.DeleteFunc(ui.sandboxes, func(s applications.Sandbox) bool {
+		return s.GUID == sandboxGUID
+	})
+
+	ui.selectionIndex = -1
+	if selectedGUID != "" && selectedGUID != sandboxGUID {
+		ui.selectionIndex = slices.IndexFunc(ui.sandboxes, func(s applications.Sandbox) bool {
+			return s.GUID == selectedGUID
+		})
+	}
+	ui.updateContextsTable()
+	ui.contextsTable.Select(ui.selectionIndex+2, 0)
+}
+
+// showDeleteSandbox asks for confirmation before deleting the highlighted sandbox
+func (ui *UI) showDeleteSandbox() {
+	index := ui.highlightedSandbox()
+	if index < 0 {
+		return
+	}
+	sandbox := ui.sandboxes[index]
+	appGUID := ui.selectedApp.GUID
+
+	ui.showSandboxConfirmation("Delete", sandbox,
+		fmt.Sprintf("Delete sandbox %s and all of its scan results?", tview.Escape(sandbox.Name)), nil,
+		func() error {
+			if err := ui.appService.DeleteSandbox(appGUID, sandbox.GUID); err != nil {
+				return err
+			}
+			ui.app.QueueUpdateDraw(func() {
+				ui.removeSandbox(sandbox.GUID)
+			})
+			return nil
+		})
+}
+
+// showPromoteSandbox asks for confirmation before promoting the latest scan of the highlighted
+// sandbox to the policy scan, optionally deleting the sandbox afterwards
+func (ui *UI) showPromoteSandbox() {
+	index := ui.highlightedSandbox()
+	if index < 0 {
+		return
+	}
+	sandbox := ui.sandboxes[index]
+	appGUID := ui.selectedApp.GUID
+	deleteOnPromote := false
+
+	ui.showSandboxConfirmation("Promote", sandbox,
+		fmt.Sprintf("Promote the latest scan of %s to the policy scan?", tview.Escape(sandbox.Name)),
+		func(form *tview.Form) {
+			form.AddCheckbox("Delete sandbox after promoting", false, func(checked bool) {
+				deleteOnPromote = checked
+			})
+		},
+		func() error {
+			promoted, err := ui.appService.PromoteSandbox(appGUID, sandbox.GUID, deleteOnPromote)
+			if err != nil {
+				return err
+			}
+			app, appErr := ui.appService.GetApplication(appGUID)
+			ui.app.QueueUpdateDraw(func() {
+				if deleteOnPromote {
+					ui.removeSandbox(sandbox.GUID)
+				} else {
+					ui.storeSandbox(sandbox.GUID, applications.SandboxRequest{Name: sandbox.Name, AutoRecreate: sandbox.AutoRecreate, CustomFields: sandbox.CustomFields}, promoted)
+				}
+				// The policy compliance of the application changes with the promoted scan
+				if appErr == nil && ui.selectedApp != nil && ui.selectedApp.GUID == app.GUID {
+					ui.selectedApp = app
+					ui.updateApplicationDetailViews()
+				}
+			})
+			return nil
+		})
+}
+
+// showSandboxConfirmation shows a confirmation modal for a sandbox action. addFields may add
+// options to the form; run performs the action in the background and the modal closes once it
+// succeeds.
+func (ui *UI) showSandboxConfirmation(action string, sandbox applications.Sandbox, message string, addFields func(*tview.Form), run func() error) {
+	messageText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Warning, message))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	form := ui.newStyledForm()
+	if addFields != nil {
+		addFields(form)
+	}
+
+	closeForm := func() {
+		ui.pages.RemovePage("sandbox-confirm")
+		ui.app.SetFocus(ui.contextsTable)
+	}
+
+	form.AddButton(action, func() {
+		statusText.SetText(fmt.Sprintf("[%s]Working...[-]", ui.theme.Pending))
+		go func() {
+			err := run()
+			ui.app.QueueUpdateDraw(func() {
+				if err != nil {
+					statusText.SetText(fmt.Sprintf("[%s]%s failed: %s[-]", ui.theme.Error, action, tview.Escape(err.Error())))
+					return
+				}
+				closeForm()
+			})
+		}()
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(messageText, 2, 0, false).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s %s ", action, tview.Escape(sandbox.Name))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(1, 0, 1, 1)
+
+	ui.pages.AddPage("sandbox-confirm", modal(content, 3, 2), true, true)
+	ui.app.SetFocus(form)
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/sbom"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// unsafeFileNameChars are replaced when an application name is used as a file name
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SetVersion sets the application version recorded in generated SBOMs
+func (ui *UI) SetVersion(version string) {
+	ui.version = version
+}
+
+// showSBOMExport asks for a format and file name, then writes an SBOM of the current
+// application context built from all of its SCA findings, regardless of the table filters
+func (ui *UI) showSBOMExport() {
+	if ui.findingsScanFilter != findings.ScanFilterSCA {
+		ui.findingsCountsLabel.SetText(fmt.Sprintf("[%s]SBOM export is available for SCA findings[-]", ui.theme.Warning))
+		return
+	}
+
+	appGUID := ui.selectedApp.GUID
+	appName := appDisplayName(ui.selectedApp)
+	sandboxGUID, sandboxName := ui.currentSandbox()
+	baseName := strings.Trim(unsafeFileNameChars.ReplaceAllString(strings.TrimSpace(appName+" "+sandboxName), "-"), "-")
+
+	formats := []sbom.Format{sbom.FormatCycloneDX, sbom.FormatSPDX}
+	format := formats[0]
+	path := baseName + format.FileExtension()
+
+	form := ui.newStyledForm()
+	form.AddDropDown("Format", []string{"CycloneDX 1.5 JSON", "SPDX 2.3 JSON"}, 0, nil)
+	form.AddInputField("File", path, 50, nil, func(text string) {
+		path = strings.TrimSpace(text)
+	})
+	fileField := form.GetFormItemByLabel("File").(*tview.InputField)
+	form.GetFormItemByLabel("Format").(*tview.DropDown).SetSelectedFunc(func(_ string, index int) {
+		if index < 0 {
+			return
+		}
+		// Keep the file extension in line with the format unless the name was changed
+		if path == baseName+format.FileExtension() {
+			fileField.SetText(baseName + formats[index].FileExtension())
+		}
+		format = formats[index]
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("sbom-export")
+		ui.app.SetFocus(ui.findingsTable)
+	}
+
+	form.AddButton("Export", func() {
+		if path == "" {
+			return
+		}
+		closeForm()
+		ui.exportSBOM(appGUID, appName, sandboxGUID, format, path)
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetBorder(true).
+		SetTitle(" Export SBOM ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("sbom-export", modal(form, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// exportSBOM fetches the SCA findings of an application context in the background and writes
+// them as an SBOM, reporting progress and the outcome in the findings counts label
+func (ui *UI) exportSBOM(appGUID, appName, sandboxGUID string, format sbom.Format, path string) {
+	ui.findingsCountsLabel.SetText(fmt.Sprintf("[%s]Generating SBOM...[-]", ui.theme.Pending))
+
+	go func() {
+		list, err := ui.findingsService.GetAllFindings(appGUID, &findings.GetFindingsOptions{
+			Context:  sandboxGUID,
+			ScanType: []string{string(findings.ScanTypeSCA)},
+		})
+		var bom *sbom.BOM
+		if err == nil {
+			bom = sbom.Build(appName, list, sbom.Options{ToolVersion: ui.version})
+			err = writeFile(path, func(w io.Writer) error {
+				return sbom.Write(w, bom, format)
+			})
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				ui.findingsCountsLabel.SetText(fmt.Sprintf("[%s]SBOM export failed: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			ui.findingsCountsLabel.SetText(fmt.Sprintf("[%s]Exported an SBOM of %d components and %d vulnerabilities to %s[-]",
+				ui.theme.Success, len(bom.Components), len(bom.Vulnerabilities), tview.Escape(path)))
+		})
+	}()
+}
@@ -170,8 +170,7 @@ func (ui *UI) showSCAFindingDetail() {
 	mainLayout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyEscape:
-			ui.pages.SwitchToPage("findings")
-			ui.app.SetFocus(ui.findingsTable)
+			ui.closeFindingDetail()
 			return nil
 		case tcell.KeyRune:
 			if event.Rune() == 'q' {
@@ -270,6 +269,8 @@ func (ui *UI) buildSCACVEDetailsContent(finding *findings.Finding) string {
 				ui.theme.Label, cveHref, cveHref))
 		}
 
+		sb.WriteString(ui.formatCVSSScores(finding))
+
 		// Add useful links if CVE name exists
 		if cveName != "" {
 			sb.WriteString(fmt.Sprintf("\n[%s]Useful Links:[-]\n", ui.theme.Label))
@@ -296,6 +297,48 @@ func (ui *UI) buildSCACVEDetailsContent(finding *findings.Finding) string {
 	return sb.String()
 }
 
+// formatCVSSScores formats the CVSS scores of a finding, each with a table of its base metrics
+// when the vector can be parsed
+func (ui *UI) formatCVSSScores(finding *findings.Finding) string {
+	var sb strings.Builder
+
+	for _, score := range finding.CVSSScores() {
+		sb.WriteString(fmt.Sprintf("\n[%s]CVSS %s:[-] [%s]%.1f %s[-]\n", ui.theme.Label, score.Version,
+			ui.cvssColorHex(score.Base), score.Base, score.Severity()))
+		if score.Vector == "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("[%s]Vector:[-] [white]%s[-]\n", ui.theme.Label, tview.Escape(score.Vector)))
+
+		vector := score.Parsed()
+		if vector == nil {
+			sb.WriteString(fmt.Sprintf("[%s]Vector could not be parsed[-]\n", ui.theme.SecondaryText))
+			continue
+		}
+		for _, metric := range vector.Metrics {
+			sb.WriteString(fmt.Sprintf("  [%s]%-3s %-24s[-] [white]%s[-]\n", ui.theme.SecondaryText, metric.Key, metric.Name, metric.ValueName))
+		}
+	}
+
+	return sb.String()
+}
+
+// cvssColorHex returns the severity color of a CVSS base score, using the v3 rating bands
+func (ui *UI) cvssColorHex(score float64) string {
+	switch {
+	case score >= 9:
+		return ui.theme.SeverityVeryHigh
+	case score >= 7:
+		return ui.theme.SeverityHigh
+	case score >= 4:
+		return ui.theme.SeverityMedium
+	case score > 0:
+		return ui.theme.SeverityLow
+	default:
+		return ui.theme.SeverityVeryLow
+	}
+}
+
 // formatComponentPaths formats component path information for display
 func (ui *UI) formatComponentPaths(componentPaths []interface{}) string {
 	var sb strings.Builder
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/applications"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// slaScanTypes are the scan types checked for grace period expiry
+var slaScanTypes = []string{string(findings.ScanTypeStatic), string(findings.ScanTypeDynamic), string(findings.ScanTypeSCA)}
+
+// slaItem is an SLA-tracked finding and the application it belongs to
+type slaItem struct {
+	appName string
+	finding *findings.Finding
+	days    int
+}
+
+// showApplicationSLA shows the SLA of the selected application's policy scan
+func (ui *UI) showApplicationSLA() {
+	if ui.selectedApp == nil {
+		return
+	}
+	ui.showSLA(fmt.Sprintf(" SLA - %s ", tview.Escape(appDisplayName(ui.selectedApp))), ui.contextsTable, false, ui.selectedApplicationLoader())
+}
+
+// showPortfolioSLA shows the SLA across all applications matching the current search and filters
+func (ui *UI) showPortfolioSLA() {
+	ui.showSLA(" SLA - Portfolio ", ui.applicationsTable, true, ui.matchingApplicationsLoader())
+}
+
+// selectedApplicationLoader returns a loader for collectFindings that yields the selected application
+func (ui *UI) selectedApplicationLoader() func() ([]applications.Application, error) {
+	app := *ui.selectedApp
+	return func() ([]applications.Application, error) {
+		return []applications.Application{app}, nil
+	}
+}
+
+// matchingApplicationsLoader returns a loader for collectFindings that yields every application
+// matching the current search and filters of the applications list
+func (ui *UI) matchingApplicationsLoader() func() ([]applications.Application, error) {
+	opts := ui.applicationsQueryOptions()
+	return func() ([]applications.Application, error) {
+		return ui.appService.GetAllApplications(opts)
+	}
+}
+
+// appDisplayName returns the application's name, or DefaultApplicationName
+func appDisplayName(app *applications.Application) string {
+	if app.Profile != nil {
+		return app.Profile.Name
+	}
+	return DefaultApplicationName
+}
+
+// showSLA lists the open, unmitigated policy violations of the applications returned by loadApps,
+// ordered by days until their grace period expires. Overdue findings are highlighted.
+func (ui *UI) showSLA(title string, returnFocus tview.Primitive, showApp bool, loadApps func() ([]applications.Application, error)) {
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[%s]Loading applications...[-]", ui.theme.Pending))
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			ui.pages.RemovePage("sla")
+			ui.app.SetFocus(returnFocus)
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("sla", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(table)
+
+	violating := true
+	fetch := portfolio.ByScanType(ui.findingsService, findings.GetFindingsOptions{ViolatesPolicy: &violating}, slaScanTypes)
+	ui.collectFindings(loadApps, fetch, summaryText, func(results []portfolio.AppFindings) {
+		ui.renderSLA(summaryText, table, results, showApp)
+	})
+}
+
+// collectFindings loads the applications and then their findings in the background, showing
+// progress in progressText. done is called on the UI goroutine with the results.
+func (ui *UI) collectFindings(loadApps func() ([]applications.Application, error), fetch portfolio.Fetch, progressText *tview.TextView, done func(results []portfolio.AppFindings)) {
+	go func() {
+		apps, err := loadApps()
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				progressText.SetText(fmt.Sprintf("[%s]Error loading applications: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			})
+			return
+		}
+
+		results := portfolio.Collect(apps, fetch, portfolio.DefaultWorkers, func(count, total int) {
+			ui.app.QueueUpdateDraw(func() {
+				progressText.SetText(fmt.Sprintf("[%s]Loading findings... %d/%d applications[-]", ui.theme.Pending, count, total))
+			})
+		})
+
+		ui.app.QueueUpdateDraw(func() {
+			done(results)
+		})
+	}()
+}
+
+// renderSLA fills the SLA summary and table from the collected findings
+func (ui *UI) renderSLA(summaryText *tview.TextView, table *tview.Table, results []portfolio.AppFindings, showApp bool) {
+	now := time.Now()
+
+	var items []slaItem
+	var all []findings.Finding
+	failed := 0
+	for i := range results {
+		result := &results[i]
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		all = append(all, result.Findings...)
+		tracked := findings.SLAFindings(result.Findings)
+		for j := range tracked {
+			days, _ := tracked[j].GraceDaysRemaining(now)
+			items = append(items, slaItem{appName: result.AppName(), finding: &tracked[j], days: days})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].days < items[j].days
+	})
+
+	summary := findings.SummarizeSLA(all, now)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]Open policy violations:[-] %d   [%s]Overdue:[-] [%s]%d[-]   [%s]Due within %d days:[-] [%s]%d[-]",
+		ui.theme.Label, summary.Tracked,
+		ui.theme.Label, ui.theme.Error, summary.Overdue,
+		ui.theme.Label, findings.DueSoonDays, ui.theme.Warning, summary.DueSoon))
+	if showApp {
+		sb.WriteString(fmt.Sprintf("   [%s]Applications:[-] %d", ui.theme.Label, len(results)))
+	}
+	if failed > 0 {
+		sb.WriteString(fmt.Sprintf("\n[%s]%d applications could not be loaded[-]", ui.theme.Error, failed))
+	}
+	summaryText.SetText(sb.String())
+
+	headers := []string{"Days", "Expires", "ID", "Scan", "Sev", "Finding"}
+	if showApp {
+		headers = slices.Insert(headers, 2, "Application")
+	}
+	table.Clear()
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if len(items) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No open policy violations with a grace period").
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		return
+	}
+
+	for i, item := range items {
+		cells := []*tview.TableCell{
+			ui.graceCell(item.days),
+			tview.NewTableCell(item.finding.GracePeriodExpiresDate.Local().Format("2006-01-02")),
+			tview.NewTableCell(fmt.Sprintf("%d", item.finding.IssueID)),
+			tview.NewTableCell(string(item.finding.ScanType)),
+			tview.NewTableCell(extractSeverity(item.finding)).SetTextColor(ui.getSeverityColor(extractSeverity(item.finding))),
+			tview.NewTableCell(tview.Escape(describeFinding(item.finding))).SetExpansion(1),
+		}
+		if showApp {
+			cells = slices.Insert(cells, 2, tview.NewTableCell(tview.Escape(item.appName)))
+		}
+		for col, cell := range cells {
+			table.SetCell(i+1, col, cell)
+		}
+	}
+	table.Select(1, 0)
+}
+
+// describeFinding returns the CWE or CVE of a finding and its location
+func describeFinding(f *findings.Finding) string {
+	switch f.ScanType {
+	case findings.ScanTypeSCA:
+		return strings.TrimSpace(f.CVE() + "  " + f.Component() + " " + f.ComponentVersion())
+	case findings.ScanTypeDynamic:
+		return strings.TrimSpace(f.CWELabel() + "  " + f.URL())
+	default:
+		return strings.TrimSpace(f.CWELabel() + "  " + extractFileLine(f))
+	}
+}
@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/snapshot"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SetSnapshots sets the store used to record findings each time they are loaded
+func (ui *UI) SetSnapshots(store *snapshot.Store) {
+	ui.snapshots = store
+}
+
+// currentSandbox returns the GUID and name of the selected sandbox, or empty strings for the policy scan
+func (ui *UI) currentSandbox() (guid, name string) {
+	if ui.selectionIndex >= 0 && ui.selectionIndex < len(ui.sandboxes) {
+		return ui.sandboxes[ui.selectionIndex].GUID, ui.sandboxes[ui.selectionIndex].Name
+	}
+	return "", ""
+}
+
+// recordSnapshot saves the loaded findings of one scan type as a snapshot. Only complete,
+// unfiltered results are recorded, since filtered or truncated lists would show as fixed findings.
+// Called from the loading goroutine; failures are ignored as snapshots are best effort.
+func (ui *UI) recordSnapshot(appGUID, appName, sandboxGUID, sandboxName, scanType string, list []findings.Finding, total int64, filtered bool) {
+	if ui.snapshots == nil || filtered || int64(len(list)) < total {
+		return
+	}
+	snap := snapshot.New(appGUID, appName, sandboxGUID, sandboxName, []string{scanType}, list, time.Now())
+	_, _ = ui.snapshots.Save(snap)
+}
+
+// showSnapshotHistory lists the snapshots of the current application context.
+// Enter compares the selected snapshot with the previous one of the same scan types,
+// or with the snapshot marked with m.
+func (ui *UI) showSnapshotHistory() {
+	if ui.selectedApp == nil || ui.snapshots == nil {
+		return
+	}
+
+	sandboxGUID, _ := ui.currentSandbox()
+	snapshots, err := ui.snapshots.List(ui.selectedApp.GUID, sandboxGUID)
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	defaultStatus := fmt.Sprintf("[%s]Enter[-] Compare with previous  [%s]m[-] Mark as base  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info)
+	statusText.SetText(defaultStatus)
+
+	marked := -1
+	render := func() {
+		table.Clear()
+		for col, header := range []string{"", "Taken", "Scan Types", "Findings", "Open"} {
+			table.SetCell(0, col, tview.NewTableCell(header).
+				SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+				SetAttributes(tcell.AttrBold).
+				SetSelectable(false))
+		}
+		if err != nil {
+			table.SetCell(1, 0, tview.NewTableCell(tview.Escape(err.Error())).
+				SetTextColor(tcell.GetColor(ui.theme.Error)).
+				SetSelectable(false))
+			return
+		}
+		if len(snapshots) == 0 {
+			table.SetCell(1, 1, tview.NewTableCell("No snapshots yet. Snapshots are recorded when unfiltered findings are loaded.").
+				SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+				SetSelectable(false))
+			return
+		}
+		// Newest first
+		for row, i := 1, len(snapshots)-1; i >= 0; row, i = row+1, i-1 {
+			snap := snapshots[i]
+			mark := " "
+			if i == marked {
+				mark = "*"
+			}
+			table.SetCell(row, 0, tview.NewTableCell(mark).SetTextColor(tcell.GetColor(ui.theme.Warning)))
+			table.SetCell(row, 1, tview.NewTableCell(snap.TakenAt.Local().Format("2006-01-02 15:04:05")))
+			table.SetCell(row, 2, tview.NewTableCell(strings.Join(snap.ScanTypes, ", ")))
+			table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", len(snap.Findings))).SetAlign(tview.AlignRight))
+			table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", snap.OpenCount())).SetAlign(tview.AlignRight).SetExpansion(1))
+		}
+	}
+	render()
+
+	indexOfRow := func(row int) int {
+		return len(snapshots) - row
+	}
+
+	closeHistory := func() {
+		ui.pages.RemovePage("snapshot-history")
+		ui.app.SetFocus(ui.findingsTable)
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		index := indexOfRow(row)
+		if row < 1 || index < 0 {
+			return
+		}
+		to := snapshots[index]
+
+		var from *snapshot.Snapshot
+		if marked >= 0 && marked != index {
+			from = snapshots[marked]
+		} else {
+			for i := index - 1; i >= 0; i-- {
+				if slices.Equal(snapshots[i].ScanTypes, to.ScanTypes) {
+					from = snapshots[i]
+					break
+				}
+			}
+		}
+		if from == nil {
+			statusText.SetText(fmt.Sprintf("[%s]No earlier snapshot of %s to compare with[-]", ui.theme.Warning, strings.Join(to.ScanTypes, ", ")))
+			return
+		}
+		// Always compare older to newer
+		if from.TakenAt.After(to.TakenAt) {
+			from, to = to, from
+		}
+		ui.showSnapshotDiff(snapshot.Compare(from, to), table)
+	})
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeHistory()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'm':
+			row, _ := table.GetSelection()
+			if index := indexOfRow(row); row > 0 && index >= 0 {
+				if marked == index {
+					marked = -1
+				} else {
+					marked = index
+				}
+				statusText.SetText(defaultStatus)
+				render()
+				table.Select(row, 0)
+			}
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Findings History ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("snapshot-history", modal(content, 3, 3), true, true)
+	ui.app.SetFocus(table)
+}
+
+// showSnapshotDiff displays the changes between two snapshots
+func (ui *UI) showSnapshotDiff(diff *snapshot.Diff, returnFocus tview.Primitive) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false)
+	view.SetText(ui.buildSnapshotDiffContent(diff))
+
+	closeDiff := func() {
+		ui.pages.RemovePage("snapshot-diff")
+		ui.app.SetFocus(returnFocus)
+	}
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeDiff()
+			return nil
+		}
+		return event
+	})
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Scroll  [%s]ESC[-] Back", ui.theme.Info, ui.theme.Info))
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Findings Changes ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("snapshot-diff", modal(content, 4, 4), true, true)
+	ui.app.SetFocus(view)
+}
+
+// buildSnapshotDiffContent formats a diff with one colored section per change category
+func (ui *UI) buildSnapshotDiffContent(diff *snapshot.Diff) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]From:[-] %s   [%s]To:[-] %s   [%s]Scan types:[-] %s\n",
+		ui.theme.Label, diff.From.TakenAt.Local().Format("2006-01-02 15:04"),
+		ui.theme.Label, diff.To.TakenAt.Local().Format("2006-01-02 15:04"),
+		ui.theme.Label, strings.Join(diff.ScanTypes, ", ")))
+
+	if diff.Empty() {
+		sb.WriteString(fmt.Sprintf("\n[%s]No changes[-]\n", ui.theme.SecondaryText))
+		return sb.String()
+	}
+
+	colors := map[string]string{
+		"New":              ui.theme.Error,
+		"Fixed":            ui.theme.Success,
+		"Reopened":         ui.theme.Warning,
+		"Newly mitigated":  ui.theme.Success,
+		"Severity changed": ui.theme.Info,
+	}
+
+	for _, category := range diff.Categories() {
+		if len(category.Changes) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n[%s::b]%s (%d)[-::-]\n", colors[category.Name], category.Name, len(category.Changes)))
+		for i := range category.Changes {
+			change := &category.Changes[i]
+			record := change.Record()
+			severity := fmt.Sprintf("%d", record.Severity)
+			if change.Before != nil && change.After != nil && change.Before.Severity != change.After.Severity {
+				severity = fmt.Sprintf("%d → %d", change.Before.Severity, change.After.Severity)
+			}
+			sb.WriteString(fmt.Sprintf("  %-10d %-8s Sev %-6s %s\n", change.IssueID, record.ScanType, severity, tview.Escape(record.Describe())))
+		}
+	}
+	return sb.String()
+}
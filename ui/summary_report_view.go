@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/dipsylala/veracode-tui/services/reports"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showSummaryReport shows the summary report of the highlighted context of the selected
+// application: the policy scan, or a sandbox's latest scan
+func (ui *UI) showSummaryReport() {
+	if ui.selectedApp == nil {
+		return
+	}
+	appGUID := ui.selectedApp.GUID
+	appName := appDisplayName(ui.selectedApp)
+	var sandboxGUID, sandboxName string
+	if index := ui.highlightedSandbox(); index >= 0 {
+		sandboxGUID, sandboxName = ui.sandboxes[index].GUID, ui.sandboxes[index].Name
+	}
+	title := appName
+	if sandboxName != "" {
+		title += " / " + sandboxName
+	}
+
+	reportText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(false).
+		SetText(fmt.Sprintf("[%s]Loading summary report...[-]", ui.theme.Pending))
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]↑/↓[-] Scroll  [%s]s[-] Save as Text  [%s]ESC[-] Close", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	loaded := false
+	reportText.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			ui.pages.RemovePage("summary-report")
+			ui.app.SetFocus(ui.contextsTable)
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 's' && loaded:
+			baseName := strings.Trim(unsafeFileNameChars.ReplaceAllString(title, "-"), "-")
+			ui.showSaveSummaryReport(reportText, statusText, baseName+"-summary.txt")
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(reportText, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Summary Report - %s ", tview.Escape(title))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("summary-report", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(reportText)
+
+	go func() {
+		report, err := ui.reportsService.GetSummaryReport(appGUID, sandboxGUID)
+
+		ui.app.QueueUpdateDraw(func() {
+			if err != nil {
+				reportText.SetText(fmt.Sprintf("[%s]Error loading summary report: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+				return
+			}
+			loaded = true
+			reportText.SetText(ui.formatSummaryReport(report, title))
+			reportText.ScrollToBeginning()
+		})
+	}()
+}
+
+// formatSummaryReport lays out a summary report as fixed-width text, so that it reads the same
+// on screen and when saved and printed
+func (ui *UI) formatSummaryReport(report *reports.SummaryReport, title string) string {
+	var b strings.Builder
+	heading := func(text string) {
+		b.WriteString(fmt.Sprintf("\n[%s::b]%s[-::-]\n", ui.theme.ColumnHeader, text))
+	}
+	status := func(passed bool, text string) string {
+		if passed {
+			return fmt.Sprintf("[%s]%s[-]", ui.theme.PolicyPass, text)
+		}
+		return fmt.Sprintf("[%s]%s[-]", ui.theme.PolicyFail, text)
+	}
+
+	b.WriteString(fmt.Sprintf("[%s::b]%s[-::-]\n", ui.theme.Label, tview.Escape(title)))
+	b.WriteString(fmt.Sprintf("Generated:            %s\n", valueOrDash(report.GenerationDate)))
+	b.WriteString(fmt.Sprintf("Business criticality: %s\n", valueOrDash(report.BusinessCriticality)))
+	if report.BusinessUnit != "" {
+		b.WriteString(fmt.Sprintf("Business unit:        %s\n", tview.Escape(report.BusinessUnit)))
+	}
+	if report.Teams != "" {
+		b.WriteString(fmt.Sprintf("Teams:                %s\n", tview.Escape(report.Teams)))
+	}
+
+	heading("POLICY")
+	b.WriteString(fmt.Sprintf("  %-22s %s (version %d)\n", "Policy", valueOrDash(report.PolicyName), report.PolicyVersion))
+	b.WriteString(fmt.Sprintf("  %-22s [%s]%s[-]\n", "Compliance", ui.complianceColor(report.PolicyComplianceStatus), valueOrDash(report.PolicyComplianceStatus)))
+	for _, rule := range report.PolicyRules() {
+		label := "FAIL"
+		if rule.Passed {
+			label = "PASS"
+		}
+		b.WriteString(fmt.Sprintf("  %-22s %s  %s\n", rule.Rule, status(rule.Passed, label), tview.Escape(rule.Detail)))
+	}
+
+	heading("ANALYSES")
+	analyses := report.Analyses()
+	if len(analyses) == 0 {
+		b.WriteString("  No analyses\n")
+	}
+	for _, analysis := range analyses {
+		b.WriteString(fmt.Sprintf("  %-8s Score %3d  Rating %-2s  Published %s\n",
+			analysis.Name, analysis.Score, valueOrDash(analysis.Rating), valueOrDash(analysis.PublishedDate)))
+	}
+
+	heading("FLAWS BY SEVERITY")
+	counts := report.FlawCounts()
+	total := 0
+	for severity := len(counts) - 1; severity >= 0; severity-- {
+		total += counts[severity]
+		b.WriteString(fmt.Sprintf("  [%s]%-14s[-] %5d\n", ui.getSeverityColorHex(severity), policies.SeverityName(severity), counts[severity]))
+	}
+	b.WriteString(fmt.Sprintf("  %-14s %5d\n", "Total", total))
+
+	if fs := report.FlawStatus; fs != nil {
+		heading("FLAW STATUS")
+		b.WriteString(fmt.Sprintf("  New %d   Reopened %d   Open %d   Fixed %d   Not mitigated %d\n",
+			fs.New, fs.Reopen, fs.Open, fs.Fixed, fs.NotMitigated))
+	}
+
+	heading("FLAWS BY CATEGORY")
+	listed := false
+	for severity := len(counts) - 1; severity >= 0; severity-- {
+		for _, level := range report.Severities {
+			if level.Level != severity {
+				continue
+			}
+			for _, category := range level.Category {
+				listed = true
+				b.WriteString(fmt.Sprintf("  [%s]%-14s[-] %-50s %5d\n", ui.getSeverityColorHex(severity), policies.SeverityName(severity),
+					tview.Escape(category.CategoryName), category.Count))
+			}
+		}
+	}
+	if !listed {
+		b.WriteString("  No flaws\n")
+	}
+
+	if sca := report.SCA; sca != nil {
+		heading("SOFTWARE COMPOSITION ANALYSIS")
+		b.WriteString(fmt.Sprintf("  Third party components %d   Violating policy %d   Blocklisted %d\n",
+			sca.ThirdPartyComponents, sca.ComponentsViolatedPolicy, sca.BlocklistedComponents))
+	}
+
+	return b.String()
+}
+
+// showSaveSummaryReport asks for a file name and saves the summary report as plain text
+func (ui *UI) showSaveSummaryReport(reportText, statusText *tview.TextView, path string) {
+	form := ui.newStyledForm()
+	form.AddInputField("File", path, 50, nil, func(text string) {
+		path = strings.TrimSpace(text)
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("summary-report-save")
+		ui.app.SetFocus(reportText)
+	}
+
+	form.AddButton("Save", func() {
+		if path == "" {
+			return
+		}
+		closeForm()
+		err := writeFile(path, func(w io.Writer) error {
+			_, err := io.WriteString(w, reportText.GetText(true))
+			return err
+		})
+		if err != nil {
+			statusText.SetText(fmt.Sprintf("[%s]Save failed: %s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			return
+		}
+		statusText.SetText(fmt.Sprintf("[%s]Saved the summary report to %s[-]", ui.theme.Success, tview.Escape(path)))
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetBorder(true).
+		SetTitle(" Save Summary Report ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("summary-report-save", modal(form, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// valueOrDash returns the value escaped for display, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return tview.Escape(value)
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/dipsylala/veracode-tui/config"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Table names used to persist layouts in the settings file
+const (
+	applicationsTableName = "applications"
+	findingsTablePrefix   = "findings-"
+)
+
+// columnInfo identifies a table column for sorting and the column chooser
+type columnInfo struct {
+	key      string
+	title    string
+	sortable bool
+}
+
+// tableLayout returns the persisted layout for a table, falling back to the given default sort
+func (ui *UI) tableLayout(name string, defaults config.TableLayout) config.TableLayout {
+	layout := ui.settings.TableLayout(name)
+	if layout.SortColumn == "" {
+		layout.SortColumn = defaults.SortColumn
+		layout.SortDescending = defaults.SortDescending
+	}
+	return layout
+}
+
+// saveTableLayout stores a table layout and writes the settings file.
+// Persisting the layout is best effort; a failed write keeps the in-memory layout.
+func (ui *UI) saveTableLayout(name string, layout config.TableLayout) {
+	ui.settings.SetTableLayout(name, layout)
+	_ = ui.settings.Save()
+}
+
+// visibleColumns filters out the hidden columns of a layout
+func visibleColumns(columns []columnInfo, layout config.TableLayout) []columnInfo {
+	visible := make([]columnInfo, 0, len(columns))
+	for _, column := range columns {
+		if !layout.IsHidden(column.key) {
+			visible = append(visible, column)
+		}
+	}
+	return visible
+}
+
+// cycleSortColumn moves the sort to the next visible sortable column
+func cycleSortColumn(columns []columnInfo, layout config.TableLayout) config.TableLayout {
+	visible := visibleColumns(columns, layout)
+	current := -1
+	for i, column := range visible {
+		if column.key == layout.SortColumn {
+			current = i
+			break
+		}
+	}
+
+	for offset := 1; offset <= len(visible); offset++ {
+		column := visible[(current+offset)%len(visible)]
+		if column.sortable {
+			layout.SortColumn = column.key
+			layout.SortDescending = false
+			return layout
+		}
+	}
+	return layout
+}
+
+// sortByColumn sorts by the given column, reversing the direction if it is already the sort column
+func sortByColumn(column columnInfo, layout config.TableLayout) config.TableLayout {
+	if !column.sortable {
+		return layout
+	}
+	if layout.SortColumn == column.key {
+		layout.SortDescending = !layout.SortDescending
+	} else {
+		layout.SortColumn = column.key
+		layout.SortDescending = false
+	}
+	return layout
+}
+
+// headerTitle returns a column title with a sort direction indicator when it is the sort column
+func headerTitle(column columnInfo, layout config.TableLayout) string {
+	if column.key != layout.SortColumn {
+		return column.title
+	}
+	if layout.SortDescending {
+		return column.title + " ▼"
+	}
+	return column.title + " ▲"
+}
+
+// applySortDirection flips a comparison result when sorting in descending order
+func applySortDirection(result int, descending bool) int {
+	if descending {
+		return -result
+	}
+	return result
+}
+
+// headerColumnAt returns the visible column index of a header click, or -1 if the click is elsewhere
+func headerColumnAt(table *tview.Table, event *tcell.EventMouse) int {
+	x, y := event.Position()
+	row, col := table.CellAt(x, y)
+	if row != 0 {
+		return -1
+	}
+	return col
+}
+
+// showColumnChooser displays a modal to show or hide the columns of a table
+func (ui *UI) showColumnChooser(title string, columns []columnInfo, layout config.TableLayout, onApply func(config.TableLayout), returnFocus tview.Primitive) {
+	hidden := make(map[string]bool)
+	for _, column := range columns {
+		hidden[column.key] = layout.IsHidden(column.key)
+	}
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("[%s]Space/Enter[-] Toggle  [%s]Tab[-] Navigate  [%s]ESC[-] Cancel", ui.theme.Info, ui.theme.Info, ui.theme.Info))
+
+	form := ui.newStyledForm()
+	for _, column := range columns {
+		key := column.key
+		form.AddCheckbox(column.title, !hidden[key], func(checked bool) {
+			hidden[key] = !checked
+		})
+	}
+
+	closeChooser := func() {
+		ui.pages.RemovePage("column-chooser")
+		ui.app.SetFocus(returnFocus)
+	}
+
+	form.AddButton("Apply", func() {
+		var hiddenColumns []string
+		for _, column := range columns {
+			if hidden[column.key] {
+				hiddenColumns = append(hiddenColumns, column.key)
+			}
+		}
+		if len(hiddenColumns) == len(columns) {
+			statusText.SetText(fmt.Sprintf("[%s]At least one column must be visible[-]", ui.theme.Error))
+			return
+		}
+		layout.HiddenColumns = hiddenColumns
+		closeChooser()
+		onApply(layout)
+	}).
+		AddButton("Cancel", closeChooser)
+	form.SetCancelFunc(closeChooser)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Columns - %s ", title)).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("column-chooser", modal(content, 2, 6), true, true)
+	ui.app.SetFocus(form)
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dipsylala/veracode-tui/snapshot"
+	"github.com/rivo/tview"
+)
+
+const (
+	// trendDays is the number of days shown in the findings trend
+	trendDays = 28
+	// trendViewHeight is the height of the trend box: a heading, six series and the border
+	trendViewHeight = 9
+)
+
+// sparkBlocks are the bar heights used to draw sparklines, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// updateTrendView shows the findings trend of the context in the given contexts table row,
+// built from the snapshots recorded for it. Snapshots are read in the background.
+func (ui *UI) updateTrendView(row int) {
+	if ui.trendView == nil || ui.selectedApp == nil {
+		return
+	}
+	if ui.snapshots == nil {
+		ui.trendView.SetText(fmt.Sprintf("[%s]Snapshots are disabled[-]", ui.theme.SecondaryText))
+		return
+	}
+
+	sandboxGUID := ""
+	if row > 1 && row-2 < len(ui.sandboxes) {
+		sandboxGUID = ui.sandboxes[row-2].GUID
+	} else if row != 1 {
+		return
+	}
+
+	appGUID := ui.selectedApp.GUID
+	go func() {
+		snapshots, err := ui.snapshots.List(appGUID, sandboxGUID)
+		var points []snapshot.Point
+		if err == nil && len(snapshots) > 0 {
+			points = snapshot.Trend(snapshots, time.Now(), trendDays)
+		}
+
+		ui.app.QueueUpdateDraw(func() {
+			// Ignore the result if another application or context was selected meanwhile
+			if current, _ := ui.contextsTable.GetSelection(); ui.selectedApp == nil || ui.selectedApp.GUID != appGUID || current != row {
+				return
+			}
+			switch {
+			case err != nil:
+				ui.trendView.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			case len(points) == 0:
+				ui.trendView.SetText(fmt.Sprintf("[%s]No history yet.\nOpen the findings of this context\nto start recording it.[-]", ui.theme.SecondaryText))
+			default:
+				ui.trendView.SetText(ui.buildTrendContent(points))
+			}
+		})
+	}()
+}
+
+// buildTrendContent renders sparklines of open, mitigated and closed findings, with the open
+// findings also broken down for the three highest severities
+func (ui *UI) buildTrendContent(points []snapshot.Point) string {
+	series := func(value func(p *snapshot.Point) int) []int {
+		values := make([]int, len(points))
+		for i := range points {
+			values[i] = value(&points[i])
+		}
+		return values
+	}
+
+	rows := []struct {
+		label  string
+		color  string
+		values []int
+	}{
+		{"Open", ui.theme.Warning, series(func(p *snapshot.Point) int { return p.Open.Total() })},
+		{" Sev 5", ui.theme.Error, series(func(p *snapshot.Point) int { return p.Open[5] })},
+		{" Sev 4", ui.theme.Error, series(func(p *snapshot.Point) int { return p.Open[4] })},
+		{" Sev 3", ui.theme.Warning, series(func(p *snapshot.Point) int { return p.Open[3] })},
+		{"Mitigated", ui.theme.Info, series(func(p *snapshot.Point) int { return p.Mitigated.Total() })},
+		{"Closed", ui.theme.Success, series(func(p *snapshot.Point) int { return p.Closed.Total() })},
+	}
+
+	recorded := 0
+	for i := range points {
+		if points[i].Recorded {
+			recorded++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]Last %d days[-] [%s](%d recorded)[-]\n", ui.theme.Label, len(points), ui.theme.SecondaryText, recorded))
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("[%s]%-9s[-] [%s]%s[-] %d\n", ui.theme.Label, row.label, row.color, sparkline(row.values), row.values[len(row.values)-1]))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// sparkline draws one bar per value, scaled to the largest value. Zero is always the lowest bar.
+func sparkline(values []int) string {
+	highest := 0
+	for _, v := range values {
+		highest = max(highest, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > 0 && v > 0 {
+			// Non-zero values use the bars above the lowest one
+			level = 1 + (v*(len(sparkBlocks)-2)+highest-1)/highest
+		}
+		sb.WriteRune(sparkBlocks[min(level, len(sparkBlocks)-1)])
+	}
+	return sb.String()
+}
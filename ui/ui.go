@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"github.com/dipsylala/veracode-tui/config"
 	"github.com/dipsylala/veracode-tui/services/annotations"
 	"github.com/dipsylala/veracode-tui/services/applications"
 	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/dipsylala/veracode-tui/services/findings/query"
 	"github.com/dipsylala/veracode-tui/services/identity"
+	"github.com/dipsylala/veracode-tui/services/policies"
+	"github.com/dipsylala/veracode-tui/services/reports"
+	"github.com/dipsylala/veracode-tui/snapshot"
 	"github.com/rivo/tview"
 )
 
@@ -16,7 +21,17 @@ type UI struct {
 	findingsService    *findings.Service
 	identityService    *identity.Service
 	annotationsService *annotations.Service
+	policiesService    *policies.Service
+	reportsService     *reports.Service
 	theme              *Theme
+	settings           *config.Settings         // Persisted preferences such as table layouts
+	bookmarks          *config.Bookmarks        // Saved findings views shown under Favorites
+	cache              cacheStatus              // Local response cache state for the status indicators
+	snapshots          *snapshot.Store          // Findings history; nil disables snapshots
+	version            string                   // Application version, recorded in generated SBOMs
+	credentials        *identity.APICredentials // Current API key, for the expiry indicator; nil until loaded
+	principal          *identity.Principal      // Current user, loaded once at startup; nil until loaded
+	capabilities       identity.Capabilities    // Actions the principal may perform, deciding which are offered
 
 	// Data
 	applications           []applications.Application
@@ -26,6 +41,7 @@ type UI struct {
 	totalApps              int
 	pageSize               int
 	searchQuery            string
+	appFilters             applicationFilters
 	selectedApp            *applications.Application
 	sandboxes              []applications.Sandbox
 	selectionIndex         int // -1 for policy, 0+ for sandbox index
@@ -33,7 +49,12 @@ type UI struct {
 	findingsScanFilter     findings.ScanFilterType
 	findingsSeverityFilter int // 0-5, 0 means no filter
 	findingsPolicyFilter   findings.PolicyFilterType
+	visibleFindings        []*findings.Finding // Findings shown after the quick filter, pointing into findings
+	findingsQuickFilter    string
+	findingsFilterQuery    *query.Query // Parsed quick filter when it is a filter expression
+	findingsFilterError    error        // Parse error when the quick filter is an invalid expression
 	selectedFinding        *findings.Finding
+	findingDetailReturn    func() // Returns from a finding detail opened outside the findings table, nil otherwise
 	staticCount            int64
 	dynamicCount           int64
 	scaCount               int64
@@ -54,6 +75,7 @@ type UI struct {
 	appInfoView     *tview.TextView
 	complianceView  *tview.TextView
 	recentScansView *tview.TextView
+	trendView       *tview.TextView
 	contextsTable   *tview.Table
 
 	// Views - Findings
@@ -61,6 +83,7 @@ type UI struct {
 	findingsFilter                 *tview.DropDown
 	findingsSeverityFilterDropdown *tview.DropDown
 	findingsPolicyFilterDropdown   *tview.DropDown
+	findingsQuickFilterInput       *tview.InputField
 	findingsCountsLabel            *tview.TextView
 	findingsTitleView              *tview.TextView
 	findingsFlex                   *tview.Flex
@@ -68,11 +91,21 @@ type UI struct {
 	findingAnnotationsView         *tview.TextView // Annotations view in finding detail
 }
 
-func NewUI(appService *applications.Service, findingsService *findings.Service, identityService *identity.Service, annotationsService *annotations.Service, theme *Theme) *UI {
+func NewUI(appService *applications.Service, findingsService *findings.Service, identityService *identity.Service, annotationsService *annotations.Service, policiesService *policies.Service, reportsService *reports.Service, theme *Theme) *UI {
 	if theme == nil {
 		theme = DefaultTheme()
 	}
 
+	// If the settings file cannot be read, keep preferences in memory for this session only
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+	bookmarks, err := config.LoadBookmarks()
+	if err != nil {
+		bookmarks = &config.Bookmarks{}
+	}
+
 	ui := &UI{
 		app:                    tview.NewApplication(),
 		pages:                  tview.NewPages(),
@@ -80,7 +113,11 @@ func NewUI(appService *applications.Service, findingsService *findings.Service,
 		findingsService:        findingsService,
 		identityService:        identityService,
 		annotationsService:     annotationsService,
+		policiesService:        policiesService,
+		reportsService:         reportsService,
 		theme:                  theme,
+		settings:               settings,
+		bookmarks:              bookmarks,
 		findingsScanFilter:     "STATIC",
 		findingsSeverityFilter: 0,
 		findingsPolicyFilter:   findings.PolicyFilterAll,
@@ -100,6 +137,10 @@ func (ui *UI) Run() error {
 
 	// Load initial data
 	go ui.loadApplications()
+	go ui.loadCredentialExpiry()
+	go func() {
+		_ = ui.loadPrincipal()
+	}()
 
 	// Set root and run
 	ui.app.SetRoot(ui.pages, true)
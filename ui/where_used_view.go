@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dipsylala/veracode-tui/portfolio"
+	"github.com/dipsylala/veracode-tui/services/findings"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// defaultWhereUsedFile is the file name suggested when exporting the search results
+const defaultWhereUsedFile = "where-used.csv"
+
+// showWhereUsedSearch asks for a CVE or component and then searches for it across all
+// applications matching the current search and filters
+func (ui *UI) showWhereUsedSearch(term string) {
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText("CVE-2021-44228, log4j-core or log4j-core@2.14.1")
+
+	form := ui.newStyledForm()
+	form.AddInputField("CVE or component", term, 50, nil, func(text string) {
+		term = text
+	})
+
+	closeForm := func() {
+		ui.pages.RemovePage("where-used-search")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+
+	form.AddButton("Search", func() {
+		search, err := portfolio.ParseSearch(term)
+		if err != nil {
+			statusText.SetText(fmt.Sprintf("[%s]%s[-]", ui.theme.Error, tview.Escape(err.Error())))
+			return
+		}
+		closeForm()
+		ui.showWhereUsed(search)
+	}).
+		AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(" Where Is This Used? ").
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused))
+
+	ui.pages.AddPage("where-used-search", modal(content, 3, 1), true, true)
+	ui.app.SetFocus(form)
+}
+
+// showWhereUsed searches the SCA findings of the policy scans and sandboxes of every matching
+// application, and lists the component versions found grouped by application. The component
+// paths of the highlighted row are shown below the table.
+func (ui *UI) showWhereUsed(search portfolio.Search) {
+	var usages []portfolio.Usage
+	loaded := false
+
+	summaryText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[%s]Loading applications...[-]", ui.theme.Pending))
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.GetColor(ui.theme.SelectionBackground)).
+		Foreground(tcell.GetColor(ui.theme.SelectionForeground)))
+
+	pathsText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+
+	statusText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	shortcuts := fmt.Sprintf("[%s]↑/↓[-] Navigate  [%s]/[-] New Search  [%s]e[-] Export CSV  [%s]ESC[-] Close",
+		ui.theme.Info, ui.theme.Info, ui.theme.Info, ui.theme.Info)
+	statusText.SetText(shortcuts)
+
+	table.SetSelectionChangedFunc(func(row, column int) {
+		if row < 1 || row > len(usages) {
+			pathsText.SetText("")
+			return
+		}
+		pathsText.SetText(ui.buildUsagePaths(&usages[row-1]))
+	})
+
+	closeView := func() {
+		ui.pages.RemovePage("where-used")
+		ui.app.SetFocus(ui.applicationsTable)
+	}
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeView()
+			return nil
+		}
+		if !loaded {
+			return event
+		}
+		switch event.Rune() {
+		case '/':
+			closeView()
+			ui.showWhereUsedSearch(search.String())
+			return nil
+		case 'e':
+			ui.showExportForm(" Export Search Results ", defaultWhereUsedFile, fmt.Sprintf("%d usages", len(usages)), func(w io.Writer) error {
+				return portfolio.WriteUsagesCSV(w, usages)
+			}, table, statusText, shortcuts)
+			return nil
+		}
+		return event
+	})
+
+	content := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summaryText, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(pathsText, 4, 0, false).
+		AddItem(statusText, 1, 0, false)
+	content.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Where Is %s Used? ", tview.Escape(search.String()))).
+		SetTitleAlign(tview.AlignCenter).
+		SetBorderColor(tcell.GetColor(ui.theme.BorderFocused)).
+		SetBorderPadding(0, 0, 1, 1)
+
+	ui.pages.AddPage("where-used", modal(content, 5, 4), true, true)
+	ui.app.SetFocus(table)
+
+	var names portfolio.SandboxNames
+	fetch := portfolio.WithSandboxes(ui.findingsService, ui.appService, findings.GetFindingsOptions{}, []string{string(findings.ScanTypeSCA)}, &names)
+	ui.collectFindings(ui.matchingApplicationsLoader(), fetch, summaryText, func(results []portfolio.AppFindings) {
+		usages = portfolio.FindUsages(results, search, &names)
+		loaded = true
+		ui.renderWhereUsed(summaryText, table, search, results, usages)
+	})
+}
+
+// renderWhereUsed fills the search summary and the table of usages. The application name is
+// only shown on the first row of each application.
+func (ui *UI) renderWhereUsed(summaryText *tview.TextView, table *tview.Table, search portfolio.Search, results []portfolio.AppFindings, usages []portfolio.Usage) {
+	failed := 0
+	for i := range results {
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]%s[-] found in [%s]%d[-] of %d applications (%d component versions)",
+		ui.theme.Label, tview.Escape(search.String()), ui.theme.Warning, portfolio.UsageApplications(usages), len(results), len(usages)))
+	if failed > 0 {
+		sb.WriteString(fmt.Sprintf("\n[%s]%d applications could not be searched[-]", ui.theme.Error, failed))
+	}
+	summaryText.SetText(sb.String())
+
+	table.Clear()
+	for col, header := range []string{"Application", "Context", "Component", "Version", "Sev", "CVEs"} {
+		table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.GetColor(ui.theme.ColumnHeader)).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+	}
+	if len(usages) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("Not found in any application").
+			SetTextColor(tcell.GetColor(ui.theme.SecondaryText)).
+			SetSelectable(false))
+		return
+	}
+
+	for i := range usages {
+		usage := &usages[i]
+		application := ""
+		if i == 0 || usages[i-1].AppGUID != usage.AppGUID {
+			application = usage.Application
+		}
+		table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(application)).SetTextColor(tcell.GetColor(ui.theme.Label)))
+		table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(usage.Context())))
+		table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(usage.Component)))
+		table.SetCell(i+1, 3, tview.NewTableCell(tview.Escape(usage.Version)))
+		table.SetCell(i+1, 4, tview.NewTableCell(fmt.Sprintf("%d", usage.MaxSeverity)).
+			SetTextColor(tcell.GetColor(ui.getSeverityColorHex(usage.MaxSeverity))))
+		table.SetCell(i+1, 5, tview.NewTableCell(tview.Escape(strings.Join(usage.CVEs, ", "))).SetExpansion(1))
+	}
+	table.Select(1, 0)
+}
+
+// buildUsagePaths lists the component paths of a usage
+func (ui *UI) buildUsagePaths(usage *portfolio.Usage) string {
+	if len(usage.Paths) == 0 {
+		return fmt.Sprintf("[%s]No component path reported[-]", ui.theme.SecondaryText)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s]Component paths:[-]", ui.theme.Label))
+	for _, path := range usage.Paths {
+		sb.WriteString("\n  " + tview.Escape(path))
+	}
+	return sb.String()
+}